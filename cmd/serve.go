@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -39,17 +41,26 @@ var serveCmd = &cobra.Command{
 		dbDriver := viper.GetString("db-driver")
 
 		var (
-			db            *sql.DB
-			err           error
-			chemLogRepo   repositories.ChemistryLogRepository
-			taskRepo      repositories.TaskRepository
-			equipRepo     repositories.EquipmentRepository
-			srRepo        repositories.ServiceRecordRepository
-			chemRepo      repositories.ChemicalRepository
-			userRepo      repositories.UserRepository
-			sessionRepo   repositories.SessionRepository
-			taskNotifRepo repositories.TaskNotificationRepository
-			milestoneRepo repositories.MilestoneRepository
+			db             *sql.DB
+			err            error
+			chemLogRepo    repositories.ChemistryLogRepository
+			taskRepo       repositories.TaskRepository
+			seriesRepo     repositories.TaskSeriesRepository
+			completionRepo repositories.TaskCompletionRepository
+			templateRepo   repositories.TaskTemplateRepository
+			equipRepo      repositories.EquipmentRepository
+			srRepo         repositories.ServiceRecordRepository
+			chemRepo       repositories.ChemicalRepository
+			movementRepo   repositories.StockMovementRepository
+			userRepo       repositories.UserRepository
+			sessionRepo    repositories.SessionRepository
+			taskNotifRepo  repositories.TaskNotificationRepository
+			milestoneRepo  repositories.MilestoneRepository
+			reminderRepo   repositories.ReminderRuleRepository
+			prefRepo       repositories.NotificationPreferenceRepository
+			hookRepo       repositories.WebhookRepository
+			deliveryRepo   repositories.WebhookDeliveryRepository
+			outboxRepo     repositories.OutboxRepository
 		)
 
 		switch dbDriver {
@@ -66,13 +77,22 @@ var serveCmd = &cobra.Command{
 
 			chemLogRepo = sqlite.NewChemistryLogRepo(db)
 			taskRepo = sqlite.NewTaskRepo(db)
+			seriesRepo = sqlite.NewTaskSeriesRepo(db)
+			completionRepo = sqlite.NewTaskCompletionRepo(db)
+			templateRepo = sqlite.NewTaskTemplateRepo(db)
 			equipRepo = sqlite.NewEquipmentRepo(db)
 			srRepo = sqlite.NewServiceRecordRepo(db)
 			chemRepo = sqlite.NewChemicalRepo(db)
+			movementRepo = sqlite.NewStockMovementRepo(db)
 			userRepo = sqlite.NewUserRepo(db)
 			sessionRepo = sqlite.NewSessionRepo(db)
 			taskNotifRepo = sqlite.NewTaskNotificationRepo(db)
 			milestoneRepo = sqlite.NewMilestoneRepo(db)
+			reminderRepo = sqlite.NewReminderRuleRepo(db)
+			prefRepo = sqlite.NewNotificationPreferenceRepo(db)
+			hookRepo = sqlite.NewWebhookRepo(db)
+			deliveryRepo = sqlite.NewWebhookDeliveryRepo(db)
+			outboxRepo = sqlite.NewOutboxRepo(db)
 
 		case "postgres":
 			db, err = postgres.Open(dbDSN)
@@ -87,13 +107,22 @@ var serveCmd = &cobra.Command{
 
 			chemLogRepo = postgres.NewChemistryLogRepo(db)
 			taskRepo = postgres.NewTaskRepo(db)
+			seriesRepo = postgres.NewTaskSeriesRepo(db)
+			completionRepo = postgres.NewTaskCompletionRepo(db)
+			templateRepo = postgres.NewTaskTemplateRepo(db)
 			equipRepo = postgres.NewEquipmentRepo(db)
 			srRepo = postgres.NewServiceRecordRepo(db)
 			chemRepo = postgres.NewChemicalRepo(db)
+			movementRepo = postgres.NewStockMovementRepo(db)
 			userRepo = postgres.NewUserRepo(db)
 			sessionRepo = postgres.NewSessionRepo(db)
 			taskNotifRepo = postgres.NewTaskNotificationRepo(db)
 			milestoneRepo = postgres.NewMilestoneRepo(db)
+			reminderRepo = postgres.NewReminderRuleRepo(db)
+			prefRepo = postgres.NewNotificationPreferenceRepo(db)
+			hookRepo = postgres.NewWebhookRepo(db)
+			deliveryRepo = postgres.NewWebhookDeliveryRepo(db)
+			outboxRepo = postgres.NewOutboxRepo(db)
 
 		default:
 			return fmt.Errorf("unsupported database driver: %s (use 'sqlite' or 'postgres')", dbDriver)
@@ -104,46 +133,85 @@ var serveCmd = &cobra.Command{
 
 		var demoSeedSvc *services.DemoSeedService
 		if demoMode {
-			demoSeedSvc = services.NewDemoSeedService(userRepo, chemLogRepo, taskRepo, equipRepo, srRepo, chemRepo)
+			demoSeedSvc = services.NewDemoSeedService(userRepo, chemLogRepo, taskRepo, seriesRepo, equipRepo, srRepo, chemRepo)
 			slog.Info("Demo mode enabled", "maxDemoUsers", maxDemoUsers)
 		}
 
-		authSvc := services.NewAuthService(userRepo, sessionRepo, demoMode, maxDemoUsers, demoSeedSvc)
-		userSvc := services.NewUserService(userRepo, sessionRepo)
-		chemSvc := services.NewChemistryService(chemLogRepo)
-		taskSvc := services.NewTaskService(taskRepo)
-		equipSvc := services.NewEquipmentService(equipRepo, srRepo)
-		chemicSvc := services.NewChemicalService(chemRepo)
-
 		// Set up notification service
 		var emailNotifier services.Notifier
 		var smsNotifier services.Notifier
+		var twilioNotifier *notify.TwilioNotifier
 
-		if apiKey := viper.GetString("resend_api_key"); apiKey != "" {
-			from := viper.GetString("resend_from")
-			if from == "" {
-				from = "notifications@poolvibes.app"
-			}
-			emailNotifier = notify.NewResendNotifier(apiKey, from)
-			slog.Info("Email notifications enabled", "provider", "Resend")
+		emailNotifier, err = newEmailNotifier()
+		if err != nil {
+			return err
 		}
 
 		if sid := viper.GetString("twilio_account_sid"); sid != "" {
 			token := viper.GetString("twilio_auth_token")
 			fromNum := viper.GetString("twilio_from_number")
 			if token != "" && fromNum != "" {
-				smsNotifier = notify.NewTwilioNotifier(sid, token, fromNum)
+				twilioNotifier = notify.NewTwilioNotifier(sid, token, fromNum)
+				smsNotifier = twilioNotifier
 				slog.Info("SMS notifications enabled", "provider", "Twilio")
 			}
 		}
 
+		// Push notifications go to each user's own ntfy topic or Gotify
+		// server, so there is nothing to configure.
+		pushNotifier := notify.NewPushNotifier(viper.GetBool("allow-private-urls"))
+
+		authSvc := services.NewAuthService(userRepo, sessionRepo, prefRepo, demoMode, maxDemoUsers, demoSeedSvc)
+		userSvc := services.NewUserService(userRepo, sessionRepo, smsNotifier, pushNotifier)
+		alertInterval, err := time.ParseDuration(viper.GetString("alert-check-interval"))
+		if err != nil {
+			alertInterval = time.Hour
+		}
+		outboxInterval, err := time.ParseDuration(viper.GetString("outbox-interval"))
+		if err != nil {
+			outboxInterval = time.Minute
+		}
+		outboxSvc := services.NewOutboxService(outboxRepo, userRepo, emailNotifier, smsNotifier, pushNotifier, outboxInterval)
+		// Unsubscribe links in emails are signed with secret_key. Without
+		// one, links made before a restart stop working.
+		secretKey := []byte(viper.GetString("secret_key"))
+		if len(secretKey) == 0 {
+			secretKey = make([]byte, 32)
+			rand.Read(secretKey)
+			if emailNotifier != nil {
+				slog.Warn("secret_key is not set, so unsubscribe links in emails stop working when the server restarts")
+			}
+		}
+		unsubSvc := services.NewUnsubscribeService(prefRepo, secretKey, viper.GetString("base-url"))
+		emails := services.NewEmailRenderer(viper.GetString("base-url"), unsubSvc)
+		alertSvc := services.NewAlertService(userRepo, prefRepo, chemLogRepo, equipRepo, outboxSvc, emails, alertInterval)
+		webhookInterval, err := time.ParseDuration(viper.GetString("webhook-interval"))
+		if err != nil {
+			webhookInterval = time.Minute
+		}
+		webhookSvc := services.NewWebhookService(hookRepo, deliveryRepo, taskRepo, userRepo, taskNotifRepo, notify.NewWebhookSender(viper.GetBool("allow-private-urls")), webhookInterval)
+		chemSvc := services.NewChemistryService(chemLogRepo, alertSvc, webhookSvc)
+		taskSvc := services.NewTaskService(taskRepo, seriesRepo, completionRepo, chemLogRepo, srRepo, equipRepo, webhookSvc)
+		templateSvc := services.NewTaskTemplateService(templateRepo, taskRepo, seriesRepo)
+		equipSvc := services.NewEquipmentService(equipRepo, srRepo, taskRepo)
+		chemicSvc := services.NewChemicalService(chemRepo, movementRepo, chemLogRepo, alertSvc, webhookSvc)
+		calendarSvc := services.NewCalendarService(userRepo, taskRepo, seriesRepo, viper.GetInt("calendar-horizon-days"))
+		reminderSvc := services.NewReminderService(reminderRepo)
+
+		// Replies to text messages need the public URL Twilio signs.
+		var smsReplySvc *services.SMSReplyService
+		if baseURL := strings.TrimSuffix(viper.GetString("base-url"), "/"); twilioNotifier != nil && baseURL != "" {
+			smsReplySvc = services.NewSMSReplyService(userRepo, taskNotifRepo, taskSvc, twilioNotifier, baseURL+"/sms/inbound")
+			slog.Info("SMS replies enabled", "webhook", baseURL+"/sms/inbound")
+		}
+
 		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 		defer stop()
 
 		if demoMode {
 			cleanupSvc := services.NewDemoCleanupService(
-				userRepo, sessionRepo, chemLogRepo, taskRepo,
-				equipRepo, srRepo, chemRepo, taskNotifRepo, milestoneRepo,
+				userRepo, sessionRepo, chemLogRepo, taskRepo, seriesRepo, completionRepo, templateRepo,
+				equipRepo, srRepo, chemRepo, taskNotifRepo, milestoneRepo, reminderRepo,
 				15*time.Minute,
 			)
 			go cleanupSvc.Start(ctx)
@@ -165,17 +233,29 @@ var serveCmd = &cobra.Command{
 			}
 		}()
 
-		if emailNotifier != nil || smsNotifier != nil {
-			intervalStr := viper.GetString("notify-check-interval")
-			interval, err := time.ParseDuration(intervalStr)
-			if err != nil {
-				interval = 1 * time.Hour
-			}
-			notifSvc := services.NewNotificationService(taskRepo, userRepo, taskNotifRepo, emailNotifier, smsNotifier, interval)
-			go notifSvc.Start(ctx)
+		overdueInterval, err := time.ParseDuration(viper.GetString("overdue-check-interval"))
+		if err != nil {
+			overdueInterval = 5 * time.Minute
+		}
+		go services.NewOverdueService(taskRepo, userRepo, overdueInterval).Start(ctx)
+		usageInterval, err := time.ParseDuration(viper.GetString("usage-check-interval"))
+		if err != nil {
+			usageInterval = 24 * time.Hour
 		}
+		go services.NewUsageService(chemRepo, movementRepo, userRepo, usageInterval).Start(ctx)
+		go webhookSvc.Start(ctx)
 
-		server := web.NewServer(authSvc, userSvc, chemSvc, taskSvc, equipSvc, chemicSvc, milestoneRepo)
+		notifyInterval, err := time.ParseDuration(viper.GetString("notify-check-interval"))
+		if err != nil {
+			notifyInterval = 15 * time.Minute
+		}
+		notifSvc := services.NewNotificationService(taskRepo, userRepo, reminderRepo, prefRepo, chemLogRepo, outboxSvc, emails, smsReplySvc, notifyInterval)
+		go outboxSvc.Start(ctx)
+		go notifSvc.Start(ctx)
+		go alertSvc.Start(ctx)
+		go services.NewDigestService(userRepo, prefRepo, chemLogRepo, taskRepo, chemRepo, milestoneRepo, webhookSvc, outboxSvc, emails, notifyInterval).Start(ctx)
+
+		server := web.NewServer(authSvc, userSvc, chemSvc, taskSvc, templateSvc, equipSvc, chemicSvc, calendarSvc, reminderSvc, alertSvc, webhookSvc, outboxSvc, smsReplySvc, unsubSvc, milestoneRepo)
 		return server.Start(ctx, addr)
 	},
 }
@@ -184,20 +264,88 @@ func init() {
 	serveCmd.Flags().String("addr", ":8080", "server listen address")
 	serveCmd.Flags().String("db", defaultDBPath(), "database connection string")
 	serveCmd.Flags().String("db-driver", "sqlite", "database driver (sqlite or postgres)")
-	serveCmd.Flags().String("notify-check-interval", "1h", "how often to check for due task notifications")
+	serveCmd.Flags().String("base-url", "", "public URL of the app, e.g. https://pool.example.com, for links in emails")
+	serveCmd.Flags().String("notify-check-interval", "15m", "how often to check for reminders to send")
+	serveCmd.Flags().String("alert-check-interval", "1h", "how often to check for expiring warranties and lapsed water testing")
+	serveCmd.Flags().String("overdue-check-interval", "5m", "how often to mark tasks whose due day has passed as overdue")
+	serveCmd.Flags().String("usage-check-interval", "24h", "how often to recalculate each chemical's daily usage for forecasts")
+	serveCmd.Flags().String("outbox-interval", "1m", "how often to retry notifications that failed to send")
+	serveCmd.Flags().String("webhook-interval", "1m", "how often to retry failed webhook deliveries and check for tasks due")
+	serveCmd.Flags().Bool("allow-private-urls", false, "let webhooks and push notifications post to private, loopback and link-local addresses")
 	serveCmd.Flags().Bool("demo", false, "enable demo mode (new non-admin signups get seeded data, auto-expire in 24h)")
 	serveCmd.Flags().Int("demo-max-users", 50, "maximum number of concurrent demo users (0 = unlimited)")
+	serveCmd.Flags().Int("calendar-horizon-days", 90, "how many days ahead calendar feeds list recurring tasks")
 
 	viper.BindPFlag("addr", serveCmd.Flags().Lookup("addr"))
 	viper.BindPFlag("db", serveCmd.Flags().Lookup("db"))
 	viper.BindPFlag("db-driver", serveCmd.Flags().Lookup("db-driver"))
+	viper.BindPFlag("base-url", serveCmd.Flags().Lookup("base-url"))
 	viper.BindPFlag("notify-check-interval", serveCmd.Flags().Lookup("notify-check-interval"))
+	viper.BindPFlag("alert-check-interval", serveCmd.Flags().Lookup("alert-check-interval"))
+	viper.BindPFlag("overdue-check-interval", serveCmd.Flags().Lookup("overdue-check-interval"))
+	viper.BindPFlag("usage-check-interval", serveCmd.Flags().Lookup("usage-check-interval"))
+	viper.BindPFlag("outbox-interval", serveCmd.Flags().Lookup("outbox-interval"))
+	viper.BindPFlag("webhook-interval", serveCmd.Flags().Lookup("webhook-interval"))
+	viper.BindPFlag("allow-private-urls", serveCmd.Flags().Lookup("allow-private-urls"))
 	viper.BindPFlag("demo", serveCmd.Flags().Lookup("demo"))
 	viper.BindPFlag("demo-max-users", serveCmd.Flags().Lookup("demo-max-users"))
+	viper.BindPFlag("calendar-horizon-days", serveCmd.Flags().Lookup("calendar-horizon-days"))
 
 	rootCmd.AddCommand(serveCmd)
 }
 
+// newEmailNotifier returns the email provider chosen by email_provider, or
+// nil if email isn't configured. With no provider set, SMTP is used if
+// smtp_host is set, then Resend if resend_api_key is.
+func newEmailNotifier() (services.Notifier, error) {
+	provider := viper.GetString("email_provider")
+	if provider == "" {
+		switch {
+		case viper.GetString("smtp_host") != "":
+			provider = "smtp"
+		case viper.GetString("resend_api_key") != "":
+			provider = "resend"
+		default:
+			return nil, nil
+		}
+	}
+
+	switch provider {
+	case "smtp":
+		from := viper.GetString("smtp_from")
+		if from == "" {
+			from = "notifications@poolvibes.app"
+		}
+		n, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+			Host:     viper.GetString("smtp_host"),
+			Port:     viper.GetInt("smtp_port"),
+			Username: viper.GetString("smtp_username"),
+			Password: viper.GetString("smtp_password"),
+			From:     from,
+			ReplyTo:  viper.GetString("smtp_reply_to"),
+			Security: viper.GetString("smtp_security"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("configuring SMTP: %w", err)
+		}
+		slog.Info("Email notifications enabled", "provider", "SMTP", "host", viper.GetString("smtp_host"))
+		return n, nil
+	case "resend":
+		apiKey := viper.GetString("resend_api_key")
+		if apiKey == "" {
+			return nil, fmt.Errorf("email_provider is resend but resend_api_key is not set")
+		}
+		from := viper.GetString("resend_from")
+		if from == "" {
+			from = "notifications@poolvibes.app"
+		}
+		slog.Info("Email notifications enabled", "provider", "Resend")
+		return notify.NewResendNotifier(apiKey, from), nil
+	default:
+		return nil, fmt.Errorf("unknown email_provider %q (use smtp or resend)", provider)
+	}
+}
+
 func defaultDBPath() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
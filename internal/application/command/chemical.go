@@ -21,3 +21,14 @@ type AdjustChemicalStock struct {
 	ID    string
 	Delta float64
 }
+
+type RecordStockMovement struct {
+	ID     string
+	Kind   string
+	Amount float64
+	Reason string
+	// Cost is what the movement cost, usually a purchase's price. Nil if
+	// not recorded.
+	Cost           *float64
+	ChemistryLogID string
+}
@@ -2,19 +2,70 @@ package command
 
 import "time"
 
+// Scopes for edits and deletes of a recurring task.
+const (
+	TaskScopeOccurrence = "occurrence" // only the selected occurrence
+	TaskScopeFuture     = "future"     // the selected occurrence and everything after it
+)
+
 type CreateTask struct {
 	Name                string
 	Description         string
 	RecurrenceFrequency string
 	RecurrenceInterval  int
+	RecurrenceAnchor    string
 	DueDate             time.Time
+	// EquipmentID optionally links the task to the equipment it maintains.
+	EquipmentID string
+	// Checklist lists one step per line; a leading "?" marks a step optional.
+	Checklist string
 }
 
 type UpdateTask struct {
 	ID                  string
+	Scope               string
 	Name                string
 	Description         string
 	RecurrenceFrequency string
 	RecurrenceInterval  int
+	RecurrenceAnchor    string
 	DueDate             time.Time
+	// EquipmentID optionally links the task to the equipment it maintains.
+	EquipmentID string
+	// Checklist lists one step per line; a leading "?" marks a step optional.
+	Checklist string
+}
+
+type DeleteTask struct {
+	ID    string
+	Scope string
+}
+
+type CheckTaskItem struct {
+	TaskID  string
+	ItemID  string
+	Checked bool
+}
+
+type SnoozeTask struct {
+	ID   string
+	Days int
+}
+
+type CompleteTask struct {
+	ID              string
+	Notes           string
+	DurationMinutes int
+	ChemistryLogID  string
+	ServiceRecordID string
+	Photos          []TaskPhotoUpload
+	// LogService records a new service record on the task's equipment.
+	LogService        bool
+	ServiceCost       float64
+	ServiceTechnician string
+}
+
+type TaskPhotoUpload struct {
+	Filename string
+	Data     []byte
 }
@@ -0,0 +1,22 @@
+package command
+
+import "time"
+
+type ApplyTaskTemplate struct {
+	ID string
+	// StartDate is the day the schedule starts; zero means today.
+	StartDate time.Time
+}
+
+// SaveTaskTemplate saves the user's current open tasks as a template.
+type SaveTaskTemplate struct {
+	Name        string
+	Description string
+	SiteWide    bool
+}
+
+type UpdateTaskTemplate struct {
+	ID          string
+	Name        string
+	Description string
+}
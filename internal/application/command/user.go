@@ -9,7 +9,35 @@ type UpdateUser struct {
 
 type UpdateNotificationPreferences struct {
 	Phone       string
-	NotifyEmail bool
-	NotifySMS   bool
 	PoolGallons int
+	Timezone    string // IANA zone name; empty keeps the current zone
+}
+
+// UpdatePush sets where the user's push notifications go. An empty URL
+// turns them off.
+type UpdatePush struct {
+	Service string // entities.PushNtfy or entities.PushGotify
+	URL     string
+	Token   string
+}
+
+type CreateReminderRule struct {
+	OffsetDays int // days relative to the due date; negative is before it
+	SendHour   int
+}
+
+type UpdateAlertPreferences struct {
+	OptIns        []NotificationOptIn
+	NoTestDays    int
+	DigestWeekday int // 0 = Sunday
+	DigestHour    int
+	QuietHours    bool
+	QuietStart    int
+	QuietEnd      int
+}
+
+// NotificationOptIn turns on one notification category for one channel.
+type NotificationOptIn struct {
+	Category string
+	Channel  string
 }
@@ -0,0 +1,6 @@
+package command
+
+type CreateWebhook struct {
+	URL    string
+	Events []string
+}
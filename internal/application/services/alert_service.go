@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// WarrantyAlertDays is how far ahead expiring warranties are alerted on.
+const WarrantyAlertDays = 30
+
+// Limits on how long users can go without a test before being alerted.
+const (
+	MinNoTestAlertDays = 1
+	MaxNoTestAlertDays = 90
+)
+
+// AlertService sends the opt-in alerts: unsafe chemistry and low stock as
+// they happen, and expiring warranties and lapsed testing on a schedule.
+// Each alert is claimed per channel so it is sent at most once.
+type AlertService struct {
+	userRepo    repositories.UserRepository
+	prefRepo    repositories.NotificationPreferenceRepository
+	chemLogRepo repositories.ChemistryLogRepository
+	equipRepo   repositories.EquipmentRepository
+	outbox      *OutboxService
+	emails      *EmailRenderer
+	interval    time.Duration
+}
+
+func NewAlertService(
+	userRepo repositories.UserRepository,
+	prefRepo repositories.NotificationPreferenceRepository,
+	chemLogRepo repositories.ChemistryLogRepository,
+	equipRepo repositories.EquipmentRepository,
+	outbox *OutboxService,
+	emails *EmailRenderer,
+	interval time.Duration,
+) *AlertService {
+	return &AlertService{
+		userRepo:    userRepo,
+		prefRepo:    prefRepo,
+		chemLogRepo: chemLogRepo,
+		equipRepo:   equipRepo,
+		outbox:      outbox,
+		emails:      emails,
+		interval:    interval,
+	}
+}
+
+// Preferences returns the current user's alert opt-ins.
+func (s *AlertService) Preferences(ctx context.Context) (*entities.NotificationPreferences, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.prefRepo.FindByUserID(ctx, userID)
+}
+
+// UpdatePreferences replaces the current user's notification opt-ins and
+// sets how long without a test before they are alerted, when their weekly
+// digest is sent and their quiet hours.
+func (s *AlertService) UpdatePreferences(ctx context.Context, cmd command.UpdateAlertPreferences) (*entities.NotificationPreferences, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.NoTestDays < MinNoTestAlertDays || cmd.NoTestDays > MaxNoTestAlertDays {
+		return nil, fmt.Errorf("days without a test must be between %d and %d", MinNoTestAlertDays, MaxNoTestAlertDays)
+	}
+	digest := entities.DigestSchedule{Weekday: time.Weekday(cmd.DigestWeekday), Hour: cmd.DigestHour}
+	if err := digest.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	quiet := entities.QuietHours{Enabled: cmd.QuietHours, Start: cmd.QuietStart, End: cmd.QuietEnd}
+	if err := quiet.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	prefs := entities.NewNotificationPreferences(userID)
+	for _, o := range cmd.OptIns {
+		category, channel, err := parseOptIn(o)
+		if err != nil {
+			return nil, err
+		}
+		prefs.Set(category, channel, true)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	user.NoTestAlertDays = cmd.NoTestDays
+	user.Digest = digest
+	user.QuietHours = quiet
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.prefRepo.Save(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func parseOptIn(o command.NotificationOptIn) (entities.NotificationCategory, string, error) {
+	category := entities.NotificationCategory(o.Category)
+	known := false
+	for _, c := range entities.NotificationCategories {
+		if c == category {
+			known = true
+		}
+	}
+	if !known {
+		return "", "", fmt.Errorf("unknown notification category %q", o.Category)
+	}
+	for _, ch := range entities.NotificationChannels {
+		if ch == o.Channel {
+			return category, ch, nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown notification channel %q", o.Channel)
+}
+
+// ChemistryLogged alerts the current user if a new or edited chemistry log
+// has unsafe readings. Each log is alerted on at most once.
+func (s *AlertService) ChemistryLogged(ctx context.Context, log *entities.ChemistryLog) {
+	if s == nil {
+		return
+	}
+	readings := log.DangerousReadings()
+	if len(readings) == 0 {
+		return
+	}
+	s.alertCurrentUser(ctx, alert{
+		category: entities.CategoryChemistry,
+		key:      "chemistry:" + log.ID.String(),
+		date:     entities.DateOf(log.CreatedAt),
+		data:     chemistryAlertData{TestedAt: log.TestedAt, Readings: readings},
+	})
+}
+
+// StockChanged alerts the current user if a chemical's stock has just
+// become low from before, the amount prior to the change: dropped to its
+// alert threshold, or to within ReorderLeadDays of running out.
+func (s *AlertService) StockChanged(ctx context.Context, chem *entities.Chemical, before float64) {
+	if s == nil || !chem.CrossedThreshold(before) {
+		return
+	}
+	today := UserNow(ctx)
+	s.alertCurrentUser(ctx, alert{
+		category: entities.CategoryLowStock,
+		key:      "low_stock:" + chem.ID.String(),
+		date:     entities.DateOf(today),
+		data:     lowStockAlertData{Chemical: chem, Forecast: chem.Forecast(today)},
+	})
+}
+
+// alert is one alert to send. key and date identify it when claiming.
+type alert struct {
+	category entities.NotificationCategory
+	key      string
+	date     time.Time
+	data     any
+}
+
+func (s *AlertService) alertCurrentUser(ctx context.Context, a alert) {
+	user, err := UserFromContext(ctx)
+	if err != nil {
+		return
+	}
+	prefs, err := s.prefRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		slog.Error("Alert preferences error", "userID", user.ID, "error", err)
+		return
+	}
+	if !prefs.Wants(a.category) {
+		return
+	}
+	s.send(ctx, user, prefs, a)
+}
+
+func (s *AlertService) Start(ctx context.Context) {
+	slog.Info("Alert scheduler started", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.checkAll(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Alert scheduler stopped")
+			return
+		case <-ticker.C:
+			s.checkAll(ctx, time.Now())
+		}
+	}
+}
+
+// checkAll runs the scheduled alert checks for each timezone that has
+// users, so "today" is each user's own local day.
+func (s *AlertService) checkAll(ctx context.Context, now time.Time) {
+	zones, err := s.userRepo.FindTimezones(ctx)
+	if err != nil {
+		slog.Error("Alert check error", "error", err)
+		return
+	}
+	for _, zone := range zones {
+		s.checkZone(ctx, zone, now.In(entities.LoadTimezone(zone)))
+	}
+}
+
+func (s *AlertService) checkZone(ctx context.Context, zone string, now time.Time) {
+	prefs, err := s.prefRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Alert check error", "timezone", zone, "error", err)
+		return
+	}
+	if len(prefs) == 0 {
+		return
+	}
+	users, err := s.userRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Alert check error", "timezone", zone, "error", err)
+		return
+	}
+	usersByID := make(map[uuid.UUID]*entities.User, len(users))
+	for i := range users {
+		usersByID[users[i].ID] = &users[i]
+	}
+
+	today := entities.DateOf(now)
+	for _, p := range prefs {
+		user, ok := usersByID[p.UserID]
+		if !ok {
+			continue
+		}
+		if p.Wants(entities.CategoryWarranty) {
+			s.checkWarranties(ctx, user, p, today)
+		}
+		if p.Wants(entities.CategoryNoTest) {
+			s.checkTesting(ctx, user, p, today)
+		}
+	}
+}
+
+// checkWarranties alerts once per piece of equipment whose warranty runs
+// out within WarrantyAlertDays.
+func (s *AlertService) checkWarranties(ctx context.Context, user *entities.User, prefs *entities.NotificationPreferences, today time.Time) {
+	equipment, err := s.equipRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		slog.Error("Warranty check error", "userID", user.ID, "error", err)
+		return
+	}
+	for i := range equipment {
+		e := &equipment[i]
+		if !e.WarrantyExpiresWithin(today, WarrantyAlertDays) {
+			continue
+		}
+		expiry := entities.DateOf(*e.WarrantyExpiry)
+		s.send(ctx, user, prefs, alert{
+			category: entities.CategoryWarranty,
+			key:      "warranty:" + e.ID.String(),
+			date:     expiry,
+			data: warrantyAlertData{
+				Equipment: e,
+				Expiry:    expiry,
+				DaysLeft:  int(expiry.Sub(today).Hours() / 24),
+			},
+		})
+	}
+}
+
+// checkTesting alerts when the user's last chemistry test is at least
+// NoTestAlertDays old. The alert is keyed on the last test's date, so it is
+// sent once per gap in testing. Users who have never tested aren't alerted.
+func (s *AlertService) checkTesting(ctx context.Context, user *entities.User, prefs *entities.NotificationPreferences, today time.Time) {
+	result, err := s.chemLogRepo.FindPaged(ctx, user.ID, repositories.ChemistryLogQuery{
+		PageSize: 1,
+		SortBy:   "tested_at",
+		SortDir:  repositories.SortDesc,
+	})
+	if err != nil {
+		slog.Error("Testing check error", "userID", user.ID, "error", err)
+		return
+	}
+	if len(result.Items) == 0 {
+		return
+	}
+	lastTested := entities.DateOf(result.Items[0].TestedAt.In(user.Location()))
+	days := int(today.Sub(lastTested).Hours() / 24)
+	if days < user.NoTestAlertDays {
+		return
+	}
+	s.send(ctx, user, prefs, alert{
+		category: entities.CategoryNoTest,
+		key:      "no_test",
+		date:     lastTested,
+		data:     noTestAlertData{LastTested: lastTested, Days: days},
+	})
+}
+
+// send renders the alert and queues it on each channel the user has opted
+// into, claiming it as it is queued so it goes out at most once per channel.
+func (s *AlertService) send(ctx context.Context, user *entities.User, prefs *entities.NotificationPreferences, a alert) {
+	subject, body, err := renderAlert(a.category, a.data)
+	if err != nil {
+		slog.Error("Alert render error", "category", a.category, "error", err)
+		return
+	}
+	for _, channel := range entities.NotificationChannels {
+		if !prefs.IsEnabled(a.category, channel) || !s.outbox.Enabled(channel) {
+			continue
+		}
+		if user.Address(channel) == "" {
+			continue
+		}
+		msg := valueobjects.Message{Subject: subject, Text: body, URL: s.emails.appURL()}
+		if channel == entities.ChannelEmail {
+			if email, err := s.emails.alert(user, a.category, subject, body); err != nil {
+				slog.Error("Alert render error", "category", a.category, "error", err)
+			} else {
+				msg = email
+			}
+		}
+		notif := entities.NewAlertNotification(user.ID, channel, a.category, a.key, a.date)
+		queued, err := s.outbox.EnqueueFor(ctx, notif, user, msg)
+		if err != nil {
+			slog.Error("Alert queue error", "userID", user.ID, "channel", channel, "category", a.category, "error", err)
+			continue
+		}
+		if queued {
+			slog.Info("Alert queued", "category", a.category, "key", a.key, "channel", channel, "userID", user.ID)
+		}
+	}
+}
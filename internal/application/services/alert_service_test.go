@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type mockPrefRepo struct {
+	prefs map[uuid.UUID]*entities.NotificationPreferences
+}
+
+func (m *mockPrefRepo) FindByUserID(_ context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	if p, ok := m.prefs[userID]; ok {
+		return p, nil
+	}
+	return entities.NewNotificationPreferences(userID), nil
+}
+
+func (m *mockPrefRepo) FindByTimezone(_ context.Context, timezone string) ([]*entities.NotificationPreferences, error) {
+	var out []*entities.NotificationPreferences
+	for _, p := range m.prefs {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (m *mockPrefRepo) Save(_ context.Context, prefs *entities.NotificationPreferences) error {
+	if m.prefs == nil {
+		m.prefs = make(map[uuid.UUID]*entities.NotificationPreferences)
+	}
+	m.prefs[prefs.UserID] = prefs
+	return nil
+}
+
+type mockChemLogRepo struct {
+	logs []entities.ChemistryLog
+}
+
+func (m *mockChemLogRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.ChemistryLog, error) {
+	return m.logs, nil
+}
+
+// FindPaged returns the logs newest first, ignoring the rest of the query.
+func (m *mockChemLogRepo) FindPaged(_ context.Context, userID uuid.UUID, query repositories.ChemistryLogQuery) (*repositories.PagedResult[entities.ChemistryLog], error) {
+	var items []entities.ChemistryLog
+	for _, l := range m.logs {
+		if len(items) == 0 || l.TestedAt.After(items[0].TestedAt) {
+			items = []entities.ChemistryLog{l}
+		}
+	}
+	return &repositories.PagedResult[entities.ChemistryLog]{Items: items}, nil
+}
+
+func (m *mockChemLogRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.ChemistryLog, error) {
+	for _, l := range m.logs {
+		if l.ID == id && l.UserID == userID {
+			return &l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockChemLogRepo) Create(_ context.Context, log *entities.ChemistryLog) error {
+	m.logs = append(m.logs, *log)
+	return nil
+}
+
+func (m *mockChemLogRepo) Update(_ context.Context, log *entities.ChemistryLog) error {
+	return nil
+}
+
+func (m *mockChemLogRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return nil
+}
+
+// newAlertTest returns an alert service for one user opted into category
+// on the given channels.
+func newAlertTest(user *entities.User, category entities.NotificationCategory, channels ...string) (*AlertService, *recordingNotifier, *recordingNotifier, *mockEquipmentRepo, *mockChemLogRepo) {
+	prefs := entities.NewNotificationPreferences(user.ID)
+	for _, ch := range channels {
+		prefs.Set(category, ch, true)
+	}
+	email, sms := &recordingNotifier{}, &recordingNotifier{}
+	equip, logs := &mockEquipmentRepo{}, &mockChemLogRepo{}
+	outbox, _ := newTestOutbox(email, sms, nil)
+	svc := NewAlertService(
+		&mockUserRepo{users: []*entities.User{user}},
+		&mockPrefRepo{prefs: map[uuid.UUID]*entities.NotificationPreferences{user.ID: prefs}},
+		logs, equip, outbox, NewEmailRenderer("", nil), time.Hour,
+	)
+	return svc, email, sms, equip, logs
+}
+
+// phoneVerifiedAt marks test users' phone numbers verified, so SMS is sent
+// to them.
+var phoneVerifiedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func alertUser() *entities.User {
+	return &entities.User{ID: uuid.New(), Email: "a@example.com", Phone: "+15551234567", PhoneVerifiedAt: &phoneVerifiedAt, Timezone: "UTC", NoTestAlertDays: 7}
+}
+
+func TestAlertService_ChemistryLogged(t *testing.T) {
+	user := alertUser()
+	svc, email, sms, _, _ := newAlertTest(user, entities.CategoryChemistry, entities.ChannelEmail)
+	chemSvc := NewChemistryService(&mockChemLogRepo{}, svc, nil)
+	ctx := WithUser(context.Background(), user)
+
+	safe := command.CreateChemistryLog{PH: 7.4, FreeChlorine: 3, TestedAt: time.Now()}
+	if _, err := chemSvc.Create(ctx, safe); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(email.sent) != 0 {
+		t.Fatalf("safe reading alerted: %v", email.subjects)
+	}
+
+	unsafe := command.CreateChemistryLog{PH: 8.4, FreeChlorine: 0.5, TestedAt: time.Now()}
+	log, err := chemSvc.Create(ctx, unsafe)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(email.sent))
+	}
+	if len(sms.sent) != 0 {
+		t.Errorf("sent SMS without opting in")
+	}
+
+	// Saving the same log again doesn't alert twice.
+	svc.ChemistryLogged(ctx, log)
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Errorf("sent %d emails after re-saving the log, want 1", len(email.sent))
+	}
+}
+
+func TestAlertService_ChemistryBody(t *testing.T) {
+	_, body, err := renderAlert(entities.CategoryChemistry, chemistryAlertData{
+		TestedAt: time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC),
+		Readings: []string{"pH is 8.4 (above 8.0)"},
+	})
+	if err != nil {
+		t.Fatalf("renderAlert() error = %v", err)
+	}
+	if !strings.Contains(body, "Mar 10 at 9:30 AM") || !strings.Contains(body, "- pH is 8.4 (above 8.0)") {
+		t.Errorf("unexpected body:\n%s", body)
+	}
+}
+
+func TestAlertService_StockChanged_OnlyOnCrossing(t *testing.T) {
+	user := alertUser()
+	svc, email, _, _, _ := newAlertTest(user, entities.CategoryLowStock, entities.ChannelEmail)
+	ctx := WithUser(context.Background(), user)
+	stock, _ := valueobjects.NewQuantity(4, valueobjects.UnitPounds)
+	chem := entities.NewChemical(user.ID, "Shock", entities.ChemicalTypeShock, stock, 5)
+
+	// Already low before the change: no alert.
+	svc.StockChanged(ctx, chem, 4.5)
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 0 {
+		t.Fatalf("alerted while already low")
+	}
+	svc.StockChanged(ctx, chem, 6)
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Fatalf("sent %d emails on crossing, want 1", len(email.sent))
+	}
+	if !strings.Contains(email.subjects[0], "Shock") {
+		t.Errorf("subject = %q, want the chemical name", email.subjects[0])
+	}
+}
+
+func TestAlertService_StockChanged_RunningOut(t *testing.T) {
+	user := alertUser()
+	svc, email, _, _, _ := newAlertTest(user, entities.CategoryLowStock, entities.ChannelEmail)
+	ctx := WithUser(context.Background(), user)
+	stock, _ := valueobjects.NewQuantity(4, valueobjects.UnitPounds)
+	chem := entities.NewChemical(user.ID, "Shock", entities.ChemicalTypeShock, stock, 1)
+	chem.DailyUsage = 0.8
+
+	// 7.5 days left before, 5 after: within the lead time, though still
+	// above the alert threshold.
+	svc.StockChanged(ctx, chem, 6)
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Fatalf("sent %d emails on running low, want 1", len(email.sent))
+	}
+	body := email.msgs[0].Text
+	for _, want := range []string{"about 5 days", "0.8 lbs a day", "Order about 26 lbs"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body = %q, want %q", body, want)
+		}
+	}
+}
+
+func TestAlertService_Warranty(t *testing.T) {
+	user := alertUser()
+	user.Timezone = "America/Los_Angeles"
+	svc, email, sms, equip, _ := newAlertTest(user, entities.CategoryWarranty, entities.ChannelEmail, entities.ChannelSMS)
+	soon := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	equip.items = []entities.Equipment{
+		{ID: uuid.New(), UserID: user.ID, Name: "Pump", WarrantyExpiry: &soon},
+		{ID: uuid.New(), UserID: user.ID, Name: "Heater", WarrantyExpiry: &later},
+	}
+
+	// 03:00 UTC on Mar 3 is still Mar 2 in Los Angeles, 30 days before
+	// the pump's expiry.
+	svc.checkAll(context.Background(), time.Date(2025, 3, 3, 3, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 || len(sms.sent) != 1 {
+		t.Fatalf("sent %d emails and %d SMS, want 1 each", len(email.sent), len(sms.sent))
+	}
+	svc.checkAll(context.Background(), time.Date(2025, 3, 4, 3, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Errorf("warranty alerted %d times, want once", len(email.sent))
+	}
+}
+
+func TestAlertService_NoTest(t *testing.T) {
+	user := alertUser()
+	svc, email, _, _, logs := newAlertTest(user, entities.CategoryNoTest, entities.ChannelEmail)
+	ctx := context.Background()
+
+	// Never tested: nothing to alert on.
+	svc.checkAll(ctx, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 0 {
+		t.Fatalf("alerted with no tests logged")
+	}
+
+	logs.logs = []entities.ChemistryLog{{ID: uuid.New(), UserID: user.ID, TestedAt: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}}
+	svc.checkAll(ctx, time.Date(2025, 3, 7, 12, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 0 {
+		t.Fatalf("alerted after 6 days, want 7")
+	}
+	svc.checkAll(ctx, time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC))
+	svc.checkAll(ctx, time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Fatalf("sent %d emails for one gap, want 1", len(email.sent))
+	}
+
+	// A new test starts a new gap.
+	logs.logs = append(logs.logs, entities.ChemistryLog{ID: uuid.New(), UserID: user.ID, TestedAt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)})
+	svc.checkAll(ctx, time.Date(2025, 3, 17, 12, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 2 {
+		t.Errorf("sent %d emails after a second gap, want 2", len(email.sent))
+	}
+}
+
+func TestAlertService_UpdatePreferences(t *testing.T) {
+	user := alertUser()
+	svc, _, _, _, _ := newAlertTest(user, entities.CategoryChemistry)
+	ctx := WithUser(context.Background(), user)
+
+	prefs, err := svc.UpdatePreferences(ctx, command.UpdateAlertPreferences{
+		OptIns:        []command.NotificationOptIn{{Category: "warranty", Channel: "sms"}},
+		NoTestDays:    14,
+		DigestWeekday: int(time.Friday),
+		DigestHour:    17,
+		QuietHours:    true,
+		QuietStart:    21,
+		QuietEnd:      8,
+	})
+	if err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+	if !prefs.IsEnabled(entities.CategoryWarranty, entities.ChannelSMS) || prefs.IsEnabled(entities.CategoryWarranty, entities.ChannelEmail) {
+		t.Errorf("opt-ins not applied")
+	}
+	saved, _ := svc.userRepo.FindByID(ctx, user.ID)
+	if saved.NoTestAlertDays != 14 {
+		t.Errorf("NoTestAlertDays = %d, want 14", saved.NoTestAlertDays)
+	}
+	if want := (entities.DigestSchedule{Weekday: time.Friday, Hour: 17}); saved.Digest != want {
+		t.Errorf("Digest = %+v, want %+v", saved.Digest, want)
+	}
+	if want := (entities.QuietHours{Enabled: true, Start: 21, End: 8}); saved.QuietHours != want {
+		t.Errorf("QuietHours = %+v, want %+v", saved.QuietHours, want)
+	}
+
+	bad := []command.UpdateAlertPreferences{
+		{NoTestDays: 0},
+		{NoTestDays: 7, OptIns: []command.NotificationOptIn{{Category: "bogus", Channel: "email"}}},
+		{NoTestDays: 7, OptIns: []command.NotificationOptIn{{Category: "warranty", Channel: "pigeon"}}},
+		{NoTestDays: 7, DigestWeekday: 7},
+		{NoTestDays: 7, DigestHour: 24},
+		{NoTestDays: 7, QuietHours: true, QuietStart: 22, QuietEnd: 22},
+	}
+	for _, cmd := range bad {
+		if _, err := svc.UpdatePreferences(ctx, cmd); err == nil {
+			t.Errorf("UpdatePreferences(%+v) succeeded, want error", cmd)
+		}
+	}
+}
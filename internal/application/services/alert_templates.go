@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// alertTemplates holds a subject and body template for each alert
+// category, named "<category>.subject" and "<category>.body".
+var alertTemplates = template.Must(template.New("alerts").Parse(`
+{{define "chemistry.subject"}}PoolVibes: unsafe water chemistry{{end}}
+{{define "chemistry.body"}}Your water test on {{.TestedAt.Format "Jan 2 at 3:04 PM"}} found:
+{{range .Readings}}
+- {{.}}{{end}}
+
+Keep swimmers out of the pool until the water is balanced again.{{end}}
+
+{{define "low_stock.subject"}}PoolVibes: {{.Chemical.Name}} is running low{{end}}
+{{define "low_stock.body"}}You have {{printf "%.1f" .Chemical.Stock.Amount}} {{.Chemical.Stock.Unit}} of {{.Chemical.Name}} left
+{{- with .Forecast}}, enough for about {{.DaysLeft}} {{if eq .DaysLeft 1}}day{{else}}days{{end}} at your recent usage of {{printf "%.2g" .DailyUsage}} {{$.Chemical.Stock.Unit}} a day. Order about {{printf "%g" .ReorderAmount}} {{$.Chemical.Stock.Unit}} to cover the next month.
+{{- else}}, at or below your alert level of {{printf "%.1f" .Chemical.AlertThreshold}} {{.Chemical.Stock.Unit}}. Time to restock.{{end}}{{end}}
+
+{{define "warranty.subject"}}PoolVibes: {{.Equipment.Name}} warranty expires soon{{end}}
+{{define "warranty.body"}}The warranty on your {{.Equipment.Name}} expires on {{.Expiry.Format "Jan 2, 2006"}}{{if eq .DaysLeft 0}}, today{{else if eq .DaysLeft 1}}, tomorrow{{else}}, in {{.DaysLeft}} days{{end}}. If anything needs a warranty claim, now is the time.{{end}}
+
+{{define "no_test.subject"}}PoolVibes: time to test your water{{end}}
+{{define "no_test.body"}}Your pool hasn't been tested in {{.Days}} days. The last test was on {{.LastTested.Format "Jan 2"}}. Regular testing keeps the water safe and catches problems early.{{end}}
+`))
+
+type chemistryAlertData struct {
+	TestedAt time.Time
+	Readings []string
+}
+
+type lowStockAlertData struct {
+	Chemical *entities.Chemical
+	Forecast *entities.StockForecast // nil if the usage isn't known
+}
+
+type warrantyAlertData struct {
+	Equipment *entities.Equipment
+	Expiry    time.Time
+	DaysLeft  int
+}
+
+type noTestAlertData struct {
+	LastTested time.Time
+	Days       int
+}
+
+// renderAlert renders the subject and body of an alert from its
+// category's templates.
+func renderAlert(category entities.NotificationCategory, data any) (subject, body string, err error) {
+	var sb, bb strings.Builder
+	if err := alertTemplates.ExecuteTemplate(&sb, string(category)+".subject", data); err != nil {
+		return "", "", fmt.Errorf("rendering %s alert subject: %w", category, err)
+	}
+	if err := alertTemplates.ExecuteTemplate(&bb, string(category)+".body", data); err != nil {
+		return "", "", fmt.Errorf("rendering %s alert body: %w", category, err)
+	}
+	return sb.String(), bb.String(), nil
+}
@@ -19,15 +19,17 @@ const sessionDuration = 7 * 24 * time.Hour
 type AuthService struct {
 	userRepo     repositories.UserRepository
 	sessionRepo  repositories.SessionRepository
+	prefRepo     repositories.NotificationPreferenceRepository
 	demoMode     bool
 	maxDemoUsers int
 	demoSeedSvc  *DemoSeedService
 }
 
-func NewAuthService(userRepo repositories.UserRepository, sessionRepo repositories.SessionRepository, demoMode bool, maxDemoUsers int, demoSeedSvc *DemoSeedService) *AuthService {
+func NewAuthService(userRepo repositories.UserRepository, sessionRepo repositories.SessionRepository, prefRepo repositories.NotificationPreferenceRepository, demoMode bool, maxDemoUsers int, demoSeedSvc *DemoSeedService) *AuthService {
 	return &AuthService{
 		userRepo:     userRepo,
 		sessionRepo:  sessionRepo,
+		prefRepo:     prefRepo,
 		demoMode:     demoMode,
 		maxDemoUsers: maxDemoUsers,
 		demoSeedSvc:  demoSeedSvc,
@@ -91,6 +93,9 @@ func (s *AuthService) SignUp(ctx context.Context, cmd command.SignUp) (*entities
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, nil, fmt.Errorf("creating user: %w", err)
 	}
+	if err := s.prefRepo.Save(ctx, entities.DefaultNotificationPreferences(user.ID)); err != nil {
+		return nil, nil, fmt.Errorf("saving notification preferences: %w", err)
+	}
 
 	// Seed demo data for demo users
 	if user.IsDemo && s.demoSeedSvc != nil {
@@ -43,6 +43,15 @@ func (m *mockUserRepo) FindByEmail(_ context.Context, email string) (*entities.U
 	return nil, nil
 }
 
+func (m *mockUserRepo) FindByCalendarToken(_ context.Context, token string) (*entities.User, error) {
+	for _, u := range m.users {
+		if token != "" && u.CalendarToken == token {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *mockUserRepo) Create(_ context.Context, user *entities.User) error {
 	m.users = append(m.users, user)
 	return nil
@@ -98,6 +107,38 @@ func (m *mockUserRepo) FindExpiredDemo(_ context.Context, now time.Time) ([]enti
 	return result, nil
 }
 
+func (m *mockUserRepo) FindTimezones(_ context.Context) ([]string, error) {
+	seen := map[string]bool{}
+	var zones []string
+	for _, u := range m.users {
+		if !u.IsDisabled && !seen[u.Timezone] {
+			seen[u.Timezone] = true
+			zones = append(zones, u.Timezone)
+		}
+	}
+	return zones, nil
+}
+
+func (m *mockUserRepo) FindByTimezone(_ context.Context, timezone string) ([]entities.User, error) {
+	var users []entities.User
+	for _, u := range m.users {
+		if !u.IsDisabled && u.Timezone == timezone {
+			users = append(users, *u)
+		}
+	}
+	return users, nil
+}
+
+func (m *mockUserRepo) FindByPhone(_ context.Context, phone string) ([]entities.User, error) {
+	var users []entities.User
+	for _, u := range m.users {
+		if !u.IsDisabled && u.Phone == phone {
+			users = append(users, *u)
+		}
+	}
+	return users, nil
+}
+
 type mockSessionRepo struct {
 	sessions []*entities.Session
 }
@@ -191,7 +232,7 @@ func TestAuthService_SignUp(t *testing.T) {
 					PasswordHash: "hash",
 				})
 			}
-			svc := NewAuthService(userRepo, sessionRepo, false, 0, nil)
+			svc := NewAuthService(userRepo, sessionRepo, &mockPrefRepo{}, false, 0, nil)
 
 			user, session, err := svc.SignUp(context.Background(), tt.cmd)
 			if tt.wantErr != "" {
@@ -229,7 +270,7 @@ func TestAuthService_SignUp_DuplicateEmail(t *testing.T) {
 	userRepo := &mockUserRepo{
 		users: []*entities.User{{ID: uuid.New(), Email: "dup@pool.com", PasswordHash: "hash"}},
 	}
-	svc := NewAuthService(userRepo, &mockSessionRepo{}, false, 0, nil)
+	svc := NewAuthService(userRepo, &mockSessionRepo{}, &mockPrefRepo{}, false, 0, nil)
 
 	_, _, err := svc.SignUp(context.Background(), command.SignUp{
 		Email:    "dup@pool.com",
@@ -243,6 +284,20 @@ func TestAuthService_SignUp_DuplicateEmail(t *testing.T) {
 	}
 }
 
+func TestAuthService_SignUp_DefaultPreferences(t *testing.T) {
+	prefRepo := &mockPrefRepo{}
+	svc := NewAuthService(&mockUserRepo{}, &mockSessionRepo{}, prefRepo, false, 0, nil)
+
+	user, _, err := svc.SignUp(context.Background(), command.SignUp{Email: "new@pool.com", Password: "myStr0ngP@ss!"})
+	if err != nil {
+		t.Fatalf("SignUp() error = %v", err)
+	}
+	prefs := prefRepo.prefs[user.ID]
+	if prefs == nil || !prefs.IsEnabled(entities.CategoryReminders, entities.ChannelEmail) || prefs.IsEnabled(entities.CategoryReminders, entities.ChannelSMS) {
+		t.Errorf("new user's preferences = %+v, want task reminders by email", prefs)
+	}
+}
+
 // --- SignIn tests ---
 
 func TestAuthService_SignIn(t *testing.T) {
@@ -288,7 +343,7 @@ func TestAuthService_SignIn(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := &mockUserRepo{users: []*entities.User{activeUser, disabledUser}}
 			sessionRepo := &mockSessionRepo{}
-			svc := NewAuthService(userRepo, sessionRepo, false, 0, nil)
+			svc := NewAuthService(userRepo, sessionRepo, &mockPrefRepo{}, false, 0, nil)
 
 			user, session, err := svc.SignIn(context.Background(), tt.cmd)
 			if tt.wantErr != "" {
@@ -320,7 +375,7 @@ func TestAuthService_SignOut(t *testing.T) {
 	sessionRepo := &mockSessionRepo{
 		sessions: []*entities.Session{{ID: sessionID, UserID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}},
 	}
-	svc := NewAuthService(&mockUserRepo{}, sessionRepo, false, 0, nil)
+	svc := NewAuthService(&mockUserRepo{}, sessionRepo, &mockPrefRepo{}, false, 0, nil)
 
 	if err := svc.SignOut(context.Background(), sessionID.String()); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -331,7 +386,7 @@ func TestAuthService_SignOut(t *testing.T) {
 }
 
 func TestAuthService_SignOut_InvalidID(t *testing.T) {
-	svc := NewAuthService(&mockUserRepo{}, &mockSessionRepo{}, false, 0, nil)
+	svc := NewAuthService(&mockUserRepo{}, &mockSessionRepo{}, &mockPrefRepo{}, false, 0, nil)
 	if err := svc.SignOut(context.Background(), "not-a-uuid"); err == nil {
 		t.Fatal("expected error for invalid session ID")
 	}
@@ -364,7 +419,7 @@ func TestAuthService_GetUserBySession(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := &mockUserRepo{users: []*entities.User{user, disabledUser}}
 			sessionRepo := &mockSessionRepo{sessions: []*entities.Session{validSession, expiredSession, disabledSession}}
-			svc := NewAuthService(userRepo, sessionRepo, false, 0, nil)
+			svc := NewAuthService(userRepo, sessionRepo, &mockPrefRepo{}, false, 0, nil)
 
 			u, err := svc.GetUserBySession(context.Background(), tt.sid)
 			if tt.wantErr {
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+// CalendarEntry is one day a task is due in a calendar feed. Projected
+// entries are later occurrences of a recurring task that have not been
+// created yet.
+type CalendarEntry struct {
+	Task      entities.Task
+	Due       time.Time
+	Projected bool
+}
+
+type CalendarService struct {
+	userRepo   repositories.UserRepository
+	taskRepo   repositories.TaskRepository
+	seriesRepo repositories.TaskSeriesRepository
+	horizon    int
+}
+
+// NewCalendarService creates a service whose feeds project recurring tasks
+// horizonDays ahead.
+func NewCalendarService(userRepo repositories.UserRepository, taskRepo repositories.TaskRepository, seriesRepo repositories.TaskSeriesRepository, horizonDays int) *CalendarService {
+	return &CalendarService{userRepo: userRepo, taskRepo: taskRepo, seriesRepo: seriesRepo, horizon: horizonDays}
+}
+
+// Feed returns the open tasks of the user whose calendar token matches,
+// with recurring tasks expanded up to the horizon, soonest first. It
+// returns nil for an unknown token or a disabled user.
+func (s *CalendarService) Feed(ctx context.Context, token string, now time.Time) ([]CalendarEntry, error) {
+	user, err := s.userRepo.FindByCalendarToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsDisabled {
+		return nil, nil
+	}
+	tasks, err := s.taskRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := entities.DateOf(now.In(user.Location()))
+	until := today.AddDate(0, 0, s.horizon)
+	entries := []CalendarEntry{}
+	for _, t := range tasks {
+		if !t.IsOpen() {
+			continue
+		}
+		series, err := s.seriesRepo.FindByID(ctx, user.ID, t.SeriesID)
+		if err != nil {
+			return nil, fmt.Errorf("loading task series: %w", err)
+		}
+		if series != nil && !series.IsActive() {
+			entries = append(entries, CalendarEntry{Task: t, Due: t.EffectiveDueDate()})
+			continue
+		}
+		for _, due := range t.UpcomingDueDates(until) {
+			projected := !due.Equal(t.EffectiveDueDate())
+			// An overdue task would project occurrences into the past; the
+			// next one is only scheduled once the overdue one is done.
+			if projected && due.Before(today) {
+				continue
+			}
+			entries = append(entries, CalendarEntry{Task: t, Due: due, Projected: projected})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Due.Before(entries[j].Due) })
+	return entries, nil
+}
+
+// TaskEntry returns a single open or closed task as a calendar entry.
+func (s *CalendarService) TaskEntry(ctx context.Context, id string) (*CalendarEntry, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+	t, err := s.taskRepo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, nil
+	}
+	return &CalendarEntry{Task: *t, Due: t.EffectiveDueDate()}, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+func newTestCalendarService(user *entities.User, tasks []entities.Task, series []entities.TaskSeries) *CalendarService {
+	return NewCalendarService(&mockUserRepo{users: []*entities.User{user}}, &mockTaskRepo{tasks: tasks}, &mockTaskSeriesRepo{series: series}, 21)
+}
+
+// calendarTask creates an open weekly task with its series.
+func calendarTask(userID uuid.UUID, name string, due time.Time) (entities.Task, entities.TaskSeries) {
+	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 1)
+	task := entities.NewTask(userID, name, "", rec, due)
+	series := entities.TaskSeries{ID: task.SeriesID, UserID: userID, Recurrence: rec}
+	return *task, series
+}
+
+func dueDates(entries []CalendarEntry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.Due.Format("2006-01-02"))
+	}
+	return out
+}
+
+func TestCalendarService_Feed_ExpandsToHorizon(t *testing.T) {
+	user := &entities.User{ID: uuid.New()}
+	user.RegenerateCalendarToken()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	weekly, weeklySeries := calendarTask(user.ID, "Skim", time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	overdue, overdueSeries := calendarTask(user.ID, "Brush", time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC))
+	svc := newTestCalendarService(user, []entities.Task{weekly, overdue}, []entities.TaskSeries{weeklySeries, overdueSeries})
+
+	entries, err := svc.Feed(context.Background(), user.CalendarToken, now)
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+
+	// The overdue task keeps its own date but projects nothing before today.
+	want := []string{"2025-02-15", "2025-03-01", "2025-03-03", "2025-03-08", "2025-03-10", "2025-03-15", "2025-03-17", "2025-03-22"}
+	got := dueDates(entries)
+	if len(got) != len(want) {
+		t.Fatalf("due dates = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("due dates = %v, want %v", got, want)
+		}
+	}
+	if entries[0].Projected || !entries[1].Projected {
+		t.Errorf("Projected = %v, %v; want false, true", entries[0].Projected, entries[1].Projected)
+	}
+}
+
+func TestCalendarService_Feed_SkipsClosedAndEndedSeries(t *testing.T) {
+	user := &entities.User{ID: uuid.New()}
+	user.RegenerateCalendarToken()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ended, endedSeries := calendarTask(user.ID, "Winterize", time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC))
+	endedSeries.End()
+	done, doneSeries := calendarTask(user.ID, "Shock", time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC))
+	done.Complete()
+	svc := newTestCalendarService(user, []entities.Task{ended, done}, []entities.TaskSeries{endedSeries, doneSeries})
+
+	entries, err := svc.Feed(context.Background(), user.CalendarToken, now)
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	if got := dueDates(entries); len(got) != 1 || got[0] != "2025-03-05" {
+		t.Errorf("due dates = %v, want [2025-03-05]", got)
+	}
+}
+
+func TestCalendarService_Feed_UnknownOrDisabled(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), IsDisabled: true}
+	user.RegenerateCalendarToken()
+	svc := newTestCalendarService(user, nil, nil)
+
+	for _, token := range []string{"", "nope", user.CalendarToken} {
+		entries, err := svc.Feed(context.Background(), token, time.Now())
+		if err != nil {
+			t.Fatalf("Feed(%q): %v", token, err)
+		}
+		if entries != nil {
+			t.Errorf("Feed(%q) = %v, want nil", token, entries)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/application/command"
@@ -12,11 +14,29 @@ import (
 )
 
 type ChemicalService struct {
-	repo repositories.ChemicalRepository
+	repo         repositories.ChemicalRepository
+	movementRepo repositories.StockMovementRepository
+	chemLogRepo  repositories.ChemistryLogRepository
+	alerts       *AlertService
+	webhooks     *WebhookService
 }
 
-func NewChemicalService(repo repositories.ChemicalRepository) *ChemicalService {
-	return &ChemicalService{repo: repo}
+// NewChemicalService creates the service. alerts and webhooks may be nil,
+// in which case no low stock alerts or webhook events are sent.
+func NewChemicalService(
+	repo repositories.ChemicalRepository,
+	movementRepo repositories.StockMovementRepository,
+	chemLogRepo repositories.ChemistryLogRepository,
+	alerts *AlertService,
+	webhooks *WebhookService,
+) *ChemicalService {
+	return &ChemicalService{
+		repo:         repo,
+		movementRepo: movementRepo,
+		chemLogRepo:  chemLogRepo,
+		alerts:       alerts,
+		webhooks:     webhooks,
+	}
 }
 
 func (s *ChemicalService) List(ctx context.Context) ([]entities.Chemical, error) {
@@ -78,20 +98,45 @@ func (s *ChemicalService) Update(ctx context.Context, cmd command.UpdateChemical
 	if err != nil {
 		return nil, fmt.Errorf("stock: %w", err)
 	}
+	before := chem.Stock.Amount
 	chem.Name = cmd.Name
 	chem.Type = entities.ChemicalType(cmd.Type)
-	chem.Stock = stock
 	chem.AlertThreshold = cmd.AlertThreshold
 	if err := chem.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
-	if err := s.repo.Update(ctx, chem); err != nil {
+	// A stock typed into the form is a hand count, so it is recorded as a
+	// correction rather than overwriting the ledger's balance.
+	if movements := chem.CorrectStock(stock, "Stock corrected"); len(movements) > 0 {
+		err = s.record(ctx, chem, movements...)
+	} else {
+		err = s.repo.Update(ctx, chem)
+	}
+	if err != nil {
 		return nil, err
 	}
+	s.stockChanged(ctx, chem, before)
 	return chem, nil
 }
 
+// AdjustStock applies a quick adjustment from the inventory's +/- buttons.
+// Taking stock out is recorded as a dose, so it counts towards usage.
 func (s *ChemicalService) AdjustStock(ctx context.Context, cmd command.AdjustChemicalStock) (*entities.Chemical, error) {
+	move := command.RecordStockMovement{
+		ID:     cmd.ID,
+		Kind:   string(entities.StockAdjustment),
+		Amount: cmd.Delta,
+		Reason: "Quick adjustment",
+	}
+	if cmd.Delta < 0 {
+		move.Kind, move.Amount = string(entities.StockDose), -cmd.Delta
+	}
+	return s.RecordMovement(ctx, move)
+}
+
+// RecordMovement records a purchase, dose, adjustment or disposal in the
+// chemical's stock ledger and updates its stock to match.
+func (s *ChemicalService) RecordMovement(ctx context.Context, cmd command.RecordStockMovement) (*entities.Chemical, error) {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -107,15 +152,46 @@ func (s *ChemicalService) AdjustStock(ctx context.Context, cmd command.AdjustChe
 	if chem == nil {
 		return nil, fmt.Errorf("chemical not found")
 	}
-	if err := chem.AdjustStock(cmd.Delta); err != nil {
+	chemLogID, err := s.resolveChemistryLog(ctx, userID, cmd.ChemistryLogID)
+	if err != nil {
+		return nil, err
+	}
+	before := chem.Stock.Amount
+	movement, err := chem.RecordMovement(entities.StockMovementKind(cmd.Kind), cmd.Amount, strings.TrimSpace(cmd.Reason), cmd.Cost)
+	if err != nil {
 		return nil, err
 	}
-	if err := s.repo.Update(ctx, chem); err != nil {
+	movement.ChemistryLogID = chemLogID
+	if err := s.record(ctx, chem, *movement); err != nil {
 		return nil, err
 	}
+	s.stockChanged(ctx, chem, before)
 	return chem, nil
 }
 
+// StockHistory is a chemical with its stock ledger.
+type StockHistory struct {
+	Chemical *entities.Chemical
+	Ledger   *entities.StockLedger
+}
+
+// History returns the chemical's stock movements with the balance after
+// each one and totals by kind.
+func (s *ChemicalService) History(ctx context.Context, id string) (*StockHistory, error) {
+	chem, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if chem == nil {
+		return nil, fmt.Errorf("chemical not found")
+	}
+	movements, err := s.movementRepo.FindByChemicalID(ctx, chem.UserID, chem.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &StockHistory{Chemical: chem, Ledger: entities.NewStockLedger(chem.Stock.Unit, movements)}, nil
+}
+
 func (s *ChemicalService) Delete(ctx context.Context, id string) error {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
@@ -127,3 +203,41 @@ func (s *ChemicalService) Delete(ctx context.Context, id string) error {
 	}
 	return s.repo.Delete(ctx, userID, uid)
 }
+
+// record saves movements to the chemical's ledger, updating its daily
+// usage to include them.
+func (s *ChemicalService) record(ctx context.Context, chem *entities.Chemical, movements ...entities.StockMovement) error {
+	ledger, err := s.movementRepo.FindByChemicalID(ctx, chem.UserID, chem.ID)
+	if err != nil {
+		return err
+	}
+	chem.UpdateUsage(append(ledger, movements...), time.Now())
+	return s.movementRepo.Record(ctx, chem, movements...)
+}
+
+// stockChanged alerts and publishes EventLowStock if the change took the
+// chemical's stock down to its alert threshold.
+func (s *ChemicalService) stockChanged(ctx context.Context, chem *entities.Chemical, before float64) {
+	s.alerts.StockChanged(ctx, chem, before)
+	if chem.CrossedThreshold(before) {
+		s.webhooks.Publish(ctx, entities.EventLowStock, chemicalWebhookData(chem))
+	}
+}
+
+func (s *ChemicalService) resolveChemistryLog(ctx context.Context, userID uuid.UUID, id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chemistry log ID: %w", err)
+	}
+	log, err := s.chemLogRepo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil {
+		return nil, fmt.Errorf("chemistry log not found")
+	}
+	return &uid, nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// mockStockMovementRepo keeps each chemical's ledger and reconciles its
+// stock the way the real repositories do.
+type mockStockMovementRepo struct {
+	movements []entities.StockMovement
+}
+
+func (m *mockStockMovementRepo) FindByChemicalID(_ context.Context, userID uuid.UUID, chemicalID uuid.UUID) ([]entities.StockMovement, error) {
+	var out []entities.StockMovement
+	for _, mv := range m.movements {
+		if mv.ChemicalID == chemicalID && mv.UserID == userID {
+			out = append(out, mv)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockStockMovementRepo) Record(_ context.Context, chemical *entities.Chemical, movements ...entities.StockMovement) error {
+	m.movements = append(m.movements, movements...)
+	var balance float64
+	for _, mv := range m.movements {
+		if mv.ChemicalID == chemical.ID {
+			balance += mv.Amount
+		}
+	}
+	chemical.Reconcile(balance)
+	return nil
+}
+
+func newTestChemicalService(t *testing.T) (*ChemicalService, *mockStockMovementRepo, *mockChemLogRepo, context.Context, *entities.Chemical) {
+	t.Helper()
+	userID := uuid.New()
+	ctx := userContext(userID)
+	chemRepo, movements, logs := &mockChemicalRepo{}, &mockStockMovementRepo{}, &mockChemLogRepo{}
+	svc := NewChemicalService(chemRepo, movements, logs, nil, nil)
+	chem, err := svc.Create(ctx, command.CreateChemical{Name: "Liquid Chlorine", Type: "sanitizer", StockAmount: 4, StockUnit: "gal", AlertThreshold: 1})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	// The real repositories open the ledger on create.
+	movements.movements = append(movements.movements, *chem.OpeningMovement())
+	return svc, movements, logs, ctx, chem
+}
+
+func TestChemicalService_RecordMovement(t *testing.T) {
+	svc, movements, logs, ctx, chem := newTestChemicalService(t)
+	test := entities.NewChemistryLog(chem.UserID, 7.4, 1, 0, 90, 40, 300, 80, "", time.Now())
+	logs.logs = append(logs.logs, *test)
+
+	cost := 18.5
+	got, err := svc.RecordMovement(ctx, command.RecordStockMovement{ID: chem.ID.String(), Kind: "purchase", Amount: 2, Reason: " Pool store ", Cost: &cost})
+	if err != nil {
+		t.Fatalf("RecordMovement(purchase): %v", err)
+	}
+	if got.Stock.Amount != 6 || got.LastPurchased == nil {
+		t.Errorf("after purchase stock = %v, last purchased = %v", got.Stock.Amount, got.LastPurchased)
+	}
+	got, err = svc.RecordMovement(ctx, command.RecordStockMovement{ID: chem.ID.String(), Kind: "dose", Amount: 0.5, ChemistryLogID: test.ID.String()})
+	if err != nil {
+		t.Fatalf("RecordMovement(dose): %v", err)
+	}
+	if got.Stock.Amount != 5.5 {
+		t.Errorf("after dose stock = %v, want 5.5", got.Stock.Amount)
+	}
+	if got.DailyUsage != 0.0714 {
+		t.Errorf("after a first-day dose daily usage = %v, want 0.5 over a week", got.DailyUsage)
+	}
+	dose := movements.movements[len(movements.movements)-1]
+	if dose.Kind != entities.StockDose || dose.Amount != -0.5 || dose.ChemistryLogID == nil || *dose.ChemistryLogID != test.ID {
+		t.Errorf("dose = %+v", dose)
+	}
+
+	if _, err := svc.RecordMovement(ctx, command.RecordStockMovement{ID: chem.ID.String(), Kind: "dose", Amount: 1, ChemistryLogID: uuid.NewString()}); err == nil {
+		t.Error("RecordMovement accepted a chemistry log that doesn't exist")
+	}
+	if _, err := svc.RecordMovement(ctx, command.RecordStockMovement{ID: chem.ID.String(), Kind: "disposal", Amount: 10}); err == nil {
+		t.Error("RecordMovement let stock go below zero")
+	}
+
+	history, err := svc.History(ctx, chem.ID.String())
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	l := history.Ledger
+	if len(l.Entries) != 3 || l.Entries[0].Balance != 5.5 || l.Entries[1].Reason != "Pool store" || l.Purchased != 2 || l.Used != 0.5 || l.Spent != 18.5 {
+		t.Errorf("ledger = %+v", l)
+	}
+}
+
+func TestChemicalService_UpdateRecordsCorrections(t *testing.T) {
+	svc, movements, _, ctx, chem := newTestChemicalService(t)
+	update := command.UpdateChemical{ID: chem.ID.String(), Name: "Bleach", Type: "sanitizer", StockAmount: 4, StockUnit: "gal", AlertThreshold: 1}
+
+	if _, err := svc.Update(ctx, update); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(movements.movements) != 1 {
+		t.Errorf("renaming recorded %d movements, want only the opening stock", len(movements.movements)-1)
+	}
+
+	update.StockAmount = 3.25
+	got, err := svc.Update(ctx, update)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	last := movements.movements[len(movements.movements)-1]
+	if got.Stock.Amount != 3.25 || last.Kind != entities.StockAdjustment || last.Amount != -0.75 {
+		t.Errorf("stock = %v, correction = %+v", got.Stock.Amount, last)
+	}
+
+	got, err = svc.AdjustStock(ctx, command.AdjustChemicalStock{ID: chem.ID.String(), Delta: -1})
+	if err != nil {
+		t.Fatalf("AdjustStock: %v", err)
+	}
+	if got.Stock.Amount != 2.25 || len(movements.movements) != 3 {
+		t.Errorf("stock = %v after a quick adjustment, %d movements", got.Stock.Amount, len(movements.movements))
+	}
+}
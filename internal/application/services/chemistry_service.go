@@ -11,11 +11,15 @@ import (
 )
 
 type ChemistryService struct {
-	repo repositories.ChemistryLogRepository
+	repo     repositories.ChemistryLogRepository
+	alerts   *AlertService
+	webhooks *WebhookService
 }
 
-func NewChemistryService(repo repositories.ChemistryLogRepository) *ChemistryService {
-	return &ChemistryService{repo: repo}
+// NewChemistryService creates the service. alerts and webhooks may be nil,
+// in which case no unsafe chemistry alerts or webhook events are sent.
+func NewChemistryService(repo repositories.ChemistryLogRepository, alerts *AlertService, webhooks *WebhookService) *ChemistryService {
+	return &ChemistryService{repo: repo, alerts: alerts, webhooks: webhooks}
 }
 
 func (s *ChemistryService) List(ctx context.Context) ([]entities.ChemistryLog, error) {
@@ -58,6 +62,8 @@ func (s *ChemistryService) Create(ctx context.Context, cmd command.CreateChemist
 	if err := s.repo.Create(ctx, log); err != nil {
 		return nil, err
 	}
+	s.alerts.ChemistryLogged(ctx, log)
+	s.webhooks.Publish(ctx, entities.EventChemistryLogCreated, chemistryLogWebhookData(log))
 	return log, nil
 }
 
@@ -92,6 +98,7 @@ func (s *ChemistryService) Update(ctx context.Context, cmd command.UpdateChemist
 	if err := s.repo.Update(ctx, log); err != nil {
 		return nil, err
 	}
+	s.alerts.ChemistryLogged(ctx, log)
 	return log, nil
 }
 
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/domain/entities"
@@ -31,3 +32,12 @@ func UserIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	}
 	return user.ID, nil
 }
+
+// UserNow returns the current time in the context user's timezone, or in
+// UTC if there is no user.
+func UserNow(ctx context.Context) time.Time {
+	if user, err := UserFromContext(ctx); err == nil {
+		return time.Now().In(user.Location())
+	}
+	return time.Now().UTC()
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/domain/entities"
@@ -47,3 +48,13 @@ func TestUserIDFromContext_Missing(t *testing.T) {
 		t.Fatal("expected error for missing user")
 	}
 }
+
+func TestUserNow(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Timezone: "Australia/Sydney"}
+	if loc := UserNow(WithUser(context.Background(), user)).Location(); loc.String() != "Australia/Sydney" {
+		t.Errorf("UserNow location = %v, want Australia/Sydney", loc)
+	}
+	if loc := UserNow(context.Background()).Location(); loc != time.UTC {
+		t.Errorf("UserNow without user location = %v, want UTC", loc)
+	}
+}
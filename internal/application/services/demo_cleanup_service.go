@@ -9,16 +9,20 @@ import (
 )
 
 type DemoCleanupService struct {
-	userRepo      repositories.UserRepository
-	sessionRepo   repositories.SessionRepository
-	chemLogRepo   repositories.ChemistryLogRepository
-	taskRepo      repositories.TaskRepository
-	equipRepo     repositories.EquipmentRepository
-	srRepo        repositories.ServiceRecordRepository
-	chemRepo      repositories.ChemicalRepository
-	taskNotifRepo repositories.TaskNotificationRepository
-	milestoneRepo repositories.MilestoneRepository
-	interval      time.Duration
+	userRepo       repositories.UserRepository
+	sessionRepo    repositories.SessionRepository
+	chemLogRepo    repositories.ChemistryLogRepository
+	taskRepo       repositories.TaskRepository
+	seriesRepo     repositories.TaskSeriesRepository
+	completionRepo repositories.TaskCompletionRepository
+	templateRepo   repositories.TaskTemplateRepository
+	equipRepo      repositories.EquipmentRepository
+	srRepo         repositories.ServiceRecordRepository
+	chemRepo       repositories.ChemicalRepository
+	taskNotifRepo  repositories.TaskNotificationRepository
+	milestoneRepo  repositories.MilestoneRepository
+	reminderRepo   repositories.ReminderRuleRepository
+	interval       time.Duration
 }
 
 func NewDemoCleanupService(
@@ -26,24 +30,32 @@ func NewDemoCleanupService(
 	sessionRepo repositories.SessionRepository,
 	chemLogRepo repositories.ChemistryLogRepository,
 	taskRepo repositories.TaskRepository,
+	seriesRepo repositories.TaskSeriesRepository,
+	completionRepo repositories.TaskCompletionRepository,
+	templateRepo repositories.TaskTemplateRepository,
 	equipRepo repositories.EquipmentRepository,
 	srRepo repositories.ServiceRecordRepository,
 	chemRepo repositories.ChemicalRepository,
 	taskNotifRepo repositories.TaskNotificationRepository,
 	milestoneRepo repositories.MilestoneRepository,
+	reminderRepo repositories.ReminderRuleRepository,
 	interval time.Duration,
 ) *DemoCleanupService {
 	return &DemoCleanupService{
-		userRepo:      userRepo,
-		sessionRepo:   sessionRepo,
-		chemLogRepo:   chemLogRepo,
-		taskRepo:      taskRepo,
-		equipRepo:     equipRepo,
-		srRepo:        srRepo,
-		chemRepo:      chemRepo,
-		taskNotifRepo: taskNotifRepo,
-		milestoneRepo: milestoneRepo,
-		interval:      interval,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		chemLogRepo:    chemLogRepo,
+		taskRepo:       taskRepo,
+		seriesRepo:     seriesRepo,
+		completionRepo: completionRepo,
+		templateRepo:   templateRepo,
+		equipRepo:      equipRepo,
+		srRepo:         srRepo,
+		chemRepo:       chemRepo,
+		taskNotifRepo:  taskNotifRepo,
+		milestoneRepo:  milestoneRepo,
+		reminderRepo:   reminderRepo,
+		interval:       interval,
 	}
 }
 
@@ -91,10 +103,14 @@ func (s *DemoCleanupService) cleanup(ctx context.Context) {
 			_ = s.equipRepo.Delete(ctx, user.ID, e.ID)
 		}
 
+		_ = s.completionRepo.DeleteByUserID(ctx, user.ID)
+
 		tasks, _ := s.taskRepo.FindAll(ctx, user.ID)
 		for _, t := range tasks {
 			_ = s.taskRepo.Delete(ctx, user.ID, t.ID)
 		}
+		_ = s.seriesRepo.DeleteByUserID(ctx, user.ID)
+		_ = s.templateRepo.DeleteByUserID(ctx, user.ID)
 
 		logs, _ := s.chemLogRepo.FindAll(ctx, user.ID)
 		for _, l := range logs {
@@ -102,6 +118,7 @@ func (s *DemoCleanupService) cleanup(ctx context.Context) {
 		}
 
 		_ = s.milestoneRepo.DeleteByUserID(ctx, user.ID)
+		_ = s.reminderRepo.DeleteByUserID(ctx, user.ID)
 
 		// Sessions are deleted via FK CASCADE, but clean up explicitly too
 		_ = s.sessionRepo.DeleteByUserID(ctx, user.ID)
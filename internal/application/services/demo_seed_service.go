@@ -17,6 +17,7 @@ type DemoSeedService struct {
 	userRepo    repositories.UserRepository
 	chemLogRepo repositories.ChemistryLogRepository
 	taskRepo    repositories.TaskRepository
+	seriesRepo  repositories.TaskSeriesRepository
 	equipRepo   repositories.EquipmentRepository
 	srRepo      repositories.ServiceRecordRepository
 	chemRepo    repositories.ChemicalRepository
@@ -26,6 +27,7 @@ func NewDemoSeedService(
 	userRepo repositories.UserRepository,
 	chemLogRepo repositories.ChemistryLogRepository,
 	taskRepo repositories.TaskRepository,
+	seriesRepo repositories.TaskSeriesRepository,
 	equipRepo repositories.EquipmentRepository,
 	srRepo repositories.ServiceRecordRepository,
 	chemRepo repositories.ChemicalRepository,
@@ -34,6 +36,7 @@ func NewDemoSeedService(
 		userRepo:    userRepo,
 		chemLogRepo: chemLogRepo,
 		taskRepo:    taskRepo,
+		seriesRepo:  seriesRepo,
 		equipRepo:   equipRepo,
 		srRepo:      srRepo,
 		chemRepo:    chemRepo,
@@ -150,10 +153,12 @@ func (s *DemoSeedService) seedTasks(ctx context.Context, userID uuid.UUID) error
 	for _, t := range tasks {
 		rec, _ := valueobjects.NewRecurrence(t.freq, t.interval)
 		dueDate := now.AddDate(0, 0, t.dueDaysOut)
-		task := entities.NewTask(userID, t.name, t.desc, rec, dueDate)
-		if t.dueDaysOut < 0 {
-			task.Status = entities.TaskStatusOverdue
+		series := entities.NewTaskSeries(userID, t.name, t.desc, rec)
+		if err := s.seriesRepo.Create(ctx, series); err != nil {
+			return err
 		}
+		task := series.NewOccurrence(dueDate)
+		task.CheckOverdue(now)
 		if err := s.taskRepo.Create(ctx, task); err != nil {
 			return err
 		}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// DigestDays is how far the weekly digest looks back for milestones and
+// ahead for tasks.
+const DigestDays = 7
+
+// DigestService sends the opt-in weekly digest: the pool's health score and
+// streaks, milestones earned in the past week, tasks coming up and
+// chemicals running low. It is sent on each user's DigestSchedule and
+// claimed per week, so it goes out at most once a week per channel.
+type DigestService struct {
+	userRepo      repositories.UserRepository
+	prefRepo      repositories.NotificationPreferenceRepository
+	chemLogRepo   repositories.ChemistryLogRepository
+	taskRepo      repositories.TaskRepository
+	chemRepo      repositories.ChemicalRepository
+	milestoneRepo repositories.MilestoneRepository
+	webhookSvc    *WebhookService
+	outbox        *OutboxService
+	emails        *EmailRenderer
+	interval      time.Duration
+}
+
+func NewDigestService(
+	userRepo repositories.UserRepository,
+	prefRepo repositories.NotificationPreferenceRepository,
+	chemLogRepo repositories.ChemistryLogRepository,
+	taskRepo repositories.TaskRepository,
+	chemRepo repositories.ChemicalRepository,
+	milestoneRepo repositories.MilestoneRepository,
+	webhookSvc *WebhookService,
+	outbox *OutboxService,
+	emails *EmailRenderer,
+	interval time.Duration,
+) *DigestService {
+	return &DigestService{
+		userRepo:      userRepo,
+		prefRepo:      prefRepo,
+		chemLogRepo:   chemLogRepo,
+		taskRepo:      taskRepo,
+		chemRepo:      chemRepo,
+		milestoneRepo: milestoneRepo,
+		webhookSvc:    webhookSvc,
+		outbox:        outbox,
+		emails:        emails,
+		interval:      interval,
+	}
+}
+
+func (s *DigestService) Start(ctx context.Context) {
+	slog.Info("Digest scheduler started", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.checkAll(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Digest scheduler stopped")
+			return
+		case <-ticker.C:
+			s.checkAll(ctx, time.Now())
+		}
+	}
+}
+
+// checkAll sends the digest to each opted-in user whose chosen day and hour
+// has come round in their timezone.
+func (s *DigestService) checkAll(ctx context.Context, now time.Time) {
+	zones, err := s.userRepo.FindTimezones(ctx)
+	if err != nil {
+		slog.Error("Digest check error", "error", err)
+		return
+	}
+	for _, zone := range zones {
+		s.checkZone(ctx, zone, now.In(entities.LoadTimezone(zone)))
+	}
+}
+
+func (s *DigestService) checkZone(ctx context.Context, zone string, now time.Time) {
+	prefs, err := s.prefRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Digest check error", "timezone", zone, "error", err)
+		return
+	}
+	wanted := make(map[uuid.UUID]*entities.NotificationPreferences, len(prefs))
+	for _, p := range prefs {
+		if p.Wants(entities.CategoryDigest) {
+			wanted[p.UserID] = p
+		}
+	}
+	if len(wanted) == 0 {
+		return
+	}
+	users, err := s.userRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Digest check error", "timezone", zone, "error", err)
+		return
+	}
+	for i := range users {
+		user := &users[i]
+		p, ok := wanted[user.ID]
+		if !ok || !user.Digest.IsSendTime(now) {
+			continue
+		}
+		s.send(ctx, user, p, now)
+	}
+}
+
+// digest is one user's weekly summary.
+type digest struct {
+	Score         int
+	ScoreLabel    string
+	TestingStreak int
+	TaskStreak    int
+	Milestones    []entities.MilestoneKey
+	Tasks         []entities.Task // open tasks due within DigestDays, soonest first
+	LowStock      []entities.Chemical
+}
+
+// build gathers the user's digest. now is in the user's timezone.
+// Milestones earned since the user last looked at the dashboard are
+// recorded here too, so the digest doesn't miss them.
+func (s *DigestService) build(ctx context.Context, user *entities.User, now time.Time) (*digest, error) {
+	logs, err := s.chemLogRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding chemistry logs: %w", err)
+	}
+	tasks, err := s.taskRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding tasks: %w", err)
+	}
+	chemicals, err := s.chemRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding chemicals: %w", err)
+	}
+	earned, err := s.milestoneRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding milestones: %w", err)
+	}
+
+	score := ComputeHealthScore(logs, tasks, chemicals, now)
+	d := &digest{
+		Score:         score,
+		ScoreLabel:    HealthScoreLabel(score),
+		TestingStreak: ComputeTestingStreak(logs, now),
+		TaskStreak:    ComputeTaskStreak(tasks, now),
+	}
+
+	earnedSet := make(map[entities.MilestoneKey]bool, len(earned))
+	weekAgo := now.AddDate(0, 0, -DigestDays)
+	for _, m := range earned {
+		earnedSet[m.Milestone] = true
+		if m.EarnedAt.After(weekAgo) {
+			d.Milestones = append(d.Milestones, m.Milestone)
+		}
+	}
+	for _, key := range CheckMilestones(logs, tasks, chemicals, score, earnedSet, now) {
+		m := entities.NewMilestone(user.ID, key)
+		if err := s.milestoneRepo.Create(ctx, m); err != nil {
+			slog.Error("Failed to persist milestone", "key", key, "error", err)
+			continue
+		}
+		s.webhookSvc.MilestoneEarned(WithUser(ctx, user), m)
+		d.Milestones = append(d.Milestones, key)
+	}
+
+	until := entities.DateOf(now).AddDate(0, 0, DigestDays)
+	for _, t := range tasks {
+		if t.IsOpen() && entities.DateOf(t.EffectiveDueDate()).Before(until) {
+			d.Tasks = append(d.Tasks, t)
+		}
+	}
+	for _, c := range chemicals {
+		if c.IsLowStock() {
+			d.LowStock = append(d.LowStock, c)
+		}
+	}
+	return d, nil
+}
+
+// send builds the digest and queues it on each channel the user has opted
+// into, claimed for the week so later checks that day don't repeat it.
+func (s *DigestService) send(ctx context.Context, user *entities.User, prefs *entities.NotificationPreferences, now time.Time) {
+	week := entities.WeekOf(now)
+	var d *digest
+	for _, channel := range entities.NotificationChannels {
+		if !prefs.IsEnabled(entities.CategoryDigest, channel) || !s.outbox.Enabled(channel) {
+			continue
+		}
+		if user.Address(channel) == "" {
+			continue
+		}
+		if d == nil {
+			var err error
+			if d, err = s.build(ctx, user, now); err != nil {
+				slog.Error("Digest build error", "userID", user.ID, "error", err)
+				return
+			}
+		}
+		subject := "PoolVibes: your weekly pool digest"
+		msg := valueobjects.Message{Subject: subject, Text: digestSMS(d), URL: s.emails.appURL()}
+		if channel == entities.ChannelEmail {
+			email, err := s.emails.digest(user, subject, d, now)
+			if err != nil {
+				slog.Error("Digest render error", "userID", user.ID, "error", err)
+				continue
+			}
+			msg = email
+		}
+		notif := entities.NewAlertNotification(user.ID, channel, entities.CategoryDigest, "digest", week)
+		queued, err := s.outbox.EnqueueFor(ctx, notif, user, msg)
+		if err != nil {
+			slog.Error("Digest queue error", "userID", user.ID, "channel", channel, "error", err)
+			continue
+		}
+		if queued {
+			slog.Info("Digest queued", "channel", channel, "userID", user.ID)
+		}
+	}
+}
+
+// digestSMS is the digest's text message and push notification: the
+// headline numbers only.
+func digestSMS(d *digest) string {
+	msg := fmt.Sprintf("PoolVibes weekly digest: health score %d (%s). Testing streak %s, task streak %s.",
+		d.Score, d.ScoreLabel, weeks(d.TestingStreak), weeks(d.TaskStreak))
+	msg += fmt.Sprintf(" %d %s due this week", len(d.Tasks), plural(len(d.Tasks), "task", "tasks"))
+	if len(d.LowStock) > 0 {
+		msg += fmt.Sprintf(", %d %s low on stock", len(d.LowStock), plural(len(d.LowStock), "chemical", "chemicals"))
+	}
+	msg += "."
+	if len(d.Milestones) > 0 {
+		msg += fmt.Sprintf(" New %s: %s.", plural(len(d.Milestones), "milestone", "milestones"), milestoneNames(d.Milestones))
+	}
+	return msg
+}
+
+func weeks(n int) string {
+	return fmt.Sprintf("%d %s", n, plural(n, "week", "weeks"))
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+func milestoneNames(keys []entities.MilestoneKey) string {
+	var names string
+	for i, k := range keys {
+		if i > 0 {
+			names += ", "
+		}
+		names += k.Label()
+	}
+	return names
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type mockChemicalRepo struct {
+	chemicals []entities.Chemical
+}
+
+func (m *mockChemicalRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.Chemical, error) {
+	return m.chemicals, nil
+}
+
+func (m *mockChemicalRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Chemical, error) {
+	for _, c := range m.chemicals {
+		if c.ID == id && c.UserID == userID {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockChemicalRepo) Create(_ context.Context, chemical *entities.Chemical) error {
+	m.chemicals = append(m.chemicals, *chemical)
+	return nil
+}
+
+func (m *mockChemicalRepo) Update(_ context.Context, chemical *entities.Chemical) error {
+	return nil
+}
+
+func (m *mockChemicalRepo) UpdateUsage(_ context.Context, chemical *entities.Chemical) error {
+	for i := range m.chemicals {
+		if m.chemicals[i].ID == chemical.ID {
+			m.chemicals[i].DailyUsage = chemical.DailyUsage
+		}
+	}
+	return nil
+}
+
+func (m *mockChemicalRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return nil
+}
+
+type mockMilestoneRepo struct {
+	milestones []entities.Milestone
+}
+
+func (m *mockMilestoneRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.Milestone, error) {
+	return m.milestones, nil
+}
+
+func (m *mockMilestoneRepo) Create(_ context.Context, milestone *entities.Milestone) error {
+	m.milestones = append(m.milestones, *milestone)
+	return nil
+}
+
+func (m *mockMilestoneRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	m.milestones = nil
+	return nil
+}
+
+func newDigestTest(user *entities.User, channels ...string) (*DigestService, *mockOutboxRepo, *recordingNotifier, *mockTaskRepo, *mockChemicalRepo) {
+	prefs := entities.NewNotificationPreferences(user.ID)
+	for _, ch := range channels {
+		prefs.Set(entities.CategoryDigest, ch, true)
+	}
+	email := &recordingNotifier{}
+	outbox, repo := newTestOutbox(email, &recordingNotifier{}, nil)
+	tasks, chems := &mockTaskRepo{}, &mockChemicalRepo{}
+	svc := NewDigestService(
+		&mockUserRepo{users: []*entities.User{user}},
+		&mockPrefRepo{prefs: map[uuid.UUID]*entities.NotificationPreferences{user.ID: prefs}},
+		&mockChemLogRepo{}, tasks, chems, &mockMilestoneRepo{}, nil,
+		outbox, NewEmailRenderer("", nil), time.Hour,
+	)
+	return svc, repo, email, tasks, chems
+}
+
+func digestUser() *entities.User {
+	u := alertUser()
+	u.Digest = entities.DigestSchedule{Weekday: time.Sunday, Hour: 8}
+	return u
+}
+
+func TestDigestService_SendsOncePerWeek(t *testing.T) {
+	user := digestUser()
+	user.Timezone = "America/Los_Angeles"
+	svc, _, email, _, _ := newDigestTest(user, entities.ChannelEmail)
+	ctx := context.Background()
+
+	// 14:00 UTC on Sunday Mar 9 is 07:00 (PDT) in Los Angeles: too early.
+	svc.checkAll(ctx, time.Date(2025, 3, 9, 14, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 0 {
+		t.Fatalf("sent before the digest hour")
+	}
+	svc.checkAll(ctx, time.Date(2025, 3, 9, 16, 0, 0, 0, time.UTC))
+	svc.checkAll(ctx, time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 1 {
+		t.Fatalf("sent %d digests on the day, want 1", len(email.sent))
+	}
+	if email.subjects[0] != "PoolVibes: your weekly pool digest" {
+		t.Errorf("subject = %q", email.subjects[0])
+	}
+
+	// Monday isn't the digest day; the next Sunday is a new week.
+	svc.checkAll(ctx, time.Date(2025, 3, 10, 16, 0, 0, 0, time.UTC))
+	svc.checkAll(ctx, time.Date(2025, 3, 16, 16, 0, 0, 0, time.UTC))
+	drainOutbox(svc.outbox)
+	if len(email.sent) != 2 {
+		t.Errorf("sent %d digests over two weeks, want 2", len(email.sent))
+	}
+}
+
+func TestDigestService_OptIn(t *testing.T) {
+	user := digestUser()
+	svc, repo, _, _, _ := newDigestTest(user)
+	svc.checkAll(context.Background(), time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC))
+	if len(repo.msgs) != 0 {
+		t.Errorf("queued %d digests without opting in", len(repo.msgs))
+	}
+}
+
+func TestDigestService_Contents(t *testing.T) {
+	user := digestUser()
+	svc, repo, _, tasks, chems := newDigestTest(user, entities.ChannelEmail, entities.ChannelSMS)
+	now := time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 3, 9+d, 0, 0, 0, 0, time.UTC) }
+	tasks.tasks = []entities.Task{
+		{ID: uuid.New(), UserID: user.ID, Name: "Brush walls", Status: entities.TaskStatusOverdue, DueDate: day(-2)},
+		{ID: uuid.New(), UserID: user.ID, Name: "Clean filter", Status: entities.TaskStatusPending, DueDate: day(3)},
+		{ID: uuid.New(), UserID: user.ID, Name: "Backwash", Status: entities.TaskStatusPending, DueDate: day(7)},
+		{ID: uuid.New(), UserID: user.ID, Name: "Shock", Status: entities.TaskStatusCompleted, DueDate: day(1)},
+	}
+	low, _ := valueobjects.NewQuantity(1, valueobjects.UnitPounds)
+	plenty, _ := valueobjects.NewQuantity(20, valueobjects.UnitPounds)
+	chems.chemicals = []entities.Chemical{
+		*entities.NewChemical(user.ID, "Cal-Hypo", entities.ChemicalTypeShock, low, 5),
+		*entities.NewChemical(user.ID, "Trichlor", entities.ChemicalTypeSanitizer, plenty, 5),
+	}
+
+	svc.milestoneRepo.(*mockMilestoneRepo).milestones = []entities.Milestone{
+		{UserID: user.ID, Milestone: entities.MilestoneFirstDip, EarnedAt: now.AddDate(0, 0, -30)},
+		{UserID: user.ID, Milestone: entities.MilestoneBalanced, EarnedAt: now.AddDate(0, 0, -2)},
+	}
+
+	svc.checkAll(context.Background(), now)
+	if len(repo.msgs) != 2 {
+		t.Fatalf("queued %d messages, want an email and an SMS", len(repo.msgs))
+	}
+	for _, m := range repo.msgs {
+		if m.Channel == entities.ChannelSMS {
+			want := "2 tasks due this week, 1 chemical low on stock. New milestone: Balanced."
+			if !strings.HasSuffix(m.Body, want) {
+				t.Errorf("SMS = %q, want it to end %q", m.Body, want)
+			}
+			continue
+		}
+		for _, want := range []string{"Brush walls", "Overdue since", "Clean filter", "Cal-Hypo", "Balanced"} {
+			if !strings.Contains(m.HTMLBody, want) {
+				t.Errorf("email doesn't mention %q", want)
+			}
+		}
+		for _, unwanted := range []string{"Backwash", "Shock", "Trichlor", "First Dip"} {
+			if strings.Contains(m.HTMLBody, unwanted) {
+				t.Errorf("email mentions %q", unwanted)
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+//go:embed emails
+var emailFS embed.FS
+
+// Each HTML email is the shared layout with the page's "content" template.
+var (
+	reminderHTML = emailTemplate("reminder.html")
+	alertHTML    = emailTemplate("alert.html")
+	digestHTML   = emailTemplate("digest.html")
+	reminderText = texttemplate.Must(texttemplate.ParseFS(emailFS, "emails/reminder.txt"))
+	digestText   = texttemplate.Must(texttemplate.ParseFS(emailFS, "emails/digest.txt"))
+)
+
+func emailTemplate(page string) *template.Template {
+	return template.Must(template.ParseFS(emailFS, "emails/layout.html", "emails/"+page))
+}
+
+// EmailRenderer renders notification emails as HTML with a plain text
+// fallback. Links in the emails point at baseURL, the address PoolVibes is
+// served from; without one the emails have no links. Each email links to
+// unsubscribe from its category, if unsubscribe is set.
+type EmailRenderer struct {
+	baseURL     string
+	unsubscribe *UnsubscribeService
+}
+
+func NewEmailRenderer(baseURL string, unsubscribe *UnsubscribeService) *EmailRenderer {
+	return &EmailRenderer{baseURL: strings.TrimRight(baseURL, "/"), unsubscribe: unsubscribe}
+}
+
+// emailFooter is the end of every email: links to the app and to
+// unsubscribe.
+type emailFooter struct {
+	AppURL         string
+	UnsubscribeURL string
+	Category       string // the label of the category UnsubscribeURL unsubscribes from
+}
+
+// footer returns the footer for the user's emails in category, and the
+// headers that let mail clients offer a one-click unsubscribe (RFC 8058).
+func (r *EmailRenderer) footer(userID uuid.UUID, category entities.NotificationCategory) (emailFooter, map[string]string) {
+	f := emailFooter{AppURL: r.appURL(), Category: category.Label()}
+	if r.unsubscribe == nil {
+		return f, nil
+	}
+	if f.UnsubscribeURL = r.unsubscribe.URL(userID, category, time.Now()); f.UnsubscribeURL == "" {
+		return f, nil
+	}
+	return f, map[string]string{
+		"List-Unsubscribe":      "<" + f.UnsubscribeURL + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+func (r *EmailRenderer) appURL() string {
+	if r.baseURL == "" {
+		return ""
+	}
+	return r.baseURL + "/"
+}
+
+// completeURL links to the app with the task's completion form open.
+func (r *EmailRenderer) completeURL(task *entities.Task) string {
+	if r.baseURL == "" {
+		return ""
+	}
+	return r.baseURL + "/?complete=" + task.ID.String()
+}
+
+type reminderEmailData struct {
+	emailFooter
+	Title     string
+	Intro     string
+	Overdue   bool
+	Tasks     []reminderEmailTask
+	Chemistry *chemistrySummary
+}
+
+type reminderEmailTask struct {
+	Name        string
+	Description string
+	Due         string
+	CompleteURL string
+}
+
+// chemistrySummary is a water test's readings, formatted for email.
+type chemistrySummary struct {
+	TestedAt string
+	Readings []chemistryReading
+}
+
+type chemistryReading struct {
+	Name    string
+	Value   string
+	InRange bool
+}
+
+// reminder renders the email for a reminder rule's batch of tasks, with a
+// summary of the user's latest water test if there is one.
+func (r *EmailRenderer) reminder(user *entities.User, subject string, rule *entities.ReminderRule, tasks []entities.Task, latest *entities.ChemistryLog) (valueobjects.Message, error) {
+	footer, headers := r.footer(user.ID, entities.CategoryReminders)
+	data := reminderEmailData{
+		emailFooter: footer,
+		Overdue:     rule.Kind() == entities.NotificationKindOverdue,
+	}
+	noun := "task"
+	if len(tasks) != 1 {
+		noun = "tasks"
+	}
+	if data.Overdue {
+		data.Title = fmt.Sprintf("%d %s overdue", len(tasks), noun)
+		data.Intro = fmt.Sprintf("You have %d overdue pool maintenance %s.", len(tasks), noun)
+	} else {
+		when := reminderWhen(rule)
+		data.Title = fmt.Sprintf("%d %s due %s", len(tasks), noun, when)
+		data.Intro = fmt.Sprintf("You have %d pool maintenance %s due %s.", len(tasks), noun, when)
+	}
+	for i := range tasks {
+		t := &tasks[i]
+		data.Tasks = append(data.Tasks, reminderEmailTask{
+			Name:        t.Name,
+			Description: t.Description,
+			Due:         t.EffectiveDueDate().Format("Mon, Jan 2"),
+			CompleteURL: r.completeURL(t),
+		})
+	}
+	if latest != nil {
+		data.Chemistry = summarizeChemistry(latest, user.Location())
+	}
+
+	var html, text bytes.Buffer
+	if err := reminderHTML.ExecuteTemplate(&html, "layout", data); err != nil {
+		return valueobjects.Message{}, fmt.Errorf("rendering reminder email: %w", err)
+	}
+	if err := reminderText.Execute(&text, data); err != nil {
+		return valueobjects.Message{}, fmt.Errorf("rendering reminder email text: %w", err)
+	}
+	return valueobjects.Message{Subject: subject, Text: text.String(), HTML: html.String(), Headers: headers}, nil
+}
+
+func summarizeChemistry(log *entities.ChemistryLog, loc *time.Location) *chemistrySummary {
+	ppm := func(v float64) string { return fmt.Sprintf("%.1f ppm", v) }
+	whole := func(v float64) string { return fmt.Sprintf("%.0f ppm", v) }
+	return &chemistrySummary{
+		TestedAt: log.TestedAt.In(loc).Format("Jan 2 at 3:04 PM"),
+		Readings: []chemistryReading{
+			{"pH", fmt.Sprintf("%.1f", log.PH), log.PHInRange()},
+			{"Free chlorine", ppm(log.FreeChlorine), log.FreeChlorineInRange()},
+			{"Combined chlorine", ppm(log.CombinedChlorine), log.CombinedChlorineInRange()},
+			{"Total alkalinity", whole(log.TotalAlkalinity), log.TotalAlkalinityInRange()},
+			{"CYA", whole(log.CYA), log.CYAInRange()},
+			{"Calcium hardness", whole(log.CalciumHardness), log.CalciumHardnessInRange()},
+		},
+	}
+}
+
+type alertEmailData struct {
+	emailFooter
+	Title      string
+	Paragraphs [][]string // lines of each paragraph
+}
+
+// alert renders an alert's plain text body as an email.
+func (r *EmailRenderer) alert(user *entities.User, category entities.NotificationCategory, subject, body string) (valueobjects.Message, error) {
+	footer, headers := r.footer(user.ID, category)
+	data := alertEmailData{
+		emailFooter: footer,
+		Title:       emailTitle(subject),
+	}
+	for _, p := range strings.Split(body, "\n\n") {
+		data.Paragraphs = append(data.Paragraphs, strings.Split(strings.Trim(p, "\n"), "\n"))
+	}
+	var html bytes.Buffer
+	if err := alertHTML.ExecuteTemplate(&html, "layout", data); err != nil {
+		return valueobjects.Message{}, fmt.Errorf("rendering alert email: %w", err)
+	}
+	text := body
+	if data.AppURL != "" {
+		text += "\n\nOpen PoolVibes: " + data.AppURL
+	}
+	if data.UnsubscribeURL != "" {
+		text += "\nUnsubscribe from “" + data.Category + "” emails: " + data.UnsubscribeURL
+	}
+	return valueobjects.Message{Subject: subject, Text: text, HTML: html.String(), Headers: headers}, nil
+}
+
+type digestEmailData struct {
+	emailFooter
+	Title             string
+	Week              string
+	Score             int
+	ScoreLabel        string
+	ScoreColor        string
+	TestingStreak     int
+	TestingStreakUnit string
+	TaskStreak        int
+	TaskStreakUnit    string
+	Milestones        []string
+	Tasks             []digestEmailTask
+	LowStock          []digestEmailChemical
+}
+
+type digestEmailTask struct {
+	Name        string
+	Due         string
+	Overdue     bool
+	CompleteURL string
+}
+
+type digestEmailChemical struct {
+	Name  string
+	Stock string
+	// Reorder suggests when and how much to reorder, if the chemical's
+	// usage is known.
+	Reorder string
+}
+
+// digest renders the user's weekly digest. now is in their timezone.
+func (r *EmailRenderer) digest(user *entities.User, subject string, d *digest, now time.Time) (valueobjects.Message, error) {
+	footer, headers := r.footer(user.ID, entities.CategoryDigest)
+	data := digestEmailData{
+		emailFooter:       footer,
+		Title:             "Your weekly pool digest",
+		Week:              entities.WeekOf(now).Format("Jan 2"),
+		Score:             d.Score,
+		ScoreLabel:        d.ScoreLabel,
+		ScoreColor:        "#10b981",
+		TestingStreak:     d.TestingStreak,
+		TestingStreakUnit: plural(d.TestingStreak, "week", "weeks"),
+		TaskStreak:        d.TaskStreak,
+		TaskStreakUnit:    plural(d.TaskStreak, "week", "weeks"),
+	}
+	switch {
+	case d.Score < 50:
+		data.ScoreColor = "#ef4444"
+	case d.Score < 80:
+		data.ScoreColor = "#f59e0b"
+	}
+	for _, m := range d.Milestones {
+		data.Milestones = append(data.Milestones, m.Label())
+	}
+	today := entities.DateOf(now)
+	for i := range d.Tasks {
+		t := &d.Tasks[i]
+		due := t.EffectiveDueDate()
+		data.Tasks = append(data.Tasks, digestEmailTask{
+			Name:        t.Name,
+			Due:         due.Format("Mon, Jan 2"),
+			Overdue:     entities.DateOf(due).Before(today),
+			CompleteURL: r.completeURL(t),
+		})
+	}
+	for _, c := range d.LowStock {
+		chem := digestEmailChemical{
+			Name:  c.Name,
+			Stock: fmt.Sprintf("%.1f %s", c.Stock.Amount, c.Stock.Unit),
+		}
+		if f := c.Forecast(now); f != nil {
+			chem.Reorder = fmt.Sprintf("about %d %s left; order %g %s by %s",
+				f.DaysLeft, plural(f.DaysLeft, "day", "days"), f.ReorderAmount, c.Stock.Unit, f.ReorderOn.Format("Mon, Jan 2"))
+		}
+		data.LowStock = append(data.LowStock, chem)
+	}
+
+	var html, text bytes.Buffer
+	if err := digestHTML.ExecuteTemplate(&html, "layout", data); err != nil {
+		return valueobjects.Message{}, fmt.Errorf("rendering digest email: %w", err)
+	}
+	if err := digestText.Execute(&text, data); err != nil {
+		return valueobjects.Message{}, fmt.Errorf("rendering digest email text: %w", err)
+	}
+	return valueobjects.Message{Subject: subject, Text: text.String(), HTML: html.String(), Headers: headers}, nil
+}
+
+// emailTitle turns a subject such as "PoolVibes: time to test your water"
+// into a heading: "Time to test your water".
+func emailTitle(subject string) string {
+	title := strings.TrimPrefix(subject, "PoolVibes: ")
+	r, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(r)) + title[size:]
+}
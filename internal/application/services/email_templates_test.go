@@ -0,0 +1,164 @@
+package services
+
+import (
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got with testdata/emails/<name>, rewriting the file
+// instead when the tests are run with -update.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "emails", name)
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s doesn't match the golden file (run with -update to accept the change)\ngot:\n%s", name, got)
+	}
+}
+
+// newTestRenderer returns a renderer whose emails link to baseURL,
+// including to unsubscribe.
+func newTestRenderer(baseURL string) *EmailRenderer {
+	return NewEmailRenderer(baseURL, NewUnsubscribeService(&mockPrefRepo{}, []byte("test key"), baseURL))
+}
+
+// withoutToken replaces msg's unsubscribe token, which depends on when it
+// was made, so msg can be compared with a golden file.
+func withoutToken(t *testing.T, msg valueobjects.Message) valueobjects.Message {
+	t.Helper()
+	link := strings.Trim(msg.Headers["List-Unsubscribe"], "<>")
+	if link == "" {
+		return msg
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("List-Unsubscribe = %q: %v", link, err)
+	}
+	token := u.Query().Get("token")
+	msg.Text = strings.ReplaceAll(msg.Text, token, "TOKEN")
+	msg.HTML = strings.ReplaceAll(msg.HTML, token, "TOKEN")
+	return msg
+}
+
+func TestEmailRenderer_Reminder(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Timezone: "America/Los_Angeles"}
+	tasks := []entities.Task{
+		{
+			ID:          uuid.MustParse("0195f2a4-8c41-7a3e-9b1d-2f6e4c8a1b01"),
+			Name:        "Clean filter",
+			Description: "Rinse the cartridge & check the O-ring",
+			DueDate:     time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:      uuid.MustParse("0195f2a4-8c41-7a3e-9b1d-2f6e4c8a1b02"),
+			Name:    "Shock <weekly>",
+			DueDate: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	latest := &entities.ChemistryLog{
+		PH: 7.8, FreeChlorine: 2.5, CombinedChlorine: 0.2, TotalAlkalinity: 90, CYA: 40, CalciumHardness: 250,
+		TestedAt: time.Date(2025, 3, 9, 16, 30, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		baseURL string
+		rule    *entities.ReminderRule
+		tasks   []entities.Task
+		latest  *entities.ChemistryLog
+	}{
+		{"reminder_due", "https://pool.example.com/", entities.NewReminderRule(user.ID, -1, 18), tasks, latest},
+		{"reminder_overdue", "", entities.NewReminderRule(user.ID, 3, 9), tasks[:1], nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, _ := reminderMessage(tt.rule, tt.tasks)
+			msg, err := newTestRenderer(tt.baseURL).reminder(user, subject, tt.rule, tt.tasks, tt.latest)
+			if err != nil {
+				t.Fatalf("reminder() error = %v", err)
+			}
+			if oneClick := msg.Headers["List-Unsubscribe-Post"] == "List-Unsubscribe=One-Click"; oneClick != (tt.baseURL != "") {
+				t.Errorf("Headers = %v", msg.Headers)
+			}
+			msg = withoutToken(t, msg)
+			if msg.Subject != subject {
+				t.Errorf("Subject = %q, want %q", msg.Subject, subject)
+			}
+			checkGolden(t, tt.name+".html", msg.HTML)
+			checkGolden(t, tt.name+".txt", msg.Text)
+		})
+	}
+}
+
+func TestEmailRenderer_Alert(t *testing.T) {
+	subject, body, err := renderAlert(entities.CategoryChemistry, chemistryAlertData{
+		TestedAt: time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC),
+		Readings: []string{"pH is 8.4 (above 8.0)", "Free chlorine is 0.5 ppm (below 1.0)"},
+	})
+	if err != nil {
+		t.Fatalf("renderAlert() error = %v", err)
+	}
+	user := &entities.User{ID: uuid.New(), Timezone: "UTC"}
+	msg, err := newTestRenderer("https://pool.example.com").alert(user, entities.CategoryChemistry, subject, body)
+	if err != nil {
+		t.Fatalf("alert() error = %v", err)
+	}
+	msg = withoutToken(t, msg)
+	checkGolden(t, "alert_chemistry.html", msg.HTML)
+	checkGolden(t, "alert_chemistry.txt", msg.Text)
+}
+
+func TestEmailRenderer_Digest(t *testing.T) {
+	d := &digest{
+		Score:         72,
+		ScoreLabel:    HealthScoreLabel(72),
+		TestingStreak: 3,
+		TaskStreak:    1,
+		Milestones:    []entities.MilestoneKey{entities.MilestoneBalanced},
+		Tasks: []entities.Task{
+			{ID: uuid.MustParse("0195f2a4-8c41-7a3e-9b1d-2f6e4c8a1b01"), Name: "Clean filter", DueDate: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.MustParse("0195f2a4-8c41-7a3e-9b1d-2f6e4c8a1b02"), Name: "Shock <weekly>", DueDate: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)},
+		},
+		LowStock: []entities.Chemical{
+			{Name: "Cal-Hypo", Stock: valueobjects.Quantity{Amount: 1.5, Unit: valueobjects.UnitPounds}},
+			{Name: "Liquid Chlorine", Stock: valueobjects.Quantity{Amount: 2, Unit: valueobjects.UnitGallons}, DailyUsage: 0.4},
+		},
+	}
+	user := &entities.User{ID: uuid.New(), Timezone: "UTC"}
+	msg, err := newTestRenderer("https://pool.example.com").digest(user, "PoolVibes: your weekly pool digest", d, time.Date(2025, 3, 9, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("digest() error = %v", err)
+	}
+	msg = withoutToken(t, msg)
+	checkGolden(t, "digest.html", msg.HTML)
+	checkGolden(t, "digest.txt", msg.Text)
+
+	empty, err := newTestRenderer("").digest(user, "PoolVibes: your weekly pool digest", &digest{Score: 100, ScoreLabel: HealthScoreLabel(100)}, time.Date(2025, 3, 9, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("digest() error = %v", err)
+	}
+	checkGolden(t, "digest_empty.txt", empty.Text)
+}
@@ -11,12 +11,13 @@ import (
 )
 
 type EquipmentService struct {
-	eqRepo repositories.EquipmentRepository
-	srRepo repositories.ServiceRecordRepository
+	eqRepo   repositories.EquipmentRepository
+	srRepo   repositories.ServiceRecordRepository
+	taskRepo repositories.TaskRepository
 }
 
-func NewEquipmentService(eqRepo repositories.EquipmentRepository, srRepo repositories.ServiceRecordRepository) *EquipmentService {
-	return &EquipmentService{eqRepo: eqRepo, srRepo: srRepo}
+func NewEquipmentService(eqRepo repositories.EquipmentRepository, srRepo repositories.ServiceRecordRepository, taskRepo repositories.TaskRepository) *EquipmentService {
+	return &EquipmentService{eqRepo: eqRepo, srRepo: srRepo, taskRepo: taskRepo}
 }
 
 func (s *EquipmentService) List(ctx context.Context) ([]entities.Equipment, error) {
@@ -28,12 +29,17 @@ func (s *EquipmentService) List(ctx context.Context) ([]entities.Equipment, erro
 	if err != nil {
 		return nil, err
 	}
+	tasks, err := s.taskRepo.FindAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading tasks: %w", err)
+	}
 	for i := range items {
 		records, err := s.srRepo.FindByEquipmentID(ctx, userID, items[i].ID)
 		if err != nil {
 			return nil, fmt.Errorf("loading service records: %w", err)
 		}
 		items[i].ServiceRecords = records
+		items[i].UpcomingTasks = upcomingTasks(tasks, items[i].ID)
 	}
 	return items, nil
 }
@@ -59,9 +65,26 @@ func (s *EquipmentService) Get(ctx context.Context, id string) (*entities.Equipm
 		return nil, fmt.Errorf("loading service records: %w", err)
 	}
 	eq.ServiceRecords = records
+	tasks, err := s.taskRepo.FindAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading tasks: %w", err)
+	}
+	eq.UpcomingTasks = upcomingTasks(tasks, eq.ID)
 	return eq, nil
 }
 
+// upcomingTasks picks the open tasks linked to the equipment, soonest due
+// first.
+func upcomingTasks(tasks []entities.Task, equipmentID uuid.UUID) []entities.Task {
+	var out []entities.Task
+	for _, t := range tasks {
+		if t.IsOpen() && t.EquipmentID != nil && *t.EquipmentID == equipmentID {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
 func (s *EquipmentService) Create(ctx context.Context, cmd command.CreateEquipment) (*entities.Equipment, error) {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
@@ -140,6 +163,18 @@ func (s *EquipmentService) AddServiceRecord(ctx context.Context, cmd command.Cre
 	return sr, nil
 }
 
+func (s *EquipmentService) GetServiceRecord(ctx context.Context, id string) (*entities.ServiceRecord, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+	return s.srRepo.FindByID(ctx, userID, uid)
+}
+
 func (s *EquipmentService) DeleteServiceRecord(ctx context.Context, id string) error {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
@@ -52,15 +52,20 @@ func ComputeHealthScore(logs []entities.ChemistryLog, tasks []entities.Task, che
 		qualityPct = float64(inRange) / 6.0
 	}
 
-	// Task Completion (25%): % of tasks completed on time in last 30 days
+	// Task Completion (25%): % of tasks completed on time in last 30 days.
+	// Skipped occurrences were deliberately not done and don't count either way.
 	thirtyDaysAgo := now.AddDate(0, 0, -30)
 	totalDue := 0
 	completedOnTime := 0
 	for _, t := range tasks {
-		if t.DueDate.Before(thirtyDaysAgo) {
+		if t.Status == entities.TaskStatusSkipped {
 			continue
 		}
-		if t.DueDate.After(now) {
+		due := t.EffectiveDueDate()
+		if due.Before(thirtyDaysAgo) {
+			continue
+		}
+		if due.After(now) {
 			continue // not due yet
 		}
 		totalDue++
@@ -92,20 +97,40 @@ func ComputeHealthScore(logs []entities.ChemistryLog, tasks []entities.Task, che
 	return score
 }
 
-// ComputeTestingStreak returns consecutive weeks with at least one water test.
+// HealthScoreLabel describes a health score in words.
+func HealthScoreLabel(score int) string {
+	switch {
+	case score >= 90:
+		return "Excellent"
+	case score >= 80:
+		return "Great"
+	case score >= 60:
+		return "Needs attention"
+	case score >= 40:
+		return "Falling behind"
+	default:
+		return "Critical"
+	}
+}
+
+// ComputeTestingStreak returns consecutive weeks with at least one water
+// test. Weeks end on the calendar date of now, which should be in the
+// user's timezone; test times are the wall-clock times the user entered.
 func ComputeTestingStreak(logs []entities.ChemistryLog, now time.Time) int {
 	if len(logs) == 0 {
 		return 0
 	}
 
+	today := entities.DateOf(now)
 	streak := 0
 	for week := 0; ; week++ {
-		weekEnd := now.AddDate(0, 0, -week*7)
-		weekStart := now.AddDate(0, 0, -(week+1)*7)
+		weekEnd := today.AddDate(0, 0, -week*7)
+		weekStart := today.AddDate(0, 0, -(week+1)*7)
 
 		hasTest := false
 		for _, l := range logs {
-			if l.TestedAt.After(weekStart) && !l.TestedAt.After(weekEnd) {
+			tested := entities.DateOf(l.TestedAt)
+			if tested.After(weekStart) && !tested.After(weekEnd) {
 				hasTest = true
 				break
 			}
@@ -119,18 +144,25 @@ func ComputeTestingStreak(logs []entities.ChemistryLog, now time.Time) int {
 }
 
 // ComputeTaskStreak returns consecutive weeks with zero overdue tasks.
+// Weeks run back from yesterday in now's timezone, which should be the
+// user's; a task due today can't be late yet. Skipped occurrences neither
+// extend nor break the streak, and snoozed ones are judged against their
+// snoozed due date.
 func ComputeTaskStreak(tasks []entities.Task, now time.Time) int {
+	today := entities.DateOf(now)
 	streak := 0
 	for week := 0; ; week++ {
-		weekEnd := now.AddDate(0, 0, -week*7)
-		weekStart := now.AddDate(0, 0, -(week+1)*7)
+		weekEnd := today.AddDate(0, 0, -week*7)
+		weekStart := today.AddDate(0, 0, -(week+1)*7)
 
 		hadOverdue := false
 		for _, t := range tasks {
-			if t.DueDate.Before(weekEnd) && t.DueDate.After(weekStart) {
-				completedInTime := t.Status == entities.TaskStatusCompleted &&
-					t.CompletedAt != nil && !t.CompletedAt.After(t.DueDate)
-				if !completedInTime {
+			if t.Status == entities.TaskStatusSkipped {
+				continue
+			}
+			due := entities.DateOf(t.EffectiveDueDate())
+			if due.Before(weekEnd) && !due.Before(weekStart) {
+				if !t.CompletedOnTime(now.Location()) {
 					hadOverdue = true
 					break
 				}
@@ -148,12 +180,14 @@ func ComputeTaskStreak(tasks []entities.Task, now time.Time) int {
 }
 
 // CheckMilestones returns milestone keys newly earned (not in alreadyEarned).
+// now should be in the user's timezone.
 func CheckMilestones(
 	logs []entities.ChemistryLog,
 	tasks []entities.Task,
 	chemicals []entities.Chemical,
 	healthScore int,
 	alreadyEarned map[entities.MilestoneKey]bool,
+	now time.Time,
 ) []entities.MilestoneKey {
 	if alreadyEarned == nil {
 		alreadyEarned = make(map[entities.MilestoneKey]bool)
@@ -181,7 +215,6 @@ func CheckMilestones(
 	check(entities.MilestoneBalanced, balanced)
 
 	// Consistent: 4-week testing streak
-	now := time.Now()
 	check(entities.MilestoneConsistent, ComputeTestingStreak(logs, now) >= 4)
 
 	// Devoted: 12-week testing streak
@@ -190,8 +223,7 @@ func CheckMilestones(
 	// On It: 10 tasks completed on time
 	onTimeCount := 0
 	for _, t := range tasks {
-		if t.Status == entities.TaskStatusCompleted && t.CompletedAt != nil &&
-			!t.CompletedAt.After(t.DueDate.AddDate(0, 0, 1)) {
+		if t.CompletedOnTime(now.Location()) {
 			onTimeCount++
 		}
 	}
@@ -96,11 +96,82 @@ func TestComputeTaskStreak(t *testing.T) {
 	}
 }
 
+func TestComputeTaskStreak_SkippedDoesNotBreak(t *testing.T) {
+	now := time.Now()
+	userID := uuid.Must(uuid.NewV7())
+
+	tasks := []entities.Task{
+		{UserID: userID, Status: entities.TaskStatusCompleted,
+			DueDate: now.AddDate(0, 0, -2), CompletedAt: timePtr(now.AddDate(0, 0, -2))},
+		{UserID: userID, Status: entities.TaskStatusSkipped,
+			DueDate: now.AddDate(0, 0, -9), SkippedAt: timePtr(now.AddDate(0, 0, -9))},
+		{UserID: userID, Status: entities.TaskStatusCompleted,
+			DueDate: now.AddDate(0, 0, -16), CompletedAt: timePtr(now.AddDate(0, 0, -16))},
+	}
+
+	streak := ComputeTaskStreak(tasks, now)
+	if streak < 3 {
+		t.Errorf("expected skipped week to keep the streak going (>= 3), got %d", streak)
+	}
+}
+
+func TestComputeTaskStreak_SnoozedJudgedOnNewDate(t *testing.T) {
+	now := time.Now()
+	userID := uuid.Must(uuid.NewV7())
+
+	// Originally due last week, snoozed to yesterday and done yesterday.
+	tasks := []entities.Task{
+		{UserID: userID, Status: entities.TaskStatusCompleted,
+			DueDate: now.AddDate(0, 0, -8), SnoozedUntil: timePtr(now.AddDate(0, 0, -1)),
+			CompletedAt: timePtr(now.AddDate(0, 0, -1))},
+	}
+
+	streak := ComputeTaskStreak(tasks, now)
+	if streak == 0 {
+		t.Error("expected snoozed task completed by its new date not to break the streak")
+	}
+}
+
+func TestComputeTaskStreak_UsesUserTimezone(t *testing.T) {
+	// Due on the 9th and done at 20:00 UTC that day: on time for a user in
+	// Los Angeles, a day late for a user in Sydney.
+	completedAt := time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC)
+	tasks := []entities.Task{
+		{Status: entities.TaskStatusCompleted,
+			DueDate: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), CompletedAt: &completedAt},
+	}
+	now := time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC)
+
+	la := ComputeTaskStreak(tasks, now.In(entities.LoadTimezone("America/Los_Angeles")))
+	if la == 0 {
+		t.Error("expected a streak in Los Angeles")
+	}
+	if sydney := ComputeTaskStreak(tasks, now.In(entities.LoadTimezone("Australia/Sydney"))); sydney != 0 {
+		t.Errorf("expected late completion to break the streak in Sydney, got %d", sydney)
+	}
+}
+
+func TestComputeHealthScore_IgnoresSkippedTasks(t *testing.T) {
+	now := time.Now()
+	userID := uuid.Must(uuid.NewV7())
+
+	completed := entities.Task{UserID: userID, Status: entities.TaskStatusCompleted,
+		DueDate: now.AddDate(0, 0, -5), CompletedAt: timePtr(now.AddDate(0, 0, -5))}
+	skipped := entities.Task{UserID: userID, Status: entities.TaskStatusSkipped,
+		DueDate: now.AddDate(0, 0, -3), SkippedAt: timePtr(now.AddDate(0, 0, -3))}
+
+	withSkip := ComputeHealthScore(nil, []entities.Task{completed, skipped}, nil, now)
+	without := ComputeHealthScore(nil, []entities.Task{completed}, nil, now)
+	if withSkip != without {
+		t.Errorf("skipped task changed the score: %d vs %d", withSkip, without)
+	}
+}
+
 func TestCheckMilestones_FirstDip(t *testing.T) {
 	logs := []entities.ChemistryLog{
 		{ID: uuid.Must(uuid.NewV7()), TestedAt: time.Now()},
 	}
-	earned := CheckMilestones(logs, nil, nil, 0, nil)
+	earned := CheckMilestones(logs, nil, nil, 0, nil, time.Now())
 	found := false
 	for _, m := range earned {
 		if m == entities.MilestoneFirstDip {
@@ -120,7 +191,7 @@ func TestCheckMilestones_Balanced(t *testing.T) {
 			TestedAt: time.Now(),
 		},
 	}
-	earned := CheckMilestones(logs, nil, nil, 0, nil)
+	earned := CheckMilestones(logs, nil, nil, 0, nil, time.Now())
 	found := false
 	for _, m := range earned {
 		if m == entities.MilestoneBalanced {
@@ -137,7 +208,7 @@ func TestCheckMilestones_SkipsAlreadyEarned(t *testing.T) {
 		{TestedAt: time.Now()},
 	}
 	alreadyEarned := map[entities.MilestoneKey]bool{entities.MilestoneFirstDip: true}
-	earned := CheckMilestones(logs, nil, nil, 0, alreadyEarned)
+	earned := CheckMilestones(logs, nil, nil, 0, alreadyEarned, time.Now())
 	for _, m := range earned {
 		if m == entities.MilestoneFirstDip {
 			t.Error("should not re-earn MilestoneFirstDip")
@@ -146,7 +217,7 @@ func TestCheckMilestones_SkipsAlreadyEarned(t *testing.T) {
 }
 
 func TestCheckMilestones_PoolPro(t *testing.T) {
-	earned := CheckMilestones(nil, nil, nil, 92, nil)
+	earned := CheckMilestones(nil, nil, nil, 92, nil, time.Now())
 	found := false
 	for _, m := range earned {
 		if m == entities.MilestonePoolPro {
@@ -6,34 +6,45 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/domain/entities"
 	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
 )
 
 type NotificationService struct {
-	taskRepo      repositories.TaskRepository
-	userRepo      repositories.UserRepository
-	notifRepo     repositories.TaskNotificationRepository
-	emailNotifier Notifier
-	smsNotifier   Notifier
-	interval      time.Duration
+	taskRepo    repositories.TaskRepository
+	userRepo    repositories.UserRepository
+	ruleRepo    repositories.ReminderRuleRepository
+	prefRepo    repositories.NotificationPreferenceRepository
+	chemLogRepo repositories.ChemistryLogRepository
+	outbox      *OutboxService
+	emails      *EmailRenderer
+	replies     *SMSReplyService // nil unless replies to text messages are handled
+	interval    time.Duration
 }
 
 func NewNotificationService(
 	taskRepo repositories.TaskRepository,
 	userRepo repositories.UserRepository,
-	notifRepo repositories.TaskNotificationRepository,
-	emailNotifier Notifier,
-	smsNotifier Notifier,
+	ruleRepo repositories.ReminderRuleRepository,
+	prefRepo repositories.NotificationPreferenceRepository,
+	chemLogRepo repositories.ChemistryLogRepository,
+	outbox *OutboxService,
+	emails *EmailRenderer,
+	replies *SMSReplyService,
 	interval time.Duration,
 ) *NotificationService {
 	return &NotificationService{
-		taskRepo:      taskRepo,
-		userRepo:      userRepo,
-		notifRepo:     notifRepo,
-		emailNotifier: emailNotifier,
-		smsNotifier:   smsNotifier,
-		interval:      interval,
+		taskRepo:    taskRepo,
+		userRepo:    userRepo,
+		ruleRepo:    ruleRepo,
+		prefRepo:    prefRepo,
+		chemLogRepo: chemLogRepo,
+		outbox:      outbox,
+		emails:      emails,
+		replies:     replies,
+		interval:    interval,
 	}
 }
 
@@ -43,7 +54,7 @@ func (s *NotificationService) Start(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run immediately on start
-	s.checkAndNotify(ctx)
+	s.checkAndNotify(ctx, time.Now())
 
 	for {
 		select {
@@ -51,100 +62,193 @@ func (s *NotificationService) Start(ctx context.Context) {
 			slog.Info("Notification scheduler stopped")
 			return
 		case <-ticker.C:
-			s.checkAndNotify(ctx)
+			s.checkAndNotify(ctx, time.Now())
 		}
 	}
 }
 
-func (s *NotificationService) checkAndNotify(ctx context.Context) {
-	today := time.Now()
-	tasks, err := s.taskRepo.FindDueOnDate(ctx, today)
+// checkAndNotify runs the due and overdue checks for each timezone that has
+// users, so everyone is reminded on their own local day.
+func (s *NotificationService) checkAndNotify(ctx context.Context, now time.Time) {
+	zones, err := s.userRepo.FindTimezones(ctx)
 	if err != nil {
 		slog.Error("Notification check error", "error", err)
 		return
 	}
+	for _, zone := range zones {
+		s.checkZone(ctx, zone, now.In(entities.LoadTimezone(zone)))
+	}
+}
 
-	if len(tasks) == 0 {
+// checkZone sends the reminders that are due for users in one timezone
+// who have opted into them. Each user's reminder rules pick which tasks to
+// send about, and from what hour; users without rules of their own get the
+// defaults.
+func (s *NotificationService) checkZone(ctx context.Context, zone string, now time.Time) {
+	prefs, err := s.prefRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Notification check error", "timezone", zone, "error", err)
 		return
 	}
-
-	// Group tasks by user
-	byUser := make(map[string][]entities.Task)
-	for _, t := range tasks {
-		uid := t.UserID.String()
-		byUser[uid] = append(byUser[uid], t)
+	wanted := make(map[uuid.UUID]*entities.NotificationPreferences, len(prefs))
+	for _, p := range prefs {
+		if p.Wants(entities.CategoryReminders) {
+			wanted[p.UserID] = p
+		}
+	}
+	if len(wanted) == 0 {
+		return
+	}
+	users, err := s.userRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Notification check error", "timezone", zone, "error", err)
+		return
+	}
+	saved, err := s.ruleRepo.FindByTimezone(ctx, zone)
+	if err != nil {
+		slog.Error("Notification check error", "timezone", zone, "error", err)
+		return
+	}
+	rulesByUser := make(map[uuid.UUID][]entities.ReminderRule)
+	for _, r := range saved {
+		rulesByUser[r.UserID] = append(rulesByUser[r.UserID], r)
 	}
 
-	for _, userTasks := range byUser {
-		if len(userTasks) == 0 {
+	today := entities.DateOf(now)
+	tasksByDate := make(map[time.Time][]entities.Task)
+	for i := range users {
+		user := &users[i]
+		userPrefs, ok := wanted[user.ID]
+		if !ok {
 			continue
 		}
-		user, err := s.userRepo.FindByID(ctx, userTasks[0].UserID)
-		if err != nil || user == nil {
-			slog.Error("Notification: could not find user", "userID", userTasks[0].UserID, "error", err)
-			continue
+		rules := rulesByUser[user.ID]
+		if len(rules) == 0 {
+			rules = entities.DefaultReminderRules(user.ID)
 		}
+		for j := range rules {
+			rule := &rules[j]
+			if !rule.IsSendTime(now) {
+				continue
+			}
+			dueDate := rule.DueDate(today)
+			tasks, ok := tasksByDate[dueDate]
+			if !ok {
+				tasks, err = s.taskRepo.FindDueOnDate(ctx, zone, dueDate)
+				if err != nil {
+					slog.Error("Notification check error", "timezone", zone, "dueDate", dueDate, "error", err)
+					continue
+				}
+				tasksByDate[dueDate] = tasks
+			}
 
-		s.notifyBatch(ctx, user, userTasks, today)
-	}
-}
-
-// notifyBatch sends at most one notification per user per channel per day,
-// batching all due tasks into a single message.
-func (s *NotificationService) notifyBatch(ctx context.Context, user *entities.User, tasks []entities.Task, today time.Time) {
-	dueDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
-
-	subject := fmt.Sprintf("PoolVibes: %d task(s) due today", len(tasks))
-	body := formatBatchBody(tasks)
-
-	// Email notification — claim once per user per day
-	if s.emailNotifier != nil && user.NotifyEmail && user.Email != "" {
-		notif := entities.NewBatchNotification(user.ID, "email", dueDate)
-		claimed, err := s.notifRepo.Claim(ctx, notif)
-		if err != nil {
-			slog.Error("Email claim error", "userID", user.ID, "error", err)
-		} else if claimed {
-			if err := s.emailNotifier.Send(ctx, user.Email, subject, body); err != nil {
-				slog.Error("Email send error", "userID", user.ID, "email", user.Email, "error", err)
-				if delErr := s.notifRepo.Delete(ctx, notif.ID); delErr != nil {
-					slog.Error("Error releasing email claim", "error", delErr)
+			var userTasks []entities.Task
+			for _, t := range tasks {
+				if t.UserID == user.ID {
+					userTasks = append(userTasks, t)
 				}
-			} else {
-				slog.Info("Email notification sent", "tasks", len(tasks), "email", user.Email)
+			}
+			if len(userTasks) > 0 {
+				s.notifyBatch(ctx, user, userPrefs, userTasks, rule, dueDate)
 			}
 		}
 	}
+}
 
-	// SMS notification — claim once per user per day
-	if s.smsNotifier != nil && user.NotifySMS && user.Phone != "" {
-		notif := entities.NewBatchNotification(user.ID, "sms", dueDate)
-		claimed, err := s.notifRepo.Claim(ctx, notif)
-		if err != nil {
-			slog.Error("SMS claim error", "userID", user.ID, "error", err)
-		} else if claimed {
-			if err := s.smsNotifier.Send(ctx, user.Phone, subject, body); err != nil {
-				slog.Error("SMS send error", "userID", user.ID, "phone", user.Phone, "error", err)
-				if delErr := s.notifRepo.Delete(ctx, notif.ID); delErr != nil {
-					slog.Error("Error releasing SMS claim", "error", delErr)
-				}
+// notifyBatch queues at most one notification per reminder rule per
+// channel per due date, batching all the tasks into a single message.
+// Emails also carry a summary of the user's latest water test.
+func (s *NotificationService) notifyBatch(ctx context.Context, user *entities.User, prefs *entities.NotificationPreferences, tasks []entities.Task, rule *entities.ReminderRule, dueDate time.Time) {
+	subject, body := reminderMessage(rule, tasks)
+	url := s.emails.appURL()
+	if len(tasks) == 1 {
+		url = s.emails.completeURL(&tasks[0])
+	}
+	for _, channel := range entities.NotificationChannels {
+		if !prefs.IsEnabled(entities.CategoryReminders, channel) || user.Address(channel) == "" || !s.outbox.Enabled(channel) {
+			continue
+		}
+		msg := valueobjects.Message{Subject: subject, Text: body, URL: url}
+		if channel == entities.ChannelSMS && s.replies != nil {
+			msg.Text += "\n\n" + replyHint(len(tasks))
+		}
+		if channel == entities.ChannelEmail {
+			email, err := s.emails.reminder(user, subject, rule, tasks, s.latestLog(ctx, user))
+			if err != nil {
+				slog.Error("Reminder render error", "userID", user.ID, "error", err)
 			} else {
-				slog.Info("SMS notification sent", "tasks", len(tasks), "phone", user.Phone)
+				msg = email
 			}
 		}
+		queued, err := s.outbox.EnqueueFor(ctx, entities.NewBatchNotification(rule, channel, dueDate, tasks), user, msg)
+		if err != nil {
+			slog.Error("Reminder queue error", "userID", user.ID, "channel", channel, "error", err)
+		} else if queued {
+			slog.Info("Reminder queued", "kind", rule.Kind(), "rule", rule.Key(), "tasks", len(tasks), "channel", channel, "userID", user.ID)
+		}
 	}
 }
 
-func formatBatchBody(tasks []entities.Task) string {
+// latestLog returns the user's most recent water test, or nil if they
+// haven't logged one.
+func (s *NotificationService) latestLog(ctx context.Context, user *entities.User) *entities.ChemistryLog {
+	result, err := s.chemLogRepo.FindPaged(ctx, user.ID, repositories.ChemistryLogQuery{
+		PageSize: 1,
+		SortBy:   "tested_at",
+		SortDir:  repositories.SortDesc,
+	})
+	if err != nil {
+		slog.Error("Reminder chemistry lookup error", "userID", user.ID, "error", err)
+		return nil
+	}
+	if len(result.Items) == 0 {
+		return nil
+	}
+	return &result.Items[0]
+}
+
+// reminderMessage builds the subject and plain text body of a rule's
+// reminder.
+func reminderMessage(rule *entities.ReminderRule, tasks []entities.Task) (subject, body string) {
+	if rule.Kind() == entities.NotificationKindOverdue {
+		return fmt.Sprintf("PoolVibes: %d task(s) overdue", len(tasks)), formatOverdueBody(tasks)
+	}
+	when := reminderWhen(rule)
+	return fmt.Sprintf("PoolVibes: %d task(s) due %s", len(tasks), when), formatBatchBody(tasks, when)
+}
+
+// reminderWhen describes when a due reminder's tasks are due: "today",
+// "tomorrow" or "in N days".
+func reminderWhen(rule *entities.ReminderRule) string {
+	switch {
+	case rule.OffsetDays == -1:
+		return "tomorrow"
+	case rule.OffsetDays < 0:
+		return fmt.Sprintf("in %d days", -rule.OffsetDays)
+	default:
+		return "today"
+	}
+}
+
+// replyHint tells SMS recipients how to reply to a reminder of n tasks.
+func replyHint(n int) string {
+	if n == 1 {
+		return "Reply DONE when it's done or SNOOZE to put it off a day."
+	}
+	return "Reply DONE to mark them all done, DONE 2 for just #2, or SNOOZE 2 to put #2 off a day."
+}
+
+func formatBatchBody(tasks []entities.Task, when string) string {
 	if len(tasks) == 1 {
 		t := tasks[0]
-		body := fmt.Sprintf("Your pool maintenance task \"%s\" is due today (%s).", t.Name, t.DueDate.Format("Jan 2, 2006"))
+		body := fmt.Sprintf("Your pool maintenance task \"%s\" is due %s (%s).", t.Name, when, t.EffectiveDueDate().Format("Jan 2, 2006"))
 		if t.Description != "" {
 			body += fmt.Sprintf("\n\nDetails: %s", t.Description)
 		}
 		return body
 	}
 
-	body := fmt.Sprintf("You have %d pool maintenance tasks due today:\n", len(tasks))
+	body := fmt.Sprintf("You have %d pool maintenance tasks due %s:\n", len(tasks), when)
 	for i, t := range tasks {
 		body += fmt.Sprintf("\n%d. %s", i+1, t.Name)
 		if t.Description != "" {
@@ -153,3 +257,16 @@ func formatBatchBody(tasks []entities.Task) string {
 	}
 	return body
 }
+
+func formatOverdueBody(tasks []entities.Task) string {
+	if len(tasks) == 1 {
+		t := tasks[0]
+		return fmt.Sprintf("Your pool maintenance task \"%s\" is overdue. It was due %s.", t.Name, t.EffectiveDueDate().Format("Jan 2, 2006"))
+	}
+
+	body := fmt.Sprintf("You have %d overdue pool maintenance tasks:\n", len(tasks))
+	for i, t := range tasks {
+		body += fmt.Sprintf("\n%d. %s (due %s)", i+1, t.Name, t.EffectiveDueDate().Format("Jan 2"))
+	}
+	return body
+}
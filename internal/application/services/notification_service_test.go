@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type mockNotifRepo struct {
+	claimed map[string]bool
+	sent    []*entities.TaskNotification // reminders sent, oldest first
+}
+
+func (m *mockNotifRepo) Claim(_ context.Context, n *entities.TaskNotification) (bool, error) {
+	if m.claimed == nil {
+		m.claimed = make(map[string]bool)
+	}
+	key := n.UserID.String() + n.Type + n.Rule + n.DueDate.Format(time.DateOnly)
+	if m.claimed[key] {
+		return false, nil
+	}
+	m.claimed[key] = true
+	return true, nil
+}
+
+func (m *mockNotifRepo) FindLastSentReminder(_ context.Context, userID uuid.UUID, channel string) (*entities.TaskNotification, error) {
+	for i := len(m.sent) - 1; i >= 0; i-- {
+		if n := m.sent[i]; n.UserID == userID && n.Type == channel && n.IsReminder() {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+type recordingNotifier struct {
+	sent     []string
+	subjects []string
+	msgs     []valueobjects.Message
+}
+
+func (r *recordingNotifier) Send(_ context.Context, to string, msg valueobjects.Message) error {
+	r.sent = append(r.sent, to)
+	r.subjects = append(r.subjects, msg.Subject)
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+// defaultPrefs returns a preference repo with each user on the defaults:
+// task reminders by email.
+func defaultPrefs(users ...*entities.User) *mockPrefRepo {
+	repo := &mockPrefRepo{prefs: make(map[uuid.UUID]*entities.NotificationPreferences)}
+	for _, u := range users {
+		repo.prefs[u.ID] = entities.DefaultNotificationPreferences(u.ID)
+	}
+	return repo
+}
+
+func TestNotificationService_DueTodayAcrossTimezones(t *testing.T) {
+	users := []*entities.User{
+		{ID: uuid.New(), Email: "sydney@example.com", Timezone: "Australia/Sydney"},
+		{ID: uuid.New(), Email: "la@example.com", Timezone: "America/Los_Angeles"},
+		{ID: uuid.New(), Email: "utc@example.com", Timezone: "UTC"},
+	}
+	dueDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	taskRepo := &mockTaskRepo{zones: map[uuid.UUID]string{}}
+	for _, u := range users {
+		taskRepo.zones[u.ID] = u.Timezone
+		taskRepo.tasks = append(taskRepo.tasks, entities.Task{
+			ID: uuid.New(), UserID: u.ID, Name: "Clean filter", DueDate: dueDate, Status: entities.TaskStatusPending,
+		})
+	}
+	email := &recordingNotifier{}
+	outbox, _ := newTestOutbox(email, nil, nil)
+	svc := NewNotificationService(taskRepo, &mockUserRepo{users: users}, &mockReminderRuleRepo{}, defaultPrefs(users...), &mockChemLogRepo{}, outbox, NewEmailRenderer("", nil), nil, time.Hour)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		// The default due-day reminder goes out from 7am local time.
+		// 7am on the 10th in Sydney is 20:00 UTC on the 9th.
+		{"sydney morning", time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC), []string{"sydney@example.com"}},
+		{"utc morning", time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC), []string{"utc@example.com"}},
+		// 7am on the 10th in Los Angeles is 14:00 UTC (PDT).
+		{"los angeles morning", time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC), []string{"la@example.com"}},
+	}
+	for _, tt := range tests {
+		email.sent = nil
+		svc.checkAndNotify(context.Background(), tt.now)
+		drainOutbox(outbox)
+		if len(email.sent) != len(tt.want) || (len(tt.want) > 0 && email.sent[0] != tt.want[0]) {
+			t.Errorf("%s: sent to %v, want %v", tt.name, email.sent, tt.want)
+		}
+	}
+}
+
+func TestNotificationService_ReminderRules(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Email: "pool@example.com", Timezone: "UTC"}
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	taskRepo := &mockTaskRepo{tasks: []entities.Task{
+		{ID: uuid.New(), UserID: user.ID, Name: "Shock", DueDate: day(11), Status: entities.TaskStatusPending},
+		{ID: uuid.New(), UserID: user.ID, Name: "Brush", DueDate: day(7), Status: entities.TaskStatusOverdue},
+	}}
+	ruleRepo := &mockReminderRuleRepo{rules: []entities.ReminderRule{
+		*entities.NewReminderRule(user.ID, -1, 18),
+		*entities.NewReminderRule(user.ID, 3, 9),
+	}}
+	email := &recordingNotifier{}
+	outbox, _ := newTestOutbox(email, nil, nil)
+	svc := NewNotificationService(taskRepo, &mockUserRepo{users: []*entities.User{user}}, ruleRepo, defaultPrefs(user), &mockChemLogRepo{}, outbox, NewEmailRenderer("", nil), nil, time.Hour)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{"before any send time", time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), nil},
+		{"overdue follow-up", time.Date(2025, 3, 10, 9, 15, 0, 0, time.UTC), []string{"PoolVibes: 1 task(s) overdue"}},
+		{"already sent this morning", time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC), nil},
+		{"day before", time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC), []string{"PoolVibes: 1 task(s) due tomorrow"}},
+		{"each rule sends once", time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC), nil},
+		// The default due-day reminder isn't used once the user has rules.
+		{"due day", time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC), nil},
+	}
+	for _, tt := range tests {
+		email.subjects = nil
+		svc.checkAndNotify(context.Background(), tt.now)
+		drainOutbox(outbox)
+		if len(email.subjects) != len(tt.want) || (len(tt.want) > 0 && email.subjects[0] != tt.want[0]) {
+			t.Errorf("%s: sent %q, want %q", tt.name, email.subjects, tt.want)
+		}
+	}
+}
+
+func TestNotificationService_ReminderPreferences(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Email: "pool@example.com", Phone: "+15551234567", PhoneVerifiedAt: &phoneVerifiedAt, Timezone: "UTC"}
+	taskRepo := &mockTaskRepo{tasks: []entities.Task{
+		{ID: uuid.New(), UserID: user.ID, Name: "Shock", DueDate: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), Status: entities.TaskStatusPending},
+	}}
+	prefs := &mockPrefRepo{prefs: map[uuid.UUID]*entities.NotificationPreferences{user.ID: entities.NewNotificationPreferences(user.ID)}}
+	email, sms := &recordingNotifier{}, &recordingNotifier{}
+	outbox, _ := newTestOutbox(email, sms, nil)
+	svc := NewNotificationService(taskRepo, &mockUserRepo{users: []*entities.User{user}}, &mockReminderRuleRepo{}, prefs, &mockChemLogRepo{}, outbox, NewEmailRenderer("", nil), nil, time.Hour)
+
+	svc.checkAndNotify(context.Background(), time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC))
+	drainOutbox(outbox)
+	if len(email.sent)+len(sms.sent) != 0 {
+		t.Fatalf("sent reminders with task reminders turned off")
+	}
+
+	prefs.prefs[user.ID].Set(entities.CategoryReminders, entities.ChannelSMS, true)
+	svc.checkAndNotify(context.Background(), time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC))
+	drainOutbox(outbox)
+	if len(email.sent) != 0 || len(sms.sent) != 1 {
+		t.Errorf("sent %d emails and %d SMS, want only an SMS", len(email.sent), len(sms.sent))
+	}
+}
+
+func TestNotificationService_SMSReplyHint(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Email: "pool@example.com", Phone: "+15551234567", PhoneVerifiedAt: &phoneVerifiedAt, Timezone: "UTC"}
+	due := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	taskRepo := &mockTaskRepo{tasks: []entities.Task{
+		{ID: uuid.New(), UserID: user.ID, Name: "Shock", DueDate: due, Status: entities.TaskStatusPending},
+		{ID: uuid.New(), UserID: user.ID, Name: "Backwash", DueDate: due, Status: entities.TaskStatusPending},
+	}}
+	prefs := entities.NewNotificationPreferences(user.ID)
+	prefs.Set(entities.CategoryReminders, entities.ChannelSMS, true)
+	outbox, repo := newTestOutbox(&recordingNotifier{}, &recordingNotifier{}, nil)
+	users := &mockUserRepo{users: []*entities.User{user}}
+	notifs := &mockNotifRepo{}
+	replies := NewSMSReplyService(users, notifs, nil, stubValidator{}, "")
+	svc := NewNotificationService(taskRepo, users, &mockReminderRuleRepo{},
+		&mockPrefRepo{prefs: map[uuid.UUID]*entities.NotificationPreferences{user.ID: prefs}},
+		&mockChemLogRepo{}, outbox, NewEmailRenderer("", nil), replies, time.Hour)
+
+	svc.checkAndNotify(context.Background(), time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC))
+	if len(repo.msgs) != 1 {
+		t.Fatalf("queued %d messages, want 1", len(repo.msgs))
+	}
+	if want := "Reply DONE to mark them all done, DONE 2 for just #2, or SNOOZE 2 to put #2 off a day."; !strings.HasSuffix(repo.msgs[0].Body, want) {
+		t.Errorf("SMS = %q, want it to end %q", repo.msgs[0].Body, want)
+	}
+}
@@ -1,7 +1,15 @@
 package services
 
-import "context"
+import (
+	"context"
 
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// Notifier sends a notification on one channel. Email notifiers send the
+// HTML body, if there is one, as a multipart email with the plain text
+// body as its fallback; SMS notifiers send only the plain text; push
+// notifiers send the subject as the title and the plain text as the body.
 type Notifier interface {
-	Send(ctx context.Context, to string, subject string, body string) error
+	Send(ctx context.Context, to string, msg valueobjects.Message) error
 }
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// outboxLease is how long a worker holds the messages it claims. Messages
+// still pending after that, because the worker died, are sent again.
+const outboxLease = 10 * time.Minute
+
+// outboxBatch is how many messages the worker claims at a time.
+const outboxBatch = 50
+
+// notificationHistory is how many messages a user's history shows.
+const notificationHistory = 50
+
+// deliveryReportDays is how far back the admin delivery report counts.
+const deliveryReportDays = 7
+
+// recentDeliveryErrors is how many failures the delivery report lists.
+const recentDeliveryErrors = 20
+
+// DeliveryReport summarises recent delivery on each channel for admins.
+type DeliveryReport struct {
+	Since  time.Time
+	Stats  []repositories.OutboxStats // one per channel, in entities.NotificationChannels order
+	Errors []entities.OutboxMessage   // the most recent failed attempts
+}
+
+// OutboxService queues emails, SMS and push notifications in a durable
+// outbox and sends them.
+// A message is queued together with its claim, so it is queued at most
+// once, and failed sends are retried with backoff until they succeed or
+// are dead-lettered after entities.MaxOutboxAttempts.
+type OutboxService struct {
+	repo          repositories.OutboxRepository
+	userRepo      repositories.UserRepository // looks up push targets when sending
+	emailNotifier Notifier
+	smsNotifier   Notifier
+	pushNotifier  Notifier
+	interval      time.Duration
+	wake          chan struct{}
+}
+
+func NewOutboxService(repo repositories.OutboxRepository, userRepo repositories.UserRepository, emailNotifier, smsNotifier, pushNotifier Notifier, interval time.Duration) *OutboxService {
+	return &OutboxService{
+		repo:          repo,
+		userRepo:      userRepo,
+		emailNotifier: emailNotifier,
+		smsNotifier:   smsNotifier,
+		pushNotifier:  pushNotifier,
+		interval:      interval,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Enabled reports whether a notifier is configured for channel.
+func (s *OutboxService) Enabled(channel string) bool {
+	return s.notifier(channel) != nil
+}
+
+func (s *OutboxService) notifier(channel string) Notifier {
+	switch channel {
+	case entities.ChannelEmail:
+		return s.emailNotifier
+	case entities.ChannelSMS:
+		return s.smsNotifier
+	case entities.ChannelPush:
+		return s.pushNotifier
+	default:
+		return nil
+	}
+}
+
+// Enqueue claims notif and, if this caller won the claim, queues msg to
+// recipient on the claim's channel. It returns whether the message was
+// queued; false means it was already claimed, e.g. by another instance.
+func (s *OutboxService) Enqueue(ctx context.Context, notif *entities.TaskNotification, recipient string, msg valueobjects.Message) (bool, error) {
+	return s.enqueue(ctx, notif, recipient, msg, time.Now())
+}
+
+// EnqueueFor queues msg to user at their address on the claim's channel,
+// like Enqueue. SMS claimed during the user's quiet hours is held until
+// they end, and push notifications get the options pushOptions picks for
+// the claim. Nothing is queued if the user has no address on the channel.
+func (s *OutboxService) EnqueueFor(ctx context.Context, notif *entities.TaskNotification, user *entities.User, msg valueobjects.Message) (bool, error) {
+	recipient := user.Address(notif.Type)
+	if recipient == "" {
+		return false, nil
+	}
+	sendAt := time.Now()
+	if notif.Type == entities.ChannelSMS {
+		sendAt = user.QuietHours.Ends(sendAt.In(user.Location()))
+	}
+	if notif.Type == entities.ChannelPush {
+		msg.Push = pushOptions(notif, msg.URL)
+	}
+	return s.enqueue(ctx, notif, recipient, msg, sendAt)
+}
+
+func (s *OutboxService) enqueue(ctx context.Context, notif *entities.TaskNotification, recipient string, msg valueobjects.Message, sendAt time.Time) (bool, error) {
+	m := entities.NewOutboxMessage(notif, recipient, msg.Subject, msg.Text)
+	m.HTMLBody = msg.HTML
+	m.Headers = msg.Headers
+	m.Push = msg.Push
+	m.NextAttemptAt = &sendAt
+	queued, err := s.repo.Enqueue(ctx, notif, m)
+	if err != nil {
+		return false, fmt.Errorf("queueing %s notification: %w", notif.Type, err)
+	}
+	if queued {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return queued, nil
+}
+
+// History returns the current user's most recent notifications, newest
+// first.
+func (s *OutboxService) History(ctx context.Context) ([]entities.OutboxMessage, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindRecent(ctx, userID, notificationHistory)
+}
+
+// DeliveryReport counts the messages queued in the last week by channel
+// and outcome, and lists the latest errors.
+func (s *OutboxService) DeliveryReport(ctx context.Context) (*DeliveryReport, error) {
+	since := time.Now().AddDate(0, 0, -deliveryReportDays)
+	counts, err := s.repo.Stats(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	byChannel := make(map[string]repositories.OutboxStats, len(counts))
+	for _, c := range counts {
+		byChannel[c.Channel] = c
+	}
+	report := &DeliveryReport{Since: since}
+	for _, channel := range entities.NotificationChannels {
+		st := byChannel[channel]
+		st.Channel = channel
+		report.Stats = append(report.Stats, st)
+	}
+	report.Errors, err = s.repo.FindRecentErrors(ctx, recentDeliveryErrors)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *OutboxService) Start(ctx context.Context) {
+	slog.Info("Outbox worker started", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.deliverDue(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Outbox worker stopped")
+			return
+		case <-s.wake:
+			s.deliverDue(ctx, time.Now())
+		case <-ticker.C:
+			s.deliverDue(ctx, time.Now())
+		}
+	}
+}
+
+// deliverDue sends every pending message whose next attempt is due, a
+// batch at a time.
+func (s *OutboxService) deliverDue(ctx context.Context, now time.Time) {
+	for {
+		msgs, err := s.repo.ClaimDue(ctx, now, now.Add(outboxLease), outboxBatch)
+		if err != nil {
+			slog.Error("Outbox claim error", "error", err)
+			return
+		}
+		for i := range msgs {
+			s.send(ctx, &msgs[i], now)
+		}
+		if len(msgs) < outboxBatch || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// send attempts a message once and records the outcome.
+func (s *OutboxService) send(ctx context.Context, msg *entities.OutboxMessage, now time.Time) {
+	if reason, err := s.deliver(ctx, msg); reason != "" {
+		msg.Abandon(reason, now)
+		slog.Error("Outbox message dead-lettered", "messageID", msg.ID, "channel", msg.Channel, "error", msg.LastError)
+	} else if err != nil {
+		msg.RecordFailure(err.Error(), now)
+		if msg.Status == entities.OutboxDead {
+			slog.Error("Outbox message dead-lettered", "messageID", msg.ID, "channel", msg.Channel, "kind", msg.Kind, "attempts", msg.Attempts, "error", err)
+		} else {
+			slog.Warn("Outbox send failed", "messageID", msg.ID, "channel", msg.Channel, "kind", msg.Kind, "attempts", msg.Attempts, "nextAttempt", msg.NextAttemptAt, "error", err)
+		}
+	} else {
+		msg.RecordSuccess(now)
+		slog.Info("Notification sent", "messageID", msg.ID, "channel", msg.Channel, "kind", msg.Kind, "userID", msg.UserID)
+	}
+	if err := s.repo.Update(ctx, msg); err != nil {
+		slog.Error("Outbox update error", "messageID", msg.ID, "error", err)
+	}
+}
+
+// deliver sends msg on its channel. If the message can never be sent it
+// returns why instead of trying. Push messages go to the user's current
+// push target with their current token, so a changed token applies to
+// retries and nothing is sent once push is turned off.
+func (s *OutboxService) deliver(ctx context.Context, msg *entities.OutboxMessage) (abandon string, err error) {
+	notifier := s.notifier(msg.Channel)
+	if notifier == nil {
+		return msg.Channel + " notifications are not configured", nil
+	}
+	out := outgoing(msg)
+	if msg.Channel == entities.ChannelPush {
+		user, err := s.userRepo.FindByID(ctx, msg.UserID)
+		if err != nil {
+			return "", fmt.Errorf("looking up push target: %w", err)
+		}
+		if user == nil || !user.Push.IsSet() {
+			return "push notifications are turned off", nil
+		}
+		msg.Recipient = user.Push.Address()
+		out.PushToken = user.Push.Token
+	}
+	return "", notifier.Send(ctx, msg.Recipient, out)
+}
+
+// outgoing is the message to send for an outbox message, tagged with its
+// ID and kind.
+func outgoing(msg *entities.OutboxMessage) valueobjects.Message {
+	return valueobjects.Message{
+		Subject: msg.Subject,
+		Text:    msg.Body,
+		HTML:    msg.HTMLBody,
+		Headers: msg.Headers,
+		Push:    msg.Push,
+		Metadata: map[string]string{
+			"message_id": msg.ID.String(),
+			"kind":       msg.Kind,
+		},
+	}
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type mockOutboxRepo struct {
+	mockNotifRepo
+	msgs []*entities.OutboxMessage
+}
+
+func (m *mockOutboxRepo) Enqueue(ctx context.Context, notif *entities.TaskNotification, msg *entities.OutboxMessage) (bool, error) {
+	claimed, err := m.Claim(ctx, notif)
+	if err != nil || !claimed {
+		return false, err
+	}
+	c := *msg
+	m.msgs = append(m.msgs, &c)
+	return true, nil
+}
+
+func (m *mockOutboxRepo) ClaimDue(_ context.Context, now, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error) {
+	var out []entities.OutboxMessage
+	for _, msg := range m.msgs {
+		if len(out) == limit {
+			break
+		}
+		if msg.Status == entities.OutboxPending && !msg.NextAttemptAt.After(now) {
+			lease := leaseUntil
+			msg.NextAttemptAt = &lease
+			out = append(out, *msg)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockOutboxRepo) Update(_ context.Context, msg *entities.OutboxMessage) error {
+	for i, existing := range m.msgs {
+		if existing.ID == msg.ID {
+			c := *msg
+			m.msgs[i] = &c
+		}
+	}
+	return nil
+}
+
+func (m *mockOutboxRepo) FindRecent(_ context.Context, userID uuid.UUID, limit int) ([]entities.OutboxMessage, error) {
+	var out []entities.OutboxMessage
+	for i := len(m.msgs) - 1; i >= 0 && len(out) < limit; i-- {
+		if m.msgs[i].UserID == userID {
+			out = append(out, *m.msgs[i])
+		}
+	}
+	return out, nil
+}
+
+func (m *mockOutboxRepo) FindRecentErrors(_ context.Context, limit int) ([]entities.OutboxMessage, error) {
+	var out []entities.OutboxMessage
+	for i := len(m.msgs) - 1; i >= 0 && len(out) < limit; i-- {
+		if m.msgs[i].LastError != "" {
+			out = append(out, *m.msgs[i])
+		}
+	}
+	return out, nil
+}
+
+func (m *mockOutboxRepo) Stats(_ context.Context, since time.Time) ([]repositories.OutboxStats, error) {
+	byChannel := map[string]*repositories.OutboxStats{}
+	var out []repositories.OutboxStats
+	for _, msg := range m.msgs {
+		if msg.CreatedAt.Before(since) {
+			continue
+		}
+		st := byChannel[msg.Channel]
+		if st == nil {
+			st = &repositories.OutboxStats{Channel: msg.Channel}
+			byChannel[msg.Channel] = st
+		}
+		switch {
+		case msg.Status == entities.OutboxSent:
+			st.Sent++
+		case msg.Status == entities.OutboxDead:
+			st.Dead++
+		case msg.Attempts > 0:
+			st.Retrying++
+		default:
+			st.Pending++
+		}
+	}
+	for _, st := range byChannel {
+		out = append(out, *st)
+	}
+	return out, nil
+}
+
+// newTestOutbox returns an outbox that sends through the given notifiers,
+// any of which may be nil, looking up push targets among users.
+func newTestOutbox(email, sms, push Notifier, users ...*entities.User) (*OutboxService, *mockOutboxRepo) {
+	repo := &mockOutboxRepo{}
+	return NewOutboxService(repo, &mockUserRepo{users: users}, email, sms, push, time.Minute), repo
+}
+
+// drainOutbox sends everything queued in the outbox that is due now.
+func drainOutbox(o *OutboxService) {
+	o.deliverDue(context.Background(), time.Now())
+}
+
+// flakyNotifier fails while err is set.
+type flakyNotifier struct {
+	recordingNotifier
+	err error
+}
+
+func (f *flakyNotifier) Send(ctx context.Context, to string, msg valueobjects.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	return f.recordingNotifier.Send(ctx, to, msg)
+}
+
+func outboxClaim(userID uuid.UUID) *entities.TaskNotification {
+	rule := entities.NewReminderRule(userID, 0, 7)
+	return entities.NewBatchNotification(rule, entities.ChannelEmail, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), nil)
+}
+
+func TestOutboxService_EnqueueClaimsOnce(t *testing.T) {
+	email := &recordingNotifier{}
+	outbox, repo := newTestOutbox(email, nil, nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	for range 2 {
+		if _, err := outbox.Enqueue(ctx, outboxClaim(userID), "a@example.com", valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+	if len(repo.msgs) != 1 {
+		t.Fatalf("queued %d messages for one claim, want 1", len(repo.msgs))
+	}
+	drainOutbox(outbox)
+	drainOutbox(outbox)
+	if len(email.sent) != 1 || repo.msgs[0].Status != entities.OutboxSent || repo.msgs[0].SentAt == nil {
+		t.Errorf("sent %d, message %+v", len(email.sent), repo.msgs[0])
+	}
+}
+
+func TestOutboxService_RetriesThenDeadLetters(t *testing.T) {
+	email := &flakyNotifier{err: errors.New("resend: 503 service unavailable")}
+	outbox, repo := newTestOutbox(email, nil, nil)
+	ctx := context.Background()
+	if _, err := outbox.Enqueue(ctx, outboxClaim(uuid.New()), "a@example.com", valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	now := time.Now()
+	outbox.deliverDue(ctx, now)
+	msg := repo.msgs[0]
+	if msg.Attempts != 1 || msg.Status != entities.OutboxPending || msg.LastError != "resend: 503 service unavailable" {
+		t.Fatalf("after one failure: %+v", msg)
+	}
+	if !msg.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("next attempt %v, want a minute later", msg.NextAttemptAt)
+	}
+
+	// Not retried before the backoff has passed.
+	outbox.deliverDue(ctx, now.Add(30*time.Second))
+	if repo.msgs[0].Attempts != 1 {
+		t.Fatalf("retried after 30s")
+	}
+
+	for repo.msgs[0].Status == entities.OutboxPending {
+		now = *repo.msgs[0].NextAttemptAt
+		outbox.deliverDue(ctx, now)
+	}
+	if msg := repo.msgs[0]; msg.Status != entities.OutboxDead || msg.Attempts != entities.MaxOutboxAttempts {
+		t.Errorf("final message %+v, want dead after %d attempts", msg, entities.MaxOutboxAttempts)
+	}
+	if len(email.sent) != 0 {
+		t.Errorf("recorded %d sends from a failing notifier", len(email.sent))
+	}
+}
+
+func TestOutboxService_RecoversAfterOutage(t *testing.T) {
+	email := &flakyNotifier{err: errors.New("connection refused")}
+	outbox, repo := newTestOutbox(email, nil, nil)
+	ctx := context.Background()
+	if _, err := outbox.Enqueue(ctx, outboxClaim(uuid.New()), "a@example.com", valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	now := time.Now()
+	outbox.deliverDue(ctx, now)
+	outbox.deliverDue(ctx, now.Add(time.Minute))
+	email.err = nil
+	outbox.deliverDue(ctx, now.Add(time.Hour))
+	if msg := repo.msgs[0]; msg.Status != entities.OutboxSent || msg.Attempts != 3 || msg.LastError != "" {
+		t.Errorf("message after recovery: %+v", msg)
+	}
+	if len(email.sent) != 1 {
+		t.Errorf("sent %d emails, want 1", len(email.sent))
+	}
+}
+
+func TestOutboxService_SendsHTMLAndHeaders(t *testing.T) {
+	email := &recordingNotifier{}
+	outbox, repo := newTestOutbox(email, nil, nil)
+	ctx := context.Background()
+	msg := valueobjects.Message{
+		Subject: "subject",
+		Text:    "body",
+		HTML:    "<p>body</p>",
+		Headers: map[string]string{"List-Unsubscribe": "<https://pool.example.com/unsubscribe?token=t>"},
+	}
+	if _, err := outbox.Enqueue(ctx, outboxClaim(uuid.New()), "a@example.com", msg); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	queued := repo.msgs[0]
+	if queued.HTMLBody != "<p>body</p>" || queued.Headers["List-Unsubscribe"] == "" {
+		t.Fatalf("queued message = %+v", queued)
+	}
+	drainOutbox(outbox)
+	if len(email.msgs) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(email.msgs))
+	}
+	sent := email.msgs[0]
+	if sent.HTML != "<p>body</p>" || sent.Headers["List-Unsubscribe"] != msg.Headers["List-Unsubscribe"] {
+		t.Errorf("sent %+v, want the queued HTML and headers", sent)
+	}
+	if sent.Metadata["message_id"] != queued.ID.String() || sent.Metadata["kind"] != entities.NotificationKindDue {
+		t.Errorf("metadata = %v", sent.Metadata)
+	}
+}
+
+func TestOutboxService_QuietHours(t *testing.T) {
+	email, sms := &recordingNotifier{}, &recordingNotifier{}
+	outbox, repo := newTestOutbox(email, sms, nil)
+	user := alertUser()
+	now := time.Now().UTC()
+	user.QuietHours = entities.QuietHours{Enabled: true, Start: now.Hour(), End: (now.Hour() + 1) % 24}
+	ctx := context.Background()
+
+	for _, channel := range entities.NotificationChannels {
+		notif := entities.NewAlertNotification(user.ID, channel, entities.CategoryChemistry, "chemistry", now)
+		if _, err := outbox.EnqueueFor(ctx, notif, user, valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+			t.Fatalf("EnqueueFor() error = %v", err)
+		}
+	}
+	drainOutbox(outbox)
+	if len(email.sent) != 1 {
+		t.Errorf("sent %d emails, want email to ignore quiet hours", len(email.sent))
+	}
+	if len(sms.sent) != 0 {
+		t.Fatalf("sent SMS during quiet hours")
+	}
+	deferred := repo.msgs[1]
+	want := now.Truncate(time.Hour).Add(time.Hour)
+	if deferred.Status != entities.OutboxPending || !deferred.NextAttemptAt.Equal(want) {
+		t.Errorf("SMS %s, next attempt %v, want pending until %v", deferred.Status, deferred.NextAttemptAt, want)
+	}
+
+	// Without a phone number there's nothing to queue.
+	user.Phone = ""
+	notif := entities.NewAlertNotification(user.ID, entities.ChannelSMS, entities.CategoryNoTest, "no_test", now)
+	if queued, err := outbox.EnqueueFor(ctx, notif, user, valueobjects.Message{Subject: "subject", Text: "body"}); err != nil || queued {
+		t.Errorf("EnqueueFor() = %v, %v for a user without a phone", queued, err)
+	}
+}
+
+func TestOutboxService_PushOptions(t *testing.T) {
+	push := &recordingNotifier{}
+	user := alertUser()
+	user.Push = entities.NewPushTarget(entities.PushNtfy, "https://ntfy.sh/my-pool", "tk_secret")
+	outbox, repo := newTestOutbox(nil, nil, push, user)
+	ctx := context.Background()
+
+	notif := entities.NewAlertNotification(user.ID, entities.ChannelPush, entities.CategoryChemistry, "chemistry", time.Now())
+	msg := valueobjects.Message{Subject: "subject", Text: "body", URL: "https://pool.example.com/"}
+	if _, err := outbox.EnqueueFor(ctx, notif, user, msg); err != nil {
+		t.Fatalf("EnqueueFor() error = %v", err)
+	}
+	rule := entities.NewReminderRule(user.ID, 0, 7)
+	notif = entities.NewBatchNotification(rule, entities.ChannelPush, time.Now(), nil)
+	if _, err := outbox.EnqueueFor(ctx, notif, user, valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+		t.Fatalf("EnqueueFor() error = %v", err)
+	}
+	for _, m := range repo.msgs {
+		if len(m.Headers) != 0 {
+			t.Errorf("queued push message has headers %v, want none", m.Headers)
+		}
+	}
+	drainOutbox(outbox)
+
+	if len(push.sent) != 2 || push.sent[0] != "ntfy:https://ntfy.sh/my-pool" {
+		t.Fatalf("sent to %v, want the user's ntfy topic twice", push.sent)
+	}
+	want := valueobjects.PushOptions{Priority: 4, Tags: "warning,test_tube", Click: "https://pool.example.com/"}
+	if got := push.msgs[0]; got.Push != want || got.PushToken != "tk_secret" {
+		t.Errorf("chemistry alert options = %+v, token %q, want %+v", got.Push, got.PushToken, want)
+	}
+	if got := push.msgs[1].Push; got.Priority != valueobjects.PushPriorityDefault || got.Click != "" {
+		t.Errorf("due reminder options = %+v, want default priority and no link", got)
+	}
+}
+
+func TestOutboxService_PushTargetLookedUpWhenSent(t *testing.T) {
+	push := &recordingNotifier{}
+	user := alertUser()
+	user.Push = entities.NewPushTarget(entities.PushGotify, "https://gotify.example.com", "old_token")
+	outbox, repo := newTestOutbox(nil, nil, push, user)
+	ctx := context.Background()
+
+	enqueue := func(category entities.NotificationCategory) {
+		t.Helper()
+		notif := entities.NewAlertNotification(user.ID, entities.ChannelPush, category, string(category), time.Now())
+		if _, err := outbox.EnqueueFor(ctx, notif, user, valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+			t.Fatalf("EnqueueFor() error = %v", err)
+		}
+	}
+
+	// The token is rotated and the server moved while the message waits.
+	enqueue(entities.CategoryLowStock)
+	user.Push = entities.NewPushTarget(entities.PushGotify, "https://push.example.com", "new_token")
+	drainOutbox(outbox)
+	if len(push.msgs) != 1 || push.msgs[0].PushToken != "new_token" || push.sent[0] != "gotify:https://push.example.com" {
+		t.Fatalf("sent %d messages to %v, want one to the new server with the new token", len(push.msgs), push.sent)
+	}
+	if repo.msgs[0].Recipient != "gotify:https://push.example.com" {
+		t.Errorf("Recipient = %q, want the address it was sent to", repo.msgs[0].Recipient)
+	}
+
+	// Push is turned off while the message waits.
+	enqueue(entities.CategoryWarranty)
+	user.Push = entities.PushTarget{}
+	drainOutbox(outbox)
+	if len(push.msgs) != 1 {
+		t.Errorf("sent %d messages, want none after push was turned off", len(push.msgs)-1)
+	}
+	if msg := repo.msgs[1]; msg.Status != entities.OutboxDead {
+		t.Errorf("message after push was turned off: %+v, want dead", msg)
+	}
+}
+
+func TestOutboxService_ChannelNotConfigured(t *testing.T) {
+	outbox, repo := newTestOutbox(&recordingNotifier{}, nil, nil)
+	if outbox.Enabled(entities.ChannelSMS) || !outbox.Enabled(entities.ChannelEmail) {
+		t.Fatalf("Enabled() doesn't match the configured notifiers")
+	}
+	rule := entities.NewReminderRule(uuid.New(), 0, 7)
+	notif := entities.NewBatchNotification(rule, entities.ChannelSMS, time.Now(), nil)
+	if _, err := outbox.Enqueue(context.Background(), notif, "+15551234567", valueobjects.Message{Subject: "subject", Text: "body"}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	drainOutbox(outbox)
+	if msg := repo.msgs[0]; msg.Status != entities.OutboxDead {
+		t.Errorf("message for an unconfigured channel: %+v, want dead", msg)
+	}
+}
+
+func TestOutboxService_HistoryAndReport(t *testing.T) {
+	email := &flakyNotifier{}
+	outbox, _ := newTestOutbox(email, &recordingNotifier{}, nil)
+	user, other := alertUser(), alertUser()
+	ctx := context.Background()
+
+	rule := entities.NewReminderRule(user.ID, 0, 7)
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	mustEnqueue := func(notif *entities.TaskNotification, to string) {
+		t.Helper()
+		if _, err := outbox.Enqueue(ctx, notif, to, valueobjects.Message{Subject: "PoolVibes: 1 task(s) due today", Text: "body"}); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+	mustEnqueue(entities.NewBatchNotification(rule, entities.ChannelEmail, day, nil), user.Email)
+	mustEnqueue(entities.NewBatchNotification(rule, entities.ChannelSMS, day, nil), user.Phone)
+	drainOutbox(outbox)
+	email.err = errors.New("smtp: 421 try again later")
+	mustEnqueue(entities.NewBatchNotification(rule, entities.ChannelEmail, day.AddDate(0, 0, 1), nil), user.Email)
+	mustEnqueue(outboxClaim(other.ID), other.Email)
+	drainOutbox(outbox)
+
+	history, err := outbox.History(WithUser(ctx, user))
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 3 || history[0].Status != entities.OutboxPending || history[0].LastError == "" {
+		t.Fatalf("history = %+v, want 3 messages, newest (failed) first", history)
+	}
+
+	report, err := outbox.DeliveryReport(ctx)
+	if err != nil {
+		t.Fatalf("DeliveryReport() error = %v", err)
+	}
+	want := []repositories.OutboxStats{
+		{Channel: entities.ChannelEmail, Sent: 1, Retrying: 2},
+		{Channel: entities.ChannelSMS, Sent: 1},
+		{Channel: entities.ChannelPush},
+	}
+	if !slices.Equal(report.Stats, want) {
+		t.Errorf("stats = %+v, want %+v", report.Stats, want)
+	}
+	if len(report.Errors) != 2 {
+		t.Errorf("listed %d errors, want 2", len(report.Errors))
+	}
+}
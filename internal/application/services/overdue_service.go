@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+// OverdueService periodically stores the overdue status of tasks whose due
+// day has passed, so the database, SQL filters and the UI agree. Running it
+// on several instances at once is safe: each run is one conditional UPDATE.
+type OverdueService struct {
+	taskRepo repositories.TaskRepository
+	userRepo repositories.UserRepository
+	interval time.Duration
+}
+
+func NewOverdueService(taskRepo repositories.TaskRepository, userRepo repositories.UserRepository, interval time.Duration) *OverdueService {
+	return &OverdueService{taskRepo: taskRepo, userRepo: userRepo, interval: interval}
+}
+
+func (s *OverdueService) Start(ctx context.Context) {
+	slog.Info("Overdue scheduler started", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.run(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Overdue scheduler stopped")
+			return
+		case <-ticker.C:
+			s.run(ctx, time.Now())
+		}
+	}
+}
+
+// MarkOverdue marks every pending task due before its user's local today
+// as overdue and returns how many were marked. A task goes overdue at
+// midnight in its user's timezone.
+func (s *OverdueService) MarkOverdue(ctx context.Context, now time.Time) (int, error) {
+	zones, err := s.userRepo.FindTimezones(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, zone := range zones {
+		today := entities.DateOf(now.In(entities.LoadTimezone(zone)))
+		n, err := s.taskRepo.MarkOverdue(ctx, zone, today, now)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (s *OverdueService) run(ctx context.Context, now time.Time) {
+	n, err := s.MarkOverdue(ctx, now)
+	if err != nil {
+		slog.Error("Overdue check error", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.Info("Marked tasks overdue", "count", n)
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+func TestOverdueService_MarkOverdue(t *testing.T) {
+	userID := uuid.New()
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	now := time.Date(2025, 3, 10, 0, 5, 0, 0, time.UTC)
+
+	snoozed := day(11)
+	repo := &mockTaskRepo{tasks: []entities.Task{
+		{ID: uuid.New(), UserID: userID, Name: "yesterday", DueDate: day(9), Status: entities.TaskStatusPending},
+		{ID: uuid.New(), UserID: userID, Name: "today", DueDate: day(10), Status: entities.TaskStatusPending},
+		{ID: uuid.New(), UserID: userID, Name: "snoozed", DueDate: day(5), SnoozedUntil: &snoozed, Status: entities.TaskStatusPending},
+		{ID: uuid.New(), UserID: userID, Name: "done", DueDate: day(5), Status: entities.TaskStatusCompleted},
+	}}
+	users := &mockUserRepo{users: []*entities.User{{ID: userID, Timezone: "UTC"}}}
+	svc := NewOverdueService(repo, users, time.Hour)
+
+	n, err := svc.MarkOverdue(context.Background(), now)
+	if err != nil {
+		t.Fatalf("MarkOverdue: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("marked %d tasks, want 1", n)
+	}
+	for _, task := range repo.tasks {
+		wantOverdue := task.Name == "yesterday"
+		if (task.Status == entities.TaskStatusOverdue) != wantOverdue {
+			t.Errorf("%s: Status = %v", task.Name, task.Status)
+		}
+		if wantOverdue && (task.OverdueAt == nil || !task.OverdueAt.Equal(now)) {
+			t.Errorf("%s: OverdueAt = %v, want %v", task.Name, task.OverdueAt, now)
+		}
+	}
+
+	// A second run, e.g. on another instance, has nothing left to mark.
+	if n, _ := svc.MarkOverdue(context.Background(), now); n != 0 {
+		t.Errorf("second run marked %d tasks, want 0", n)
+	}
+}
+
+func TestOverdueService_MarkOverdue_AcrossTimezones(t *testing.T) {
+	sydney, la, utc := uuid.New(), uuid.New(), uuid.New()
+	dueDate := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)
+	// 2025-03-10 01:00 in Sydney, 2025-03-09 07:00 in Los Angeles.
+	now := time.Date(2025, 3, 9, 14, 0, 0, 0, time.UTC)
+
+	repo := &mockTaskRepo{
+		tasks: []entities.Task{
+			{ID: uuid.New(), UserID: sydney, Name: "sydney", DueDate: dueDate, Status: entities.TaskStatusPending},
+			{ID: uuid.New(), UserID: la, Name: "la", DueDate: dueDate, Status: entities.TaskStatusPending},
+			{ID: uuid.New(), UserID: utc, Name: "utc", DueDate: dueDate, Status: entities.TaskStatusPending},
+		},
+		zones: map[uuid.UUID]string{sydney: "Australia/Sydney", la: "America/Los_Angeles"},
+	}
+	users := &mockUserRepo{users: []*entities.User{
+		{ID: sydney, Timezone: "Australia/Sydney"},
+		{ID: la, Timezone: "America/Los_Angeles"},
+		{ID: utc, Timezone: "UTC"},
+	}}
+	svc := NewOverdueService(repo, users, time.Hour)
+
+	n, err := svc.MarkOverdue(context.Background(), now)
+	if err != nil {
+		t.Fatalf("MarkOverdue: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("marked %d tasks, want 1", n)
+	}
+	for _, task := range repo.tasks {
+		wantOverdue := task.Name == "sydney"
+		if (task.Status == entities.TaskStatusOverdue) != wantOverdue {
+			t.Errorf("%s: Status = %v", task.Name, task.Status)
+		}
+	}
+}
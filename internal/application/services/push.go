@@ -0,0 +1,37 @@
+package services
+
+import (
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// pushStyle returns the priority and tags of a push notification for
+// notif, so urgent alerts stand out and summaries don't buzz.
+func pushStyle(notif *entities.TaskNotification) (priority int, tags string) {
+	switch notif.Category() {
+	case entities.CategoryReminders:
+		if notif.Kind == entities.NotificationKindOverdue {
+			return valueobjects.PushPriorityHigh, "warning"
+		}
+		return valueobjects.PushPriorityDefault, "calendar"
+	case entities.CategoryChemistry:
+		return valueobjects.PushPriorityHigh, "warning,test_tube"
+	case entities.CategoryLowStock:
+		return valueobjects.PushPriorityDefault, "package"
+	case entities.CategoryWarranty:
+		return valueobjects.PushPriorityLow, "wrench"
+	case entities.CategoryNoTest:
+		return valueobjects.PushPriorityDefault, "test_tube"
+	case entities.CategoryDigest:
+		return valueobjects.PushPriorityLow, "bar_chart"
+	default:
+		return valueobjects.PushPriorityDefault, ""
+	}
+}
+
+// pushOptions returns the push options for notif, opening url when
+// tapped.
+func pushOptions(notif *entities.TaskNotification, url string) valueobjects.PushOptions {
+	priority, tags := pushStyle(notif)
+	return valueobjects.PushOptions{Priority: priority, Tags: tags, Click: url}
+}
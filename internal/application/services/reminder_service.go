@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+type ReminderService struct {
+	repo repositories.ReminderRuleRepository
+}
+
+func NewReminderService(repo repositories.ReminderRuleRepository) *ReminderService {
+	return &ReminderService{repo: repo}
+}
+
+// List returns the user's reminder rules, or the defaults if they haven't
+// set any.
+func (s *ReminderService) List(ctx context.Context) ([]entities.ReminderRule, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, _, err := s.rules(ctx, userID)
+	return rules, err
+}
+
+// Create adds a reminder rule. A user's first change saves the default
+// rules too, so adding a reminder doesn't drop the ones they already get.
+func (s *ReminderService) Create(ctx context.Context, cmd command.CreateReminderRule) (*entities.ReminderRule, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rule := entities.NewReminderRule(userID, cmd.OffsetDays, cmd.SendHour)
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	rules, saved, err := s.rules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.Key() == rule.Key() {
+			return nil, fmt.Errorf("you already have that reminder")
+		}
+	}
+	if !saved {
+		if err := s.save(ctx, rules); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Delete removes the reminder rule with the given key. The last rule can't
+// be removed; turning off email and SMS stops reminders altogether.
+func (s *ReminderService) Delete(ctx context.Context, key string) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	rules, saved, err := s.rules(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var keep []entities.ReminderRule
+	var found *entities.ReminderRule
+	for i := range rules {
+		if rules[i].Key() == key {
+			found = &rules[i]
+		} else {
+			keep = append(keep, rules[i])
+		}
+	}
+	if found == nil {
+		return fmt.Errorf("reminder not found")
+	}
+	if len(keep) == 0 {
+		return fmt.Errorf("at least one reminder is required; turn off email and SMS notifications to stop reminders")
+	}
+	if !saved {
+		return s.save(ctx, keep)
+	}
+	return s.repo.Delete(ctx, userID, found.ID)
+}
+
+// rules returns the user's saved rules, or the defaults and false if they
+// have none saved.
+func (s *ReminderService) rules(ctx context.Context, userID uuid.UUID) ([]entities.ReminderRule, bool, error) {
+	rules, err := s.repo.FindAll(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(rules) == 0 {
+		return entities.DefaultReminderRules(userID), false, nil
+	}
+	return rules, true, nil
+}
+
+func (s *ReminderService) save(ctx context.Context, rules []entities.ReminderRule) error {
+	for i := range rules {
+		if err := s.repo.Create(ctx, &rules[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// --- mock repos ---
+
+type mockReminderRuleRepo struct {
+	rules []entities.ReminderRule
+	zones map[uuid.UUID]string // user timezones; unlisted users are in UTC
+}
+
+func (m *mockReminderRuleRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.ReminderRule, error) {
+	var out []entities.ReminderRule
+	for _, r := range m.rules {
+		if r.UserID == userID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockReminderRuleRepo) FindByTimezone(_ context.Context, timezone string) ([]entities.ReminderRule, error) {
+	var out []entities.ReminderRule
+	for _, r := range m.rules {
+		zone, ok := m.zones[r.UserID]
+		if !ok {
+			zone = "UTC"
+		}
+		if zone == timezone {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockReminderRuleRepo) Create(_ context.Context, rule *entities.ReminderRule) error {
+	m.rules = append(m.rules, *rule)
+	return nil
+}
+
+func (m *mockReminderRuleRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	for i, r := range m.rules {
+		if r.ID == id && r.UserID == userID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockReminderRuleRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	var keep []entities.ReminderRule
+	for _, r := range m.rules {
+		if r.UserID != userID {
+			keep = append(keep, r)
+		}
+	}
+	m.rules = keep
+	return nil
+}
+
+// --- tests ---
+
+func ruleKeys(rules []entities.ReminderRule) map[string]bool {
+	keys := make(map[string]bool)
+	for _, r := range rules {
+		keys[r.Key()] = true
+	}
+	return keys
+}
+
+func TestReminderService_ListDefaults(t *testing.T) {
+	svc := NewReminderService(&mockReminderRuleRepo{})
+	rules, err := svc.List(userContext(uuid.New()))
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(rules) != len(entities.DefaultReminderRules(uuid.Nil)) {
+		t.Errorf("got %d rules, want the defaults", len(rules))
+	}
+}
+
+func TestReminderService_CreateKeepsDefaults(t *testing.T) {
+	repo := &mockReminderRuleRepo{}
+	svc := NewReminderService(repo)
+	ctx := userContext(uuid.New())
+
+	if _, err := svc.Create(ctx, command.CreateReminderRule{OffsetDays: -1, SendHour: 18}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	keys := ruleKeys(repo.rules)
+	for _, want := range []string{"-1@18", "+0@07", "+1@09"} {
+		if !keys[want] {
+			t.Errorf("missing rule %s after first change, got %v", want, keys)
+		}
+	}
+
+	if _, err := svc.Create(ctx, command.CreateReminderRule{OffsetDays: -1, SendHour: 18}); err == nil {
+		t.Error("expected error creating a duplicate rule")
+	}
+	if _, err := svc.Create(ctx, command.CreateReminderRule{OffsetDays: 0, SendHour: 25}); err == nil {
+		t.Error("expected validation error")
+	}
+}
+
+func TestReminderService_Delete(t *testing.T) {
+	repo := &mockReminderRuleRepo{}
+	svc := NewReminderService(repo)
+	ctx := userContext(uuid.New())
+
+	// Deleting a default rule saves the remaining defaults.
+	if err := svc.Delete(ctx, "+1@09"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if keys := ruleKeys(repo.rules); len(keys) != 1 || !keys["+0@07"] {
+		t.Fatalf("rules after delete = %v, want only +0@07", keys)
+	}
+
+	if err := svc.Delete(ctx, "+0@07"); err == nil {
+		t.Error("expected error deleting the last rule")
+	}
+	if err := svc.Delete(ctx, "-3@08"); err == nil {
+		t.Error("expected error deleting a missing rule")
+	}
+}
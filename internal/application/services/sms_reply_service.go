@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+// smsReplyHelp is sent back when a reply isn't understood.
+const smsReplyHelp = "Reply DONE to mark the tasks in your last reminder done, DONE 2 for just the second, " +
+	"or SNOOZE 1 to put the first off a day. Reply STOP to stop texts."
+
+// RequestValidator checks that an inbound webhook request was signed by
+// the SMS provider.
+type RequestValidator interface {
+	ValidateRequest(url string, body []byte, signature string) bool
+}
+
+// SMSReplyService acts on replies to text message reminders. DONE and
+// SNOOZE complete or put off the tasks the user's last SMS reminder
+// listed, and STOP and START opt the sender out of text messages and back
+// in.
+type SMSReplyService struct {
+	userRepo  repositories.UserRepository
+	notifRepo repositories.TaskNotificationRepository
+	taskSvc   *TaskService
+	validator RequestValidator
+	url       string
+}
+
+// NewSMSReplyService creates the service for replies posted to url, the
+// public address of the inbound webhook, which the provider signs.
+func NewSMSReplyService(
+	userRepo repositories.UserRepository,
+	notifRepo repositories.TaskNotificationRepository,
+	taskSvc *TaskService,
+	validator RequestValidator,
+	url string,
+) *SMSReplyService {
+	return &SMSReplyService{
+		userRepo:  userRepo,
+		notifRepo: notifRepo,
+		taskSvc:   taskSvc,
+		validator: validator,
+		url:       url,
+	}
+}
+
+// Verify reports whether an inbound request body carries the provider's
+// signature.
+func (s *SMSReplyService) Verify(body []byte, signature string) bool {
+	return s.validator.ValidateRequest(s.url, body, signature)
+}
+
+// Handle acts on a text message body from the phone number from and
+// returns the reply to send back, or "" to send none. Messages from
+// numbers no user has are ignored.
+func (s *SMSReplyService) Handle(ctx context.Context, from, body string) (string, error) {
+	users, err := s.userRepo.FindByPhone(ctx, from)
+	if err != nil {
+		return "", fmt.Errorf("finding users by phone: %w", err)
+	}
+	if len(users) == 0 {
+		return "", nil
+	}
+
+	reply := entities.ParseSMSReply(body)
+	switch reply.Action {
+	case entities.SMSActionStop, entities.SMSActionStart:
+		// The carrier confirms these itself and won't deliver anything
+		// else to a number that has just opted out.
+		return "", s.setOptedOut(ctx, users, reply.Action == entities.SMSActionStop)
+	case entities.SMSActionHelp:
+		return "", nil
+	case entities.SMSActionOther:
+		return smsReplyHelp, nil
+	}
+
+	user, notif, err := s.lastReminder(ctx, users)
+	if err != nil {
+		return "", err
+	}
+	if notif == nil {
+		return "There's no reminder to reply to yet.", nil
+	}
+	ids := notif.TaskIDs
+	if reply.Task > len(ids) {
+		return fmt.Sprintf("Your last reminder only listed %d %s. %s", len(ids), plural(len(ids), "task", "tasks"), smsReplyHelp), nil
+	}
+	if reply.Task > 0 {
+		ids = ids[reply.Task-1 : reply.Task]
+	}
+
+	ctx = WithUser(ctx, user)
+	var acted, closed, failed []string
+	for _, id := range ids {
+		task, err := s.taskSvc.Get(ctx, id.String())
+		if err != nil {
+			return "", fmt.Errorf("finding task: %w", err)
+		}
+		if task == nil {
+			continue
+		}
+		if !task.IsOpen() {
+			closed = append(closed, task.Name)
+			continue
+		}
+		result := task.Name
+		if reply.Action == entities.SMSActionDone {
+			_, err = s.taskSvc.Complete(ctx, command.CompleteTask{ID: task.ID.String()})
+		} else {
+			var snoozed *entities.Task
+			if snoozed, err = s.taskSvc.Snooze(ctx, command.SnoozeTask{ID: task.ID.String(), Days: 1}); err == nil {
+				result += ", now due " + snoozed.EffectiveDueDate().Format("Jan 2")
+			}
+		}
+		if err != nil {
+			slog.Warn("SMS reply action failed", "action", reply.Action, "taskID", id, "userID", user.ID, "error", err)
+			failed = append(failed, fmt.Sprintf("%s (%v)", task.Name, err))
+			continue
+		}
+		acted = append(acted, result)
+	}
+	slog.Info("SMS reply handled", "action", reply.Action, "tasks", len(acted), "userID", user.ID)
+	return smsReplyConfirmation(reply.Action, acted, closed, failed), nil
+}
+
+// lastReminder finds the most recent reminder sent by SMS to any of the
+// users with the sender's number, and the user it was sent to.
+func (s *SMSReplyService) lastReminder(ctx context.Context, users []entities.User) (*entities.User, *entities.TaskNotification, error) {
+	var user *entities.User
+	var last *entities.TaskNotification
+	for i := range users {
+		n, err := s.notifRepo.FindLastSentReminder(ctx, users[i].ID, entities.ChannelSMS)
+		if err != nil {
+			return nil, nil, fmt.Errorf("finding last reminder: %w", err)
+		}
+		if n != nil && (last == nil || n.SentAt.After(last.SentAt)) {
+			user, last = &users[i], n
+		}
+	}
+	return user, last, nil
+}
+
+func (s *SMSReplyService) setOptedOut(ctx context.Context, users []entities.User, optedOut bool) error {
+	for i := range users {
+		u := &users[i]
+		if u.SMSOptedOut == optedOut {
+			continue
+		}
+		u.SMSOptedOut = optedOut
+		if err := s.userRepo.Update(ctx, u); err != nil {
+			return fmt.Errorf("updating SMS opt-out: %w", err)
+		}
+		slog.Info("SMS opt-out changed", "optedOut", optedOut, "userID", u.ID)
+	}
+	return nil
+}
+
+// smsReplyConfirmation tells the user what their reply did.
+func smsReplyConfirmation(action entities.SMSAction, acted, closed, failed []string) string {
+	var parts []string
+	if len(acted) > 0 {
+		verb := "Marked done"
+		if action == entities.SMSActionSnooze {
+			verb = "Snoozed"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s.", verb, strings.Join(acted, "; ")))
+	}
+	if len(closed) > 0 {
+		parts = append(parts, fmt.Sprintf("Already closed: %s.", strings.Join(closed, "; ")))
+	}
+	if len(failed) > 0 {
+		verb := "Couldn't complete"
+		if action == entities.SMSActionSnooze {
+			verb = "Couldn't snooze"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s.", verb, strings.Join(failed, "; ")))
+	}
+	if len(parts) == 0 {
+		return "The tasks in your last reminder have been deleted."
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type stubValidator struct{ valid bool }
+
+func (v stubValidator) ValidateRequest(url string, body []byte, signature string) bool {
+	return v.valid
+}
+
+const replyPhone = "+15551234567"
+
+// newSMSReplyTest creates two tasks and a sent SMS reminder listing them.
+func newSMSReplyTest(t *testing.T) (*SMSReplyService, *mockUserRepo, *mockNotifRepo, *mockTaskRepo, []entities.Task) {
+	t.Helper()
+	user := entities.NewUser("a@example.com", "hash")
+	user.Phone, user.PhoneVerifiedAt = replyPhone, &phoneVerifiedAt
+	users := &mockUserRepo{users: []*entities.User{user}}
+	taskSvc, taskRepo, _ := newTestTaskService()
+	ctx := WithUser(context.Background(), user)
+
+	var tasks []entities.Task
+	for _, name := range []string{"Clean filter", "Backwash"} {
+		task, err := taskSvc.Create(ctx, command.CreateTask{
+			Name:                name,
+			RecurrenceFrequency: "weekly",
+			RecurrenceInterval:  1,
+			DueDate:             entities.DateOf(time.Now()),
+		})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	rule := entities.NewReminderRule(user.ID, 0, 7)
+	notifs := &mockNotifRepo{sent: []*entities.TaskNotification{
+		entities.NewBatchNotification(rule, entities.ChannelSMS, entities.DateOf(time.Now()), tasks),
+	}}
+	svc := NewSMSReplyService(users, notifs, taskSvc, stubValidator{valid: true}, "https://pool.example.com/sms/inbound")
+	return svc, users, notifs, taskRepo, tasks
+}
+
+func TestSMSReplyService_DoneAndSnooze(t *testing.T) {
+	svc, _, _, taskRepo, tasks := newSMSReplyTest(t)
+	ctx := context.Background()
+
+	reply, err := svc.Handle(ctx, replyPhone, "done 2")
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if reply != "Marked done: Backwash." {
+		t.Errorf("reply = %q", reply)
+	}
+	if taskRepo.find(tasks[1].ID).IsOpen() {
+		t.Error("DONE 2 didn't complete the second task")
+	}
+	if !taskRepo.find(tasks[0].ID).IsOpen() {
+		t.Error("DONE 2 completed the first task")
+	}
+
+	reply, _ = svc.Handle(ctx, replyPhone, "SNOOZE 1")
+	if !strings.HasPrefix(reply, "Snoozed: Clean filter, now due ") {
+		t.Errorf("reply = %q", reply)
+	}
+	if taskRepo.find(tasks[0].ID).SnoozedUntil == nil {
+		t.Error("SNOOZE 1 didn't snooze the first task")
+	}
+
+	reply, _ = svc.Handle(ctx, replyPhone, "DONE")
+	if reply != "Marked done: Clean filter. Already closed: Backwash." {
+		t.Errorf("reply = %q", reply)
+	}
+}
+
+func TestSMSReplyService_Replies(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		body string
+		want string
+	}{
+		{"unknown number", "+15550000000", "DONE", ""},
+		{"not understood", replyPhone, "thanks!", smsReplyHelp},
+		{"out of range", replyPhone, "DONE 3", "Your last reminder only listed 2 tasks. " + smsReplyHelp},
+		{"help", replyPhone, "HELP", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _, _ := newSMSReplyTest(t)
+			reply, err := svc.Handle(context.Background(), tt.from, tt.body)
+			if err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+			if reply != tt.want {
+				t.Errorf("reply = %q, want %q", reply, tt.want)
+			}
+		})
+	}
+}
+
+func TestSMSReplyService_NoReminder(t *testing.T) {
+	svc, _, notifs, _, _ := newSMSReplyTest(t)
+	notifs.sent = []*entities.TaskNotification{
+		entities.NewAlertNotification(uuid.New(), entities.ChannelSMS, entities.CategoryChemistry, "log", time.Now()),
+	}
+	reply, _ := svc.Handle(context.Background(), replyPhone, "DONE")
+	if reply != "There's no reminder to reply to yet." {
+		t.Errorf("reply = %q", reply)
+	}
+}
+
+func TestSMSReplyService_StopStart(t *testing.T) {
+	svc, users, _, _, _ := newSMSReplyTest(t)
+	ctx := context.Background()
+
+	if reply, err := svc.Handle(ctx, replyPhone, "STOP"); err != nil || reply != "" {
+		t.Fatalf("STOP = %q, %v; want no reply", reply, err)
+	}
+	if user := users.users[0]; !user.SMSOptedOut || user.Address(entities.ChannelSMS) != "" {
+		t.Error("STOP didn't opt the user out of SMS")
+	}
+	if reply, err := svc.Handle(ctx, replyPhone, "start"); err != nil || reply != "" {
+		t.Fatalf("START = %q, %v; want no reply", reply, err)
+	}
+	if user := users.users[0]; user.SMSOptedOut || user.Address(entities.ChannelSMS) != replyPhone {
+		t.Error("START didn't opt the user back in")
+	}
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/application/command"
@@ -12,11 +14,33 @@ import (
 )
 
 type TaskService struct {
-	repo repositories.TaskRepository
+	repo           repositories.TaskRepository
+	seriesRepo     repositories.TaskSeriesRepository
+	completionRepo repositories.TaskCompletionRepository
+	chemLogRepo    repositories.ChemistryLogRepository
+	srRepo         repositories.ServiceRecordRepository
+	eqRepo         repositories.EquipmentRepository
+	webhooks       *WebhookService
 }
 
-func NewTaskService(repo repositories.TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+func NewTaskService(
+	repo repositories.TaskRepository,
+	seriesRepo repositories.TaskSeriesRepository,
+	completionRepo repositories.TaskCompletionRepository,
+	chemLogRepo repositories.ChemistryLogRepository,
+	srRepo repositories.ServiceRecordRepository,
+	eqRepo repositories.EquipmentRepository,
+	webhooks *WebhookService,
+) *TaskService {
+	return &TaskService{
+		repo:           repo,
+		seriesRepo:     seriesRepo,
+		completionRepo: completionRepo,
+		chemLogRepo:    chemLogRepo,
+		srRepo:         srRepo,
+		eqRepo:         eqRepo,
+		webhooks:       webhooks,
+	}
 }
 
 func (s *TaskService) List(ctx context.Context) ([]entities.Task, error) {
@@ -48,16 +72,36 @@ func (s *TaskService) Create(ctx context.Context, cmd command.CreateTask) (*enti
 	if err != nil {
 		return nil, fmt.Errorf("recurrence: %w", err)
 	}
-	task := entities.NewTask(userID, cmd.Name, cmd.Description, rec, cmd.DueDate)
+	rec, err = rec.WithAnchor(valueobjects.Anchor(cmd.RecurrenceAnchor))
+	if err != nil {
+		return nil, fmt.Errorf("recurrence: %w", err)
+	}
+	equipmentID, err := s.resolveEquipment(ctx, userID, cmd.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+	series := entities.NewTaskSeries(userID, cmd.Name, cmd.Description, rec)
+	series.EquipmentID = equipmentID
+	series.Checklist = entities.ParseChecklist(cmd.Checklist)
+	if err := series.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	task := series.NewOccurrence(cmd.DueDate)
+	task.CheckOverdue(UserNow(ctx))
 	if err := task.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
+	if err := s.seriesRepo.Create(ctx, series); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Create(ctx, task); err != nil {
 		return nil, err
 	}
 	return task, nil
 }
 
+// Update edits a task. With TaskScopeFuture the change is also saved to the
+// series and carried to every open occurrence due after this one.
 func (s *TaskService) Update(ctx context.Context, cmd command.UpdateTask) (*entities.Task, error) {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
@@ -74,29 +118,88 @@ func (s *TaskService) Update(ctx context.Context, cmd command.UpdateTask) (*enti
 	if task == nil {
 		return nil, fmt.Errorf("task not found")
 	}
+	if err := validateScope(cmd.Scope); err != nil {
+		return nil, err
+	}
 	rec, err := valueobjects.NewRecurrence(valueobjects.Frequency(cmd.RecurrenceFrequency), cmd.RecurrenceInterval)
 	if err != nil {
 		return nil, fmt.Errorf("recurrence: %w", err)
 	}
+	rec, err = rec.WithAnchor(valueobjects.Anchor(cmd.RecurrenceAnchor))
+	if err != nil {
+		return nil, fmt.Errorf("recurrence: %w", err)
+	}
+	equipmentID, err := s.resolveEquipment(ctx, userID, cmd.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+	originalDue := task.DueDate
 	task.Name = cmd.Name
 	task.Description = cmd.Description
 	task.Recurrence = rec
+	task.EquipmentID = equipmentID
 	task.DueDate = cmd.DueDate
+	task.CheckOverdue(UserNow(ctx))
+	checklist := entities.ParseChecklist(cmd.Checklist)
+	task.SetChecklist(checklist)
 	if err := task.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
-	if err := s.repo.Update(ctx, task); err != nil {
+	if cmd.Scope != command.TaskScopeFuture {
+		if err := s.save(ctx, task); err != nil {
+			return nil, err
+		}
+		return task, nil
+	}
+
+	series, err := s.findSeries(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	series.Name = cmd.Name
+	series.Description = cmd.Description
+	series.Recurrence = rec
+	series.EquipmentID = equipmentID
+	series.Checklist = checklist
+	if err := series.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
 		return nil, err
 	}
+	if err := s.save(ctx, task); err != nil {
+		return nil, err
+	}
+
+	occurrences, err := s.repo.FindBySeriesID(ctx, userID, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range occurrences {
+		o := &occurrences[i]
+		if o.ID == task.ID || !o.IsOpen() || o.DueDate.Before(originalDue) {
+			continue
+		}
+		series.Apply(o)
+		if err := s.save(ctx, o); err != nil {
+			return nil, fmt.Errorf("updating future occurrence: %w", err)
+		}
+	}
 	return task, nil
 }
 
-func (s *TaskService) Complete(ctx context.Context, id string) (*entities.Task, error) {
+// Complete marks the task done, records what was done in a TaskCompletion
+// and schedules the next occurrence of the series. The series rather than
+// the completed occurrence decides what comes next, so one-off edits to an
+// occurrence don't carry forward. It returns nil when the series has ended.
+// With LogService set, a service record is also added to the task's
+// equipment and linked to the completion.
+func (s *TaskService) Complete(ctx context.Context, cmd command.CompleteTask) (*entities.Task, error) {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	uid, err := uuid.Parse(id)
+	uid, err := uuid.Parse(cmd.ID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid ID: %w", err)
 	}
@@ -107,24 +210,401 @@ func (s *TaskService) Complete(ctx context.Context, id string) (*entities.Task,
 	if task == nil {
 		return nil, fmt.Errorf("task not found")
 	}
-	next := task.Complete()
+	if !task.IsOpen() {
+		return nil, fmt.Errorf("task is already %s", task.Status)
+	}
+	if n := task.RequiredItemsRemaining(); n > 0 {
+		return nil, fmt.Errorf("finish the required checklist steps first (%d open)", n)
+	}
+	chemLogID, err := s.resolveChemistryLog(ctx, userID, cmd.ChemistryLogID)
+	if err != nil {
+		return nil, err
+	}
+	srID, err := s.resolveServiceRecord(ctx, userID, cmd.ServiceRecordID)
+	if err != nil {
+		return nil, err
+	}
+	var record *entities.ServiceRecord
+	if cmd.LogService {
+		if task.EquipmentID == nil {
+			return nil, fmt.Errorf("task is not linked to any equipment")
+		}
+		if srID != nil {
+			return nil, fmt.Errorf("link an existing service record or log a new one, not both")
+		}
+		record = entities.NewServiceRecord(userID, *task.EquipmentID, time.Now(), task.Name, cmd.ServiceCost, cmd.ServiceTechnician)
+		if err := record.Validate(); err != nil {
+			return nil, fmt.Errorf("service record: %w", err)
+		}
+		srID = &record.ID
+	}
+
+	series, err := s.findSeries(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Complete()
+	completion := entities.NewTaskCompletion(task, cmd.Notes, cmd.DurationMinutes, chemLogID, srID)
+	for _, p := range cmd.Photos {
+		if err := completion.AddPhoto(p.Filename, p.Data); err != nil {
+			return nil, fmt.Errorf("photo: %w", err)
+		}
+	}
+	if err := completion.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
 	if err := s.repo.Update(ctx, task); err != nil {
 		return nil, fmt.Errorf("updating completed task: %w", err)
 	}
+	if record != nil {
+		if err := s.srRepo.Create(ctx, record); err != nil {
+			return nil, fmt.Errorf("creating service record: %w", err)
+		}
+	}
+	if err := s.completionRepo.Create(ctx, completion); err != nil {
+		return nil, fmt.Errorf("recording completion: %w", err)
+	}
+	s.webhooks.Publish(ctx, entities.EventTaskCompleted, taskWebhookData(task))
+	if !series.IsActive() {
+		return nil, nil
+	}
+	next := series.NextOccurrence(task, UserNow(ctx))
 	if err := s.repo.Create(ctx, next); err != nil {
 		return nil, fmt.Errorf("creating next task: %w", err)
 	}
 	return next, nil
 }
 
-func (s *TaskService) Delete(ctx context.Context, id string) error {
+// CheckItem ticks or unticks a step on an open task's checklist.
+func (s *TaskService) CheckItem(ctx context.Context, cmd command.CheckTaskItem) (*entities.Task, error) {
+	task, err := s.Get(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+	itemID, err := uuid.Parse(cmd.ItemID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checklist item ID: %w", err)
+	}
+	if err := task.CheckItem(itemID, cmd.Checked); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveChecklist(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// Skip closes an occurrence without doing it and schedules the next one,
+// so the recurrence keeps its rhythm.
+func (s *TaskService) Skip(ctx context.Context, id string) (*entities.Task, error) {
+	task, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+	series, err := s.findSeries(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.Skip(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("updating skipped task: %w", err)
+	}
+	if !series.IsActive() {
+		return nil, nil
+	}
+	next := series.NextOccurrence(task, UserNow(ctx))
+	if err := s.repo.Create(ctx, next); err != nil {
+		return nil, fmt.Errorf("creating next task: %w", err)
+	}
+	return next, nil
+}
+
+// Snooze postpones an occurrence by a number of days. Later occurrences
+// keep their original schedule.
+func (s *TaskService) Snooze(ctx context.Context, cmd command.SnoozeTask) (*entities.Task, error) {
+	task, err := s.Get(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+	if err := task.Snooze(cmd.Days, UserNow(ctx)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// SeriesHistory is everything recorded for one recurring task.
+type SeriesHistory struct {
+	Series      *entities.TaskSeries
+	Occurrences []entities.Task // newest first
+	Completions []entities.TaskCompletion
+	Stats       SeriesStats
+}
+
+// History returns every occurrence in the task's series along with the
+// completion records captured for them and summary stats.
+func (s *TaskService) History(ctx context.Context, id string) (*SeriesHistory, error) {
+	task, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+	series, err := s.findSeries(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.repo.FindBySeriesID(ctx, task.UserID, task.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := s.completionRepo.FindBySeriesID(ctx, task.UserID, task.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	return &SeriesHistory{
+		Series:      series,
+		Occurrences: tasks,
+		Completions: completions,
+		Stats:       ComputeSeriesStats(tasks, completions, UserNow(ctx).Location()),
+	}, nil
+}
+
+func (s *TaskService) GetPhoto(ctx context.Context, id string) (*entities.TaskPhoto, error) {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+	return s.completionRepo.FindPhoto(ctx, userID, uid)
+}
+
+func (s *TaskService) resolveChemistryLog(ctx context.Context, userID uuid.UUID, id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
 	}
 	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chemistry log ID: %w", err)
+	}
+	log, err := s.chemLogRepo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil {
+		return nil, fmt.Errorf("chemistry log not found")
+	}
+	return &uid, nil
+}
+
+func (s *TaskService) resolveServiceRecord(ctx context.Context, userID uuid.UUID, id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service record ID: %w", err)
+	}
+	sr, err := s.srRepo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if sr == nil {
+		return nil, fmt.Errorf("service record not found")
+	}
+	return &uid, nil
+}
+
+func (s *TaskService) resolveEquipment(ctx context.Context, userID uuid.UUID, id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid equipment ID: %w", err)
+	}
+	eq, err := s.eqRepo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if eq == nil {
+		return nil, fmt.Errorf("equipment not found")
+	}
+	return &uid, nil
+}
+
+// Delete removes a task. With TaskScopeOccurrence the series carries on: if
+// the deleted occurrence was the only open one, the next is scheduled. With
+// TaskScopeFuture every open occurrence from this one on is removed and the
+// series is ended; completed and skipped occurrences are kept as history.
+func (s *TaskService) Delete(ctx context.Context, cmd command.DeleteTask) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	uid, err := uuid.Parse(cmd.ID)
 	if err != nil {
 		return fmt.Errorf("invalid ID: %w", err)
 	}
-	return s.repo.Delete(ctx, userID, uid)
+	if err := validateScope(cmd.Scope); err != nil {
+		return err
+	}
+	task, err := s.repo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("task not found")
+	}
+	series, err := s.findSeries(ctx, task)
+	if err != nil {
+		return err
+	}
+	occurrences, err := s.repo.FindBySeriesID(ctx, userID, series.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Scope == command.TaskScopeFuture {
+		for _, o := range occurrences {
+			if o.ID != task.ID && (!o.IsOpen() || o.DueDate.Before(task.DueDate)) {
+				continue
+			}
+			if err := s.repo.Delete(ctx, userID, o.ID); err != nil {
+				return err
+			}
+		}
+		series.End()
+		return s.seriesRepo.Update(ctx, series)
+	}
+
+	if err := s.repo.Delete(ctx, userID, task.ID); err != nil {
+		return err
+	}
+	if !task.IsOpen() || !series.IsActive() {
+		return nil
+	}
+	for _, o := range occurrences {
+		if o.ID != task.ID && o.IsOpen() {
+			return nil
+		}
+	}
+	if err := s.repo.Create(ctx, series.NextOccurrence(task, UserNow(ctx))); err != nil {
+		return fmt.Errorf("creating next task: %w", err)
+	}
+	return nil
+}
+
+// save writes an edited task along with its checklist.
+func (s *TaskService) save(ctx context.Context, t *entities.Task) error {
+	if err := s.repo.Update(ctx, t); err != nil {
+		return err
+	}
+	return s.repo.SaveChecklist(ctx, t)
+}
+
+func (s *TaskService) findSeries(ctx context.Context, task *entities.Task) (*entities.TaskSeries, error) {
+	series, err := s.seriesRepo.FindByID(ctx, task.UserID, task.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, fmt.Errorf("task series not found")
+	}
+	return series, nil
+}
+
+func validateScope(scope string) error {
+	switch scope {
+	case "", command.TaskScopeOccurrence, command.TaskScopeFuture:
+		return nil
+	}
+	return fmt.Errorf("invalid scope: %s", scope)
+}
+
+// SeriesStats summarises how reliably a recurring task gets done. Skipped
+// occurrences are counted separately and neither help nor hurt the on-time
+// figures.
+type SeriesStats struct {
+	Completed          int
+	OnTime             int
+	Late               int
+	Skipped            int
+	OnTimeRate         int // percent of completions done by the end of their due day
+	CurrentStreak      int // consecutive on-time completions, most recent first
+	AvgDurationMinutes int // average over completions that recorded a duration
+	LastCompletedAt    *time.Time
+}
+
+// ComputeSeriesStats summarises a series' occurrences, judging whether each
+// was done on time by its due day in loc, the user's timezone.
+func ComputeSeriesStats(occurrences []entities.Task, completions []entities.TaskCompletion, loc *time.Location) SeriesStats {
+	var stats SeriesStats
+	var done []entities.Task
+	for _, t := range occurrences {
+		switch {
+		case t.Status == entities.TaskStatusSkipped:
+			stats.Skipped++
+		case t.Status == entities.TaskStatusCompleted && t.CompletedAt != nil:
+			done = append(done, t)
+		}
+	}
+	if len(done) == 0 {
+		return stats
+	}
+	sort.Slice(done, func(i, j int) bool { return done[i].DueDate.After(done[j].DueDate) })
+
+	streakBroken := false
+	for _, t := range done {
+		stats.Completed++
+		if t.CompletedOnTime(loc) {
+			stats.OnTime++
+			if !streakBroken {
+				stats.CurrentStreak++
+			}
+		} else {
+			stats.Late++
+			streakBroken = true
+		}
+		if stats.LastCompletedAt == nil || t.CompletedAt.After(*stats.LastCompletedAt) {
+			stats.LastCompletedAt = t.CompletedAt
+		}
+	}
+	stats.OnTimeRate = stats.OnTime * 100 / stats.Completed
+
+	// Completions of deleted occurrences no longer count.
+	kept := make(map[uuid.UUID]bool, len(done))
+	for _, t := range done {
+		kept[t.ID] = true
+	}
+	var total, timed int
+	for _, c := range completions {
+		if kept[c.TaskID] && c.DurationMinutes > 0 {
+			total += c.DurationMinutes
+			timed++
+		}
+	}
+	if timed > 0 {
+		stats.AvgDurationMinutes = total / timed
+	}
+	return stats
 }
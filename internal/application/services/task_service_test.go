@@ -0,0 +1,747 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// --- mock repos ---
+
+type mockTaskRepo struct {
+	tasks []entities.Task
+	zones map[uuid.UUID]string // user timezones; unlisted users are in UTC
+}
+
+func (m *mockTaskRepo) inZone(t entities.Task, timezone string) bool {
+	zone, ok := m.zones[t.UserID]
+	if !ok {
+		zone = "UTC"
+	}
+	return zone == timezone
+}
+
+func (m *mockTaskRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.Task, error) {
+	var out []entities.Task
+	for _, t := range m.tasks {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTaskRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Task, error) {
+	for _, t := range m.tasks {
+		if t.ID == id && t.UserID == userID {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockTaskRepo) FindBySeriesID(_ context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.Task, error) {
+	var out []entities.Task
+	for _, t := range m.tasks {
+		if t.SeriesID == seriesID && t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTaskRepo) FindDueOnDate(_ context.Context, timezone string, date time.Time) ([]entities.Task, error) {
+	var out []entities.Task
+	for _, t := range m.tasks {
+		if m.inZone(t, timezone) && t.IsOpen() && t.EffectiveDueDate().Equal(date) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTaskRepo) MarkOverdue(_ context.Context, timezone string, dueBefore, now time.Time) (int, error) {
+	n := 0
+	for i, t := range m.tasks {
+		if m.inZone(t, timezone) && t.Status == entities.TaskStatusPending && t.EffectiveDueDate().Before(dueBefore) {
+			m.tasks[i].Status = entities.TaskStatusOverdue
+			m.tasks[i].OverdueAt = &now
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockTaskRepo) Create(_ context.Context, task *entities.Task) error {
+	m.tasks = append(m.tasks, *task)
+	return nil
+}
+
+func (m *mockTaskRepo) Update(_ context.Context, task *entities.Task) error {
+	for i, t := range m.tasks {
+		if t.ID == task.ID {
+			m.tasks[i] = *task
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskRepo) SaveChecklist(_ context.Context, task *entities.Task) error {
+	if t := m.find(task.ID); t != nil {
+		t.Checklist = task.Checklist
+	}
+	return nil
+}
+
+func (m *mockTaskRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	for i, t := range m.tasks {
+		if t.ID == id && t.UserID == userID {
+			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskRepo) find(id uuid.UUID) *entities.Task {
+	for i := range m.tasks {
+		if m.tasks[i].ID == id {
+			return &m.tasks[i]
+		}
+	}
+	return nil
+}
+
+type mockTaskSeriesRepo struct {
+	series []entities.TaskSeries
+}
+
+func (m *mockTaskSeriesRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskSeries, error) {
+	for _, s := range m.series {
+		if s.ID == id && s.UserID == userID {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockTaskSeriesRepo) Create(_ context.Context, series *entities.TaskSeries) error {
+	m.series = append(m.series, *series)
+	return nil
+}
+
+func (m *mockTaskSeriesRepo) Update(_ context.Context, series *entities.TaskSeries) error {
+	for i, s := range m.series {
+		if s.ID == series.ID {
+			m.series[i] = *series
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskSeriesRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+type mockTaskCompletionRepo struct {
+	completions []entities.TaskCompletion
+}
+
+func (m *mockTaskCompletionRepo) FindBySeriesID(_ context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.TaskCompletion, error) {
+	return m.completions, nil
+}
+
+func (m *mockTaskCompletionRepo) FindPhoto(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskPhoto, error) {
+	return nil, nil
+}
+
+func (m *mockTaskCompletionRepo) Create(_ context.Context, completion *entities.TaskCompletion) error {
+	m.completions = append(m.completions, *completion)
+	return nil
+}
+
+func (m *mockTaskCompletionRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+type mockEquipmentRepo struct {
+	items []entities.Equipment
+}
+
+func (m *mockEquipmentRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.Equipment, error) {
+	return m.items, nil
+}
+
+func (m *mockEquipmentRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Equipment, error) {
+	for _, e := range m.items {
+		if e.ID == id && e.UserID == userID {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockEquipmentRepo) Create(_ context.Context, equipment *entities.Equipment) error {
+	m.items = append(m.items, *equipment)
+	return nil
+}
+
+func (m *mockEquipmentRepo) Update(_ context.Context, equipment *entities.Equipment) error {
+	return nil
+}
+
+func (m *mockEquipmentRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return nil
+}
+
+type mockServiceRecordRepo struct {
+	records []entities.ServiceRecord
+}
+
+func (m *mockServiceRecordRepo) FindByEquipmentID(_ context.Context, userID uuid.UUID, equipmentID uuid.UUID) ([]entities.ServiceRecord, error) {
+	return m.records, nil
+}
+
+func (m *mockServiceRecordRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.ServiceRecord, error) {
+	for _, r := range m.records {
+		if r.ID == id && r.UserID == userID {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockServiceRecordRepo) Create(_ context.Context, record *entities.ServiceRecord) error {
+	m.records = append(m.records, *record)
+	return nil
+}
+
+func (m *mockServiceRecordRepo) Update(_ context.Context, record *entities.ServiceRecord) error {
+	return nil
+}
+
+func (m *mockServiceRecordRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return nil
+}
+
+// --- helpers ---
+
+func newTestTaskService() (*TaskService, *mockTaskRepo, *mockTaskSeriesRepo) {
+	taskRepo := &mockTaskRepo{}
+	seriesRepo := &mockTaskSeriesRepo{}
+	return NewTaskService(taskRepo, seriesRepo, &mockTaskCompletionRepo{}, nil, nil, nil, nil), taskRepo, seriesRepo
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	return WithUser(context.Background(), &entities.User{ID: userID})
+}
+
+// seedSeries creates a weekly series with one completed occurrence followed
+// by the given number of open ones, oldest first.
+func seedSeries(t *testing.T, svc *TaskService, taskRepo *mockTaskRepo, ctx context.Context, open int) []uuid.UUID {
+	t.Helper()
+	first, err := svc.Create(ctx, command.CreateTask{
+		Name:                "Clean filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  1,
+		DueDate:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	done := taskRepo.find(first.ID)
+	done.Complete()
+
+	ids := []uuid.UUID{first.ID}
+	prev := *done
+	for i := 0; i < open; i++ {
+		next := prev
+		next.ID = uuid.New()
+		next.Status = entities.TaskStatusPending
+		next.CompletedAt = nil
+		next.DueDate = prev.DueDate.AddDate(0, 0, 7)
+		taskRepo.tasks = append(taskRepo.tasks, next)
+		ids = append(ids, next.ID)
+		prev = next
+	}
+	return ids
+}
+
+// --- tests ---
+
+func TestTaskService_Create_StartsSeries(t *testing.T) {
+	svc, taskRepo, seriesRepo := newTestTaskService()
+	ctx := userContext(uuid.New())
+
+	task, err := svc.Create(ctx, command.CreateTask{
+		Name:                "Clean filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  2,
+		DueDate:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(seriesRepo.series) != 1 || len(taskRepo.tasks) != 1 {
+		t.Fatalf("expected 1 series and 1 task, got %d and %d", len(seriesRepo.series), len(taskRepo.tasks))
+	}
+	if task.SeriesID != seriesRepo.series[0].ID {
+		t.Errorf("task SeriesID = %v, want %v", task.SeriesID, seriesRepo.series[0].ID)
+	}
+}
+
+func TestTaskService_Update_Scopes(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        string
+		wantLater    string
+		wantSeries   string
+		wantInterval int
+	}{
+		{"occurrence only", command.TaskScopeOccurrence, "Clean filter", "Clean filter", 1},
+		{"this and future", command.TaskScopeFuture, "Deep clean filter", "Deep clean filter", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, taskRepo, seriesRepo := newTestTaskService()
+			ctx := userContext(uuid.New())
+			ids := seedSeries(t, svc, taskRepo, ctx, 2)
+			current := taskRepo.find(ids[1])
+
+			_, err := svc.Update(ctx, command.UpdateTask{
+				ID:                  ids[1].String(),
+				Scope:               tt.scope,
+				Name:                "Deep clean filter",
+				RecurrenceFrequency: "weekly",
+				RecurrenceInterval:  2,
+				DueDate:             current.DueDate,
+			})
+			if err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			if got := taskRepo.find(ids[1]).Name; got != "Deep clean filter" {
+				t.Errorf("edited occurrence Name = %q", got)
+			}
+			if got := taskRepo.find(ids[2]).Name; got != tt.wantLater {
+				t.Errorf("later occurrence Name = %q, want %q", got, tt.wantLater)
+			}
+			if got := taskRepo.find(ids[0]).Name; got != "Clean filter" {
+				t.Errorf("completed occurrence Name = %q, should be untouched", got)
+			}
+			s := seriesRepo.series[0]
+			if s.Name != tt.wantSeries || s.Recurrence.Interval != tt.wantInterval {
+				t.Errorf("series = %q every %d, want %q every %d", s.Name, s.Recurrence.Interval, tt.wantSeries, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestTaskService_Update_InvalidScope(t *testing.T) {
+	svc, taskRepo, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 1)
+
+	_, err := svc.Update(ctx, command.UpdateTask{
+		ID:                  ids[1].String(),
+		Scope:               "everything",
+		Name:                "Clean filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  1,
+		DueDate:             time.Now(),
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid scope")
+	}
+}
+
+func TestTaskService_Delete_OccurrenceKeepsSeriesGoing(t *testing.T) {
+	svc, taskRepo, seriesRepo := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 1)
+	deleted := *taskRepo.find(ids[1])
+
+	if err := svc.Delete(ctx, command.DeleteTask{ID: ids[1].String(), Scope: command.TaskScopeOccurrence}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if taskRepo.find(ids[1]) != nil {
+		t.Fatal("occurrence should be deleted")
+	}
+	if len(taskRepo.tasks) != 2 {
+		t.Fatalf("expected completed occurrence plus a new one, got %d tasks", len(taskRepo.tasks))
+	}
+	next := taskRepo.tasks[1]
+	if next.SeriesID != deleted.SeriesID || next.Status != entities.TaskStatusPending {
+		t.Errorf("next occurrence = %+v, want pending in same series", next)
+	}
+	if !next.DueDate.Equal(deleted.DueDate.AddDate(0, 0, 7)) {
+		t.Errorf("next DueDate = %v, want %v", next.DueDate, deleted.DueDate.AddDate(0, 0, 7))
+	}
+	if !seriesRepo.series[0].IsActive() {
+		t.Error("series should still be active")
+	}
+}
+
+func TestTaskService_Delete_OccurrenceWithOtherOpen(t *testing.T) {
+	svc, taskRepo, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 2)
+
+	if err := svc.Delete(ctx, command.DeleteTask{ID: ids[1].String(), Scope: command.TaskScopeOccurrence}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if len(taskRepo.tasks) != 2 {
+		t.Errorf("expected no replacement when another occurrence is open, got %d tasks", len(taskRepo.tasks))
+	}
+}
+
+func TestTaskService_Delete_FutureEndsSeries(t *testing.T) {
+	svc, taskRepo, seriesRepo := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 2)
+
+	if err := svc.Delete(ctx, command.DeleteTask{ID: ids[1].String(), Scope: command.TaskScopeFuture}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if len(taskRepo.tasks) != 1 || taskRepo.tasks[0].ID != ids[0] {
+		t.Errorf("only the completed occurrence should remain, got %d tasks", len(taskRepo.tasks))
+	}
+	if seriesRepo.series[0].IsActive() {
+		t.Error("series should be ended")
+	}
+}
+
+func TestTaskService_Delete_FutureKeepsSkipped(t *testing.T) {
+	svc, taskRepo, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 2)
+	if err := taskRepo.find(ids[2]).Skip(); err != nil {
+		t.Fatalf("Skip: %v", err)
+	}
+
+	if err := svc.Delete(ctx, command.DeleteTask{ID: ids[1].String(), Scope: command.TaskScopeFuture}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if taskRepo.find(ids[1]) != nil {
+		t.Error("selected occurrence should be deleted")
+	}
+	if taskRepo.find(ids[2]) == nil {
+		t.Error("skipped occurrence should be kept as history")
+	}
+}
+
+func TestTaskService_Skip_SchedulesNext(t *testing.T) {
+	svc, taskRepo, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 1)
+	skippedDue := taskRepo.find(ids[1]).DueDate
+
+	next, err := svc.Skip(ctx, ids[1].String())
+	if err != nil {
+		t.Fatalf("Skip: %v", err)
+	}
+	if got := taskRepo.find(ids[1]); got.Status != entities.TaskStatusSkipped || got.SkippedAt == nil {
+		t.Errorf("skipped occurrence = %+v", got)
+	}
+	if !next.DueDate.Equal(skippedDue.AddDate(0, 0, 7)) {
+		t.Errorf("next DueDate = %v, want %v", next.DueDate, skippedDue.AddDate(0, 0, 7))
+	}
+	if _, err := svc.Skip(ctx, ids[1].String()); err == nil {
+		t.Error("expected error skipping a closed occurrence")
+	}
+	if _, err := svc.Complete(ctx, command.CompleteTask{ID: ids[1].String()}); err == nil {
+		t.Error("expected error completing a skipped occurrence")
+	}
+}
+
+func TestTaskService_Snooze_KeepsSchedule(t *testing.T) {
+	svc, taskRepo, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 1)
+	originalDue := taskRepo.find(ids[1]).DueDate
+
+	if _, err := svc.Snooze(ctx, command.SnoozeTask{ID: ids[1].String(), Days: 3}); err != nil {
+		t.Fatalf("Snooze: %v", err)
+	}
+	got := taskRepo.find(ids[1])
+	if got.SnoozedUntil == nil || !got.EffectiveDueDate().After(originalDue) {
+		t.Errorf("EffectiveDueDate = %v, want after %v", got.EffectiveDueDate(), originalDue)
+	}
+	if !got.DueDate.Equal(originalDue) {
+		t.Errorf("DueDate = %v, want unchanged %v", got.DueDate, originalDue)
+	}
+	if _, err := svc.Snooze(ctx, command.SnoozeTask{ID: ids[1].String(), Days: 0}); err == nil {
+		t.Error("expected error for zero-day snooze")
+	}
+}
+
+func TestComputeSeriesStats(t *testing.T) {
+	due := func(day int) time.Time { return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC) }
+	done := func(day, hour int) *time.Time { return timePtr(time.Date(2025, 3, day, hour, 0, 0, 0, time.UTC)) }
+
+	onTime1 := entities.Task{ID: uuid.New(), Status: entities.TaskStatusCompleted, DueDate: due(1), CompletedAt: done(1, 18)}
+	late := entities.Task{ID: uuid.New(), Status: entities.TaskStatusCompleted, DueDate: due(8), CompletedAt: done(10, 9)}
+	onTime2 := entities.Task{ID: uuid.New(), Status: entities.TaskStatusCompleted, DueDate: due(15), CompletedAt: done(14, 9)}
+	onTime3 := entities.Task{ID: uuid.New(), Status: entities.TaskStatusCompleted, DueDate: due(22), CompletedAt: done(22, 23)}
+	skipped := entities.Task{ID: uuid.New(), Status: entities.TaskStatusSkipped, DueDate: due(18)}
+	open := entities.Task{ID: uuid.New(), Status: entities.TaskStatusPending, DueDate: due(29)}
+
+	completions := []entities.TaskCompletion{
+		{TaskID: onTime1.ID, DurationMinutes: 20},
+		{TaskID: late.ID, DurationMinutes: 40},
+		{TaskID: onTime2.ID},
+		{TaskID: uuid.New(), DurationMinutes: 500}, // occurrence was deleted
+	}
+
+	stats := ComputeSeriesStats([]entities.Task{open, onTime3, skipped, onTime2, late, onTime1}, completions, time.UTC)
+
+	if stats.Completed != 4 {
+		t.Errorf("Completed = %d, want 4", stats.Completed)
+	}
+	if stats.OnTime != 3 || stats.Late != 1 {
+		t.Errorf("OnTime/Late = %d/%d, want 3/1", stats.OnTime, stats.Late)
+	}
+	if stats.Skipped != 1 {
+		t.Errorf("Skipped = %d, want 1", stats.Skipped)
+	}
+	if stats.OnTimeRate != 75 {
+		t.Errorf("OnTimeRate = %d, want 75", stats.OnTimeRate)
+	}
+	if stats.CurrentStreak != 2 {
+		t.Errorf("CurrentStreak = %d, want 2", stats.CurrentStreak)
+	}
+	if stats.AvgDurationMinutes != 30 {
+		t.Errorf("AvgDurationMinutes = %d, want 30", stats.AvgDurationMinutes)
+	}
+	if stats.LastCompletedAt == nil || !stats.LastCompletedAt.Equal(*onTime3.CompletedAt) {
+		t.Errorf("LastCompletedAt = %v, want %v", stats.LastCompletedAt, onTime3.CompletedAt)
+	}
+}
+
+func TestComputeSeriesStats_UserTimezone(t *testing.T) {
+	// Done at 9pm on its due day in Los Angeles, which is the next day in UTC.
+	completedAt := time.Date(2025, 3, 2, 5, 0, 0, 0, time.UTC)
+	tasks := []entities.Task{{
+		ID:          uuid.New(),
+		Status:      entities.TaskStatusCompleted,
+		DueDate:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		CompletedAt: &completedAt,
+	}}
+
+	if stats := ComputeSeriesStats(tasks, nil, entities.LoadTimezone("America/Los_Angeles")); stats.OnTime != 1 {
+		t.Errorf("Los Angeles OnTime = %d, want 1", stats.OnTime)
+	}
+	if stats := ComputeSeriesStats(tasks, nil, time.UTC); stats.Late != 1 {
+		t.Errorf("UTC Late = %d, want 1", stats.Late)
+	}
+}
+
+func TestComputeSeriesStats_NoCompletions(t *testing.T) {
+	stats := ComputeSeriesStats([]entities.Task{{Status: entities.TaskStatusPending}}, nil, time.UTC)
+	if stats != (SeriesStats{}) {
+		t.Errorf("expected zero stats, got %+v", stats)
+	}
+}
+
+func TestTaskService_Complete_Anchors(t *testing.T) {
+	// The occurrence was due a week ago, so completing it today is late.
+	now := time.Now()
+	due := time.Date(now.Year(), now.Month(), now.Day()-7, 0, 0, 0, 0, now.Location())
+	tests := []struct {
+		anchor  string
+		wantDue time.Time
+	}{
+		{"", due.AddDate(0, 0, 14)},
+		{"due_date", due.AddDate(0, 0, 14)},
+		{"completion_date", due.AddDate(0, 0, 21)},
+	}
+	for _, tt := range tests {
+		t.Run("anchor "+tt.anchor, func(t *testing.T) {
+			svc, _, seriesRepo := newTestTaskService()
+			ctx := userContext(uuid.New())
+			task, err := svc.Create(ctx, command.CreateTask{
+				Name:                "Clean filter",
+				RecurrenceFrequency: "weekly",
+				RecurrenceInterval:  2,
+				RecurrenceAnchor:    tt.anchor,
+				DueDate:             due,
+			})
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if seriesRepo.series[0].Recurrence.Anchor == "" {
+				t.Error("series anchor should default to due_date")
+			}
+			next, err := svc.Complete(ctx, command.CompleteTask{ID: task.ID.String()})
+			if err != nil {
+				t.Fatalf("Complete: %v", err)
+			}
+			if !next.DueDate.Equal(tt.wantDue) {
+				t.Errorf("next DueDate = %v, want %v", next.DueDate, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestTaskService_Create_InvalidAnchor(t *testing.T) {
+	svc, _, _ := newTestTaskService()
+	_, err := svc.Create(userContext(uuid.New()), command.CreateTask{
+		Name:                "Clean filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  1,
+		RecurrenceAnchor:    "whenever",
+		DueDate:             time.Now(),
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid anchor")
+	}
+}
+
+func TestTaskService_Complete_RequiresChecklist(t *testing.T) {
+	svc, taskRepo, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	task, err := svc.Create(ctx, command.CreateTask{
+		Name:                "Clean filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  1,
+		DueDate:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Checklist:           "Turn off pump\n? Hose down cartridge",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(task.Checklist) != 2 {
+		t.Fatalf("Checklist has %d items, want 2", len(task.Checklist))
+	}
+
+	if _, err := svc.Complete(ctx, command.CompleteTask{ID: task.ID.String()}); err == nil {
+		t.Fatal("expected error completing with a required step open")
+	}
+	_, err = svc.CheckItem(ctx, command.CheckTaskItem{
+		TaskID:  task.ID.String(),
+		ItemID:  task.Checklist[0].ID.String(),
+		Checked: true,
+	})
+	if err != nil {
+		t.Fatalf("CheckItem: %v", err)
+	}
+	if taskRepo.find(task.ID).Checklist[0].CheckedAt == nil {
+		t.Error("checked item was not saved")
+	}
+
+	next, err := svc.Complete(ctx, command.CompleteTask{ID: task.ID.String()})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if len(next.Checklist) != 2 {
+		t.Fatalf("next Checklist has %d items, want 2", len(next.Checklist))
+	}
+	if checked, _ := next.ChecklistProgress(); checked != 0 {
+		t.Errorf("next occurrence starts with %d checked items, want 0", checked)
+	}
+}
+
+func TestTaskService_Update_FutureChecklist(t *testing.T) {
+	svc, taskRepo, seriesRepo := newTestTaskService()
+	ctx := userContext(uuid.New())
+	ids := seedSeries(t, svc, taskRepo, ctx, 2)
+	current := taskRepo.find(ids[1])
+
+	_, err := svc.Update(ctx, command.UpdateTask{
+		ID:                  ids[1].String(),
+		Scope:               command.TaskScopeFuture,
+		Name:                "Clean filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  1,
+		DueDate:             current.DueDate,
+		Checklist:           "Turn off pump\nRinse",
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := len(seriesRepo.series[0].Checklist); got != 2 {
+		t.Errorf("series Checklist has %d items, want 2", got)
+	}
+	if got := len(taskRepo.find(ids[2]).Checklist); got != 2 {
+		t.Errorf("later occurrence Checklist has %d items, want 2", got)
+	}
+	if got := len(taskRepo.find(ids[0]).Checklist); got != 0 {
+		t.Errorf("completed occurrence Checklist has %d items, should be untouched", got)
+	}
+}
+
+func TestTaskService_Complete_LogsService(t *testing.T) {
+	userID := uuid.New()
+	ctx := userContext(userID)
+	filter := entities.NewEquipment(userID, "Sand filter", entities.CategoryFilter, "", "", "", nil, nil)
+	completions := &mockTaskCompletionRepo{}
+	records := &mockServiceRecordRepo{}
+	svc := NewTaskService(&mockTaskRepo{}, &mockTaskSeriesRepo{}, completions, nil, records, &mockEquipmentRepo{items: []entities.Equipment{*filter}}, nil)
+
+	task, err := svc.Create(ctx, command.CreateTask{
+		Name:                "Backwash filter",
+		RecurrenceFrequency: "weekly",
+		RecurrenceInterval:  1,
+		DueDate:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		EquipmentID:         filter.ID.String(),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	next, err := svc.Complete(ctx, command.CompleteTask{
+		ID:                task.ID.String(),
+		LogService:        true,
+		ServiceCost:       45,
+		ServiceTechnician: "Pool Pros",
+	})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if len(records.records) != 1 {
+		t.Fatalf("expected 1 service record, got %d", len(records.records))
+	}
+	sr := records.records[0]
+	if sr.EquipmentID != filter.ID || sr.Description != "Backwash filter" || sr.Cost != 45 || sr.Technician != "Pool Pros" {
+		t.Errorf("service record = %+v", sr)
+	}
+	if c := completions.completions[0]; c.ServiceRecordID == nil || *c.ServiceRecordID != sr.ID {
+		t.Error("completion should link the new service record")
+	}
+	if next.EquipmentID == nil || *next.EquipmentID != filter.ID {
+		t.Error("next occurrence should keep the equipment link")
+	}
+}
+
+func TestTaskService_Complete_LogServiceWithoutEquipment(t *testing.T) {
+	svc, _, _ := newTestTaskService()
+	ctx := userContext(uuid.New())
+	task, err := svc.Create(ctx, command.CreateTask{
+		Name:                "Skim surface",
+		RecurrenceFrequency: "daily",
+		RecurrenceInterval:  1,
+		DueDate:             time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Complete(ctx, command.CompleteTask{ID: task.ID.String(), LogService: true}); err == nil {
+		t.Fatal("expected error logging service for a task without equipment")
+	}
+}
+
+func TestTaskService_Create_UnknownEquipment(t *testing.T) {
+	svc := NewTaskService(&mockTaskRepo{}, &mockTaskSeriesRepo{}, &mockTaskCompletionRepo{}, nil, nil, &mockEquipmentRepo{}, nil)
+	_, err := svc.Create(userContext(uuid.New()), command.CreateTask{
+		Name:                "Clean salt cell",
+		RecurrenceFrequency: "monthly",
+		RecurrenceInterval:  3,
+		DueDate:             time.Now(),
+		EquipmentID:         uuid.New().String(),
+	})
+	if err == nil {
+		t.Fatal("expected error for equipment the user doesn't own")
+	}
+}
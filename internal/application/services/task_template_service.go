@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+type TaskTemplateService struct {
+	repo       repositories.TaskTemplateRepository
+	taskRepo   repositories.TaskRepository
+	seriesRepo repositories.TaskSeriesRepository
+}
+
+func NewTaskTemplateService(repo repositories.TaskTemplateRepository, taskRepo repositories.TaskRepository, seriesRepo repositories.TaskSeriesRepository) *TaskTemplateService {
+	return &TaskTemplateService{repo: repo, taskRepo: taskRepo, seriesRepo: seriesRepo}
+}
+
+// List returns the templates the user can apply: built-in, site-wide and
+// their own.
+func (s *TaskTemplateService) List(ctx context.Context) ([]entities.TaskTemplate, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repo.FindAvailable(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(entities.BuiltInTaskTemplates(), stored...), nil
+}
+
+// ListSiteWide returns the stored templates offered to every user.
+func (s *TaskTemplateService) ListSiteWide(ctx context.Context) ([]entities.TaskTemplate, error) {
+	return s.repo.FindSiteWide(ctx)
+}
+
+// Get returns a template the user can apply, or nil if there is none.
+func (s *TaskTemplateService) Get(ctx context.Context, id string) (*entities.TaskTemplate, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+	if t := entities.FindBuiltInTaskTemplate(uid); t != nil {
+		return t, nil
+	}
+	t, err := s.repo.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || (!t.IsSiteWide() && *t.UserID != userID) {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// Apply starts a task series for every item in the template.
+func (s *TaskTemplateService) Apply(ctx context.Context, cmd command.ApplyTaskTemplate) ([]entities.Task, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := s.Get(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		return nil, fmt.Errorf("template not found")
+	}
+	start := cmd.StartDate
+	if start.IsZero() {
+		start = entities.DateOf(UserNow(ctx))
+	}
+
+	var tasks []entities.Task
+	for _, item := range tmpl.Items {
+		series := item.Series(userID)
+		task := series.NewOccurrence(item.DueDate(start))
+		if err := s.seriesRepo.Create(ctx, series); err != nil {
+			return nil, fmt.Errorf("creating task series: %w", err)
+		}
+		if err := s.taskRepo.Create(ctx, task); err != nil {
+			return nil, fmt.Errorf("creating task: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
+
+// SaveFromTasks saves the user's open recurring tasks as a new template.
+// Only admins may save site-wide templates.
+func (s *TaskTemplateService) SaveFromTasks(ctx context.Context, cmd command.SaveTaskTemplate) (*entities.TaskTemplate, error) {
+	user, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner := &user.ID
+	if cmd.SiteWide {
+		if !user.IsAdmin {
+			return nil, fmt.Errorf("only admins can save site-wide templates")
+		}
+		owner = nil
+	}
+	tasks, err := s.taskRepo.FindAll(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := entities.NewTaskTemplate(owner, cmd.Name, cmd.Description)
+	start := entities.DateOf(UserNow(ctx))
+	seen := make(map[uuid.UUID]bool)
+	for _, t := range tasks {
+		if !t.IsOpen() || seen[t.SeriesID] {
+			continue
+		}
+		seen[t.SeriesID] = true
+		name, description, rec := t.Name, t.Description, t.Recurrence
+		series, err := s.seriesRepo.FindByID(ctx, user.ID, t.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		if series != nil {
+			name, description, rec = series.Name, series.Description, series.Recurrence
+		}
+		tmpl.AddItem(name, description, rec, daysUntil(start, t.DueDate))
+	}
+	if err := tmpl.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if err := s.repo.Create(ctx, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+func (s *TaskTemplateService) Update(ctx context.Context, cmd command.UpdateTaskTemplate) (*entities.TaskTemplate, error) {
+	tmpl, err := s.findEditable(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Name = cmd.Name
+	tmpl.Description = cmd.Description
+	if err := tmpl.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if err := s.repo.Update(ctx, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+func (s *TaskTemplateService) Delete(ctx context.Context, id string) error {
+	tmpl, err := s.findEditable(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, tmpl.ID)
+}
+
+// findEditable loads a stored template the user may change: their own, or
+// any site-wide template if they are an admin. Built-in templates are
+// read-only.
+func (s *TaskTemplateService) findEditable(ctx context.Context, id string) (*entities.TaskTemplate, error) {
+	user, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+	if entities.FindBuiltInTaskTemplate(uid) != nil {
+		return nil, fmt.Errorf("built-in templates cannot be changed")
+	}
+	tmpl, err := s.repo.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil || (!tmpl.IsSiteWide() && *tmpl.UserID != user.ID) {
+		return nil, fmt.Errorf("template not found")
+	}
+	if tmpl.IsSiteWide() && !user.IsAdmin {
+		return nil, fmt.Errorf("only admins can change site-wide templates")
+	}
+	return tmpl, nil
+}
+
+// daysUntil returns the whole days from start to due's date, never negative.
+func daysUntil(start, due time.Time) int {
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(dueDay.Sub(start).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
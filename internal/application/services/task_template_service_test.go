@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// --- mock repos ---
+
+type mockTaskTemplateRepo struct {
+	templates []entities.TaskTemplate
+}
+
+func (m *mockTaskTemplateRepo) FindAvailable(_ context.Context, userID uuid.UUID) ([]entities.TaskTemplate, error) {
+	var out []entities.TaskTemplate
+	for _, t := range m.templates {
+		if t.IsSiteWide() || *t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTaskTemplateRepo) FindSiteWide(_ context.Context) ([]entities.TaskTemplate, error) {
+	var out []entities.TaskTemplate
+	for _, t := range m.templates {
+		if t.IsSiteWide() {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTaskTemplateRepo) FindByID(_ context.Context, id uuid.UUID) (*entities.TaskTemplate, error) {
+	for _, t := range m.templates {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockTaskTemplateRepo) Create(_ context.Context, t *entities.TaskTemplate) error {
+	m.templates = append(m.templates, *t)
+	return nil
+}
+
+func (m *mockTaskTemplateRepo) Update(_ context.Context, t *entities.TaskTemplate) error {
+	for i := range m.templates {
+		if m.templates[i].ID == t.ID {
+			m.templates[i] = *t
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskTemplateRepo) Delete(_ context.Context, id uuid.UUID) error {
+	for i, t := range m.templates {
+		if t.ID == id {
+			m.templates = append(m.templates[:i], m.templates[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskTemplateRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+// --- helpers ---
+
+func newTestTaskTemplateService() (*TaskTemplateService, *mockTaskTemplateRepo, *mockTaskRepo, *mockTaskSeriesRepo) {
+	repo := &mockTaskTemplateRepo{}
+	taskRepo := &mockTaskRepo{}
+	seriesRepo := &mockTaskSeriesRepo{}
+	return NewTaskTemplateService(repo, taskRepo, seriesRepo), repo, taskRepo, seriesRepo
+}
+
+func storedTemplate(owner *uuid.UUID) entities.TaskTemplate {
+	weekly, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 1)
+	t := entities.NewTaskTemplate(owner, "My routine", "")
+	t.AddItem("Skim", "", weekly, 0)
+	return *t
+}
+
+// --- tests ---
+
+func TestTaskTemplateService_List(t *testing.T) {
+	svc, repo, _, _ := newTestTaskTemplateService()
+	userID := uuid.New()
+	otherID := uuid.New()
+	repo.templates = []entities.TaskTemplate{storedTemplate(nil), storedTemplate(&userID), storedTemplate(&otherID)}
+
+	templates, err := svc.List(userContext(userID))
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	want := len(entities.BuiltInTaskTemplates()) + 2
+	if len(templates) != want {
+		t.Errorf("got %d templates, want %d (built-in, site-wide and own)", len(templates), want)
+	}
+}
+
+func TestTaskTemplateService_Apply_BuiltIn(t *testing.T) {
+	svc, _, taskRepo, seriesRepo := newTestTaskTemplateService()
+	ctx := userContext(uuid.New())
+	tmpl := entities.BuiltInTaskTemplates()[0]
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tasks, err := svc.Apply(ctx, command.ApplyTaskTemplate{ID: tmpl.ID.String(), StartDate: start})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(tasks) != len(tmpl.Items) || len(taskRepo.tasks) != len(tmpl.Items) || len(seriesRepo.series) != len(tmpl.Items) {
+		t.Fatalf("expected %d tasks and series, got %d tasks and %d series", len(tmpl.Items), len(taskRepo.tasks), len(seriesRepo.series))
+	}
+	for i, task := range tasks {
+		item := tmpl.Items[i]
+		if task.Name != item.Name || task.Recurrence != item.Recurrence {
+			t.Errorf("task %d = %q %v, want %q %v", i, task.Name, task.Recurrence, item.Name, item.Recurrence)
+		}
+		if want := start.AddDate(0, 0, item.DueInDays); !task.DueDate.Equal(want) {
+			t.Errorf("task %d DueDate = %v, want %v", i, task.DueDate, want)
+		}
+		if task.SeriesID != seriesRepo.series[i].ID {
+			t.Errorf("task %d not linked to its series", i)
+		}
+	}
+}
+
+func TestTaskTemplateService_Apply_OtherUsersTemplate(t *testing.T) {
+	svc, repo, _, _ := newTestTaskTemplateService()
+	otherID := uuid.New()
+	tmpl := storedTemplate(&otherID)
+	repo.templates = append(repo.templates, tmpl)
+
+	_, err := svc.Apply(userContext(uuid.New()), command.ApplyTaskTemplate{ID: tmpl.ID.String()})
+	if err == nil || err.Error() != "template not found" {
+		t.Errorf("err = %v, want template not found", err)
+	}
+}
+
+func TestTaskTemplateService_SaveFromTasks(t *testing.T) {
+	taskSvc, taskRepo, seriesRepo := newTestTaskService()
+	svc := NewTaskTemplateService(&mockTaskTemplateRepo{}, taskRepo, seriesRepo)
+	ctx := userContext(uuid.New())
+	// One series with a completed occurrence and two open ones.
+	seedSeries(t, taskSvc, taskRepo, ctx, 2)
+
+	tmpl, err := svc.SaveFromTasks(ctx, command.SaveTaskTemplate{Name: "Mine"})
+	if err != nil {
+		t.Fatalf("SaveFromTasks: %v", err)
+	}
+	if tmpl.IsSiteWide() {
+		t.Error("user template should not be site-wide")
+	}
+	if len(tmpl.Items) != 1 {
+		t.Fatalf("got %d items, want one per series", len(tmpl.Items))
+	}
+	if tmpl.Items[0].Name != "Clean filter" {
+		t.Errorf("item Name = %q, want %q", tmpl.Items[0].Name, "Clean filter")
+	}
+}
+
+func TestTaskTemplateService_SiteWideRequiresAdmin(t *testing.T) {
+	svc, repo, taskRepo, _ := newTestTaskTemplateService()
+	user := &entities.User{ID: uuid.New()}
+	ctx := WithUser(context.Background(), user)
+	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 1)
+	taskRepo.tasks = append(taskRepo.tasks, *entities.NewTask(user.ID, "Skim", "", rec, time.Now()))
+
+	if _, err := svc.SaveFromTasks(ctx, command.SaveTaskTemplate{Name: "Everyone", SiteWide: true}); err == nil {
+		t.Error("expected error saving site-wide template as non-admin")
+	}
+	siteWide := storedTemplate(nil)
+	repo.templates = append(repo.templates, siteWide)
+	if err := svc.Delete(ctx, siteWide.ID.String()); err == nil {
+		t.Error("expected error deleting site-wide template as non-admin")
+	}
+
+	user.IsAdmin = true
+	if _, err := svc.SaveFromTasks(ctx, command.SaveTaskTemplate{Name: "Everyone", SiteWide: true}); err != nil {
+		t.Errorf("admin SaveFromTasks: %v", err)
+	}
+	if err := svc.Delete(ctx, siteWide.ID.String()); err != nil {
+		t.Errorf("admin Delete: %v", err)
+	}
+}
+
+func TestTaskTemplateService_BuiltInIsReadOnly(t *testing.T) {
+	svc, _, _, _ := newTestTaskTemplateService()
+	ctx := WithUser(context.Background(), &entities.User{ID: uuid.New(), IsAdmin: true})
+	id := entities.BuiltInTaskTemplates()[0].ID.String()
+
+	if err := svc.Delete(ctx, id); err == nil {
+		t.Error("expected error deleting a built-in template")
+	}
+}
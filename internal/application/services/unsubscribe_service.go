@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+// UnsubscribeTTL is how long the unsubscribe link in an email works.
+const UnsubscribeTTL = 60 * 24 * time.Hour
+
+var (
+	ErrUnsubscribeInvalid = errors.New("this unsubscribe link isn't valid")
+	ErrUnsubscribeExpired = errors.New("this unsubscribe link has expired")
+)
+
+// UnsubscribeService makes and honours the unsubscribe links in emails. A
+// link's token names the user and a notification category and is signed
+// with the server's key, so it works without signing in. Following it
+// turns that category's emails off.
+type UnsubscribeService struct {
+	prefRepo repositories.NotificationPreferenceRepository
+	key      []byte
+	baseURL  string
+}
+
+// NewUnsubscribeService creates the service. key signs the tokens; links
+// made with another key are rejected. Without a baseURL there are no
+// links to put in emails.
+func NewUnsubscribeService(prefRepo repositories.NotificationPreferenceRepository, key []byte, baseURL string) *UnsubscribeService {
+	return &UnsubscribeService{
+		prefRepo: prefRepo,
+		key:      key,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// URL returns the link that unsubscribes the user from category's emails,
+// valid for UnsubscribeTTL from now, or "" if there is no base URL.
+func (s *UnsubscribeService) URL(userID uuid.UUID, category entities.NotificationCategory, now time.Time) string {
+	if s.baseURL == "" {
+		return ""
+	}
+	return s.baseURL + "/unsubscribe?token=" + url.QueryEscape(s.token(userID, category, now.Add(UnsubscribeTTL)))
+}
+
+// token is the user's ID, the expiry and the category, followed by their
+// HMAC, each part base64url encoded.
+func (s *UnsubscribeService) token(userID uuid.UUID, category entities.NotificationCategory, expires time.Time) string {
+	payload := binary.BigEndian.AppendUint64(userID[:], uint64(expires.Unix()))
+	payload = append(payload, category...)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(s.sign(payload))
+}
+
+func (s *UnsubscribeService) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte("unsubscribe\x00"))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Check returns the user and category a token unsubscribes, or
+// ErrUnsubscribeInvalid or ErrUnsubscribeExpired.
+func (s *UnsubscribeService) Check(token string, now time.Time) (uuid.UUID, entities.NotificationCategory, error) {
+	enc := base64.RawURLEncoding
+	p, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, "", ErrUnsubscribeInvalid
+	}
+	payload, err := enc.DecodeString(p)
+	if err != nil || len(payload) <= 24 {
+		return uuid.Nil, "", ErrUnsubscribeInvalid
+	}
+	mac, err := enc.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.sign(payload)) {
+		return uuid.Nil, "", ErrUnsubscribeInvalid
+	}
+	userID, _ := uuid.FromBytes(payload[:16])
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[16:24])), 0)
+	category := entities.NotificationCategory(bytes.Clone(payload[24:]))
+	if !slices.Contains(entities.NotificationCategories, category) {
+		return uuid.Nil, "", ErrUnsubscribeInvalid
+	}
+	if !now.Before(expires) {
+		return uuid.Nil, "", ErrUnsubscribeExpired
+	}
+	return userID, category, nil
+}
+
+// Unsubscribe turns off email for the category a token names and returns
+// the category. Unsubscribing twice is harmless.
+func (s *UnsubscribeService) Unsubscribe(ctx context.Context, token string) (entities.NotificationCategory, error) {
+	userID, category, err := s.Check(token, time.Now())
+	if err != nil {
+		return "", err
+	}
+	prefs, err := s.prefRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("finding notification preferences: %w", err)
+	}
+	if prefs == nil || !prefs.IsEnabled(category, entities.ChannelEmail) {
+		return category, nil
+	}
+	prefs.Set(category, entities.ChannelEmail, false)
+	if err := s.prefRepo.Save(ctx, prefs); err != nil {
+		return "", fmt.Errorf("saving notification preferences: %w", err)
+	}
+	slog.Info("Unsubscribed from emails", "category", category, "userID", userID)
+	return category, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+func TestUnsubscribeService_Check(t *testing.T) {
+	svc := NewUnsubscribeService(&mockPrefRepo{}, []byte("key"), "https://pool.example.com/")
+	userID := uuid.New()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	link, err := url.Parse(svc.URL(userID, entities.CategoryDigest, now))
+	if err != nil || link.Host != "pool.example.com" || link.Path != "/unsubscribe" {
+		t.Fatalf("URL = %v, %v", link, err)
+	}
+	token := link.Query().Get("token")
+	gotID, category, err := svc.Check(token, now.Add(UnsubscribeTTL-time.Minute))
+	if err != nil || gotID != userID || category != entities.CategoryDigest {
+		t.Errorf("Check = %v, %q, %v; want %v, digest", gotID, category, err, userID)
+	}
+
+	if _, _, err := svc.Check(token, now.Add(UnsubscribeTTL)); !errors.Is(err, ErrUnsubscribeExpired) {
+		t.Errorf("expired token: err = %v", err)
+	}
+	otherKey := NewUnsubscribeService(&mockPrefRepo{}, []byte("other key"), "https://pool.example.com")
+	if _, _, err := otherKey.Check(token, now); !errors.Is(err, ErrUnsubscribeInvalid) {
+		t.Errorf("token signed with another key: err = %v", err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := svc.token(userID, entities.CategoryReminders, now.Add(UnsubscribeTTL))
+	_, forgedSig, _ := strings.Cut(forged, ".")
+	tampered := "x" + payload[1:]
+	if payload[0] == 'x' {
+		tampered = "y" + payload[1:]
+	}
+	for _, bad := range []string{"", "abc", payload, payload + "." + forgedSig, tampered + "." + sig} {
+		if _, _, err := svc.Check(bad, now); !errors.Is(err, ErrUnsubscribeInvalid) {
+			t.Errorf("Check(%q): err = %v", bad, err)
+		}
+	}
+	if _, _, err := svc.Check(svc.token(userID, "spam", now.Add(time.Hour)), now); !errors.Is(err, ErrUnsubscribeInvalid) {
+		t.Errorf("unknown category: err = %v", err)
+	}
+	if NewUnsubscribeService(&mockPrefRepo{}, []byte("key"), "").URL(userID, entities.CategoryDigest, now) != "" {
+		t.Error("made a link without a base URL")
+	}
+}
+
+func TestUnsubscribeService_Unsubscribe(t *testing.T) {
+	userID := uuid.New()
+	prefs := entities.DefaultNotificationPreferences(userID)
+	prefs.Set(entities.CategoryReminders, entities.ChannelSMS, true)
+	prefs.Set(entities.CategoryDigest, entities.ChannelEmail, true)
+	repo := &mockPrefRepo{prefs: map[uuid.UUID]*entities.NotificationPreferences{userID: prefs}}
+	svc := NewUnsubscribeService(repo, []byte("key"), "https://pool.example.com")
+	token := svc.token(userID, entities.CategoryReminders, time.Now().Add(time.Hour))
+
+	for range 2 {
+		category, err := svc.Unsubscribe(context.Background(), token)
+		if err != nil || category != entities.CategoryReminders {
+			t.Fatalf("Unsubscribe = %q, %v", category, err)
+		}
+	}
+	got := repo.prefs[userID]
+	if got.IsEnabled(entities.CategoryReminders, entities.ChannelEmail) {
+		t.Error("reminder emails still on")
+	}
+	if !got.IsEnabled(entities.CategoryReminders, entities.ChannelSMS) || !got.IsEnabled(entities.CategoryDigest, entities.ChannelEmail) {
+		t.Error("unsubscribing turned off other notifications")
+	}
+
+	if _, err := svc.Unsubscribe(context.Background(), "bogus"); !errors.Is(err, ErrUnsubscribeInvalid) {
+		t.Errorf("bogus token: err = %v", err)
+	}
+}
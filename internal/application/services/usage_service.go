@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+// UsageService periodically recalculates every chemical's daily usage
+// from its stock ledger. Usage is otherwise only updated when stock is
+// recorded, so without this a chemical nobody has touched would keep its
+// old rate, and its run-out and reorder forecast with it.
+type UsageService struct {
+	chemRepo     repositories.ChemicalRepository
+	movementRepo repositories.StockMovementRepository
+	userRepo     repositories.UserRepository
+	interval     time.Duration
+}
+
+func NewUsageService(chemRepo repositories.ChemicalRepository, movementRepo repositories.StockMovementRepository, userRepo repositories.UserRepository, interval time.Duration) *UsageService {
+	return &UsageService{chemRepo: chemRepo, movementRepo: movementRepo, userRepo: userRepo, interval: interval}
+}
+
+func (s *UsageService) Start(ctx context.Context) {
+	slog.Info("Usage scheduler started", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.run(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Usage scheduler stopped")
+			return
+		case <-ticker.C:
+			s.run(ctx, time.Now())
+		}
+	}
+}
+
+// UpdateUsage recalculates the daily usage of every user's chemicals as of
+// now and returns how many changed.
+func (s *UsageService) UpdateUsage(ctx context.Context, now time.Time) (int, error) {
+	users, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, user := range users {
+		chems, err := s.chemRepo.FindAll(ctx, user.ID)
+		if err != nil {
+			return changed, err
+		}
+		for i := range chems {
+			chem := &chems[i]
+			movements, err := s.movementRepo.FindByChemicalID(ctx, user.ID, chem.ID)
+			if err != nil {
+				return changed, err
+			}
+			before := chem.DailyUsage
+			chem.UpdateUsage(movements, now)
+			if chem.DailyUsage == before {
+				continue
+			}
+			if err := s.chemRepo.UpdateUsage(ctx, chem); err != nil {
+				return changed, err
+			}
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func (s *UsageService) run(ctx context.Context, now time.Time) {
+	n, err := s.UpdateUsage(ctx, now)
+	if err != nil {
+		slog.Error("Usage update error", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.Info("Updated chemical usage", "count", n)
+	}
+}
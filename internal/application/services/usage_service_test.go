@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+func TestUsageService_UpdateUsage(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	user := &entities.User{ID: uuid.New()}
+	lbs := valueobjects.Quantity{Amount: 10, Unit: valueobjects.UnitPounds}
+	// Last dosed three months ago, but still using its old rate.
+	stale := entities.Chemical{ID: uuid.New(), UserID: user.ID, Name: "Shock", Stock: lbs, DailyUsage: 0.5}
+	// Dosed recently, and its rate is up to date.
+	current := entities.Chemical{ID: uuid.New(), UserID: user.ID, Name: "Chlorine", Stock: lbs, DailyUsage: 0.2}
+	// Dosed before usage was tracked.
+	untracked := entities.Chemical{ID: uuid.New(), UserID: user.ID, Name: "Acid", Stock: lbs}
+
+	movement := func(c entities.Chemical, kind entities.StockMovementKind, amount float64, at time.Time) entities.StockMovement {
+		return entities.StockMovement{ID: uuid.New(), UserID: user.ID, ChemicalID: c.ID, Kind: kind, Amount: amount, Unit: valueobjects.UnitPounds, CreatedAt: at}
+	}
+	movements := &mockStockMovementRepo{movements: []entities.StockMovement{
+		movement(stale, entities.StockPurchase, 40, daysAgo(120)),
+		movement(stale, entities.StockDose, -30, daysAgo(90)),
+		movement(current, entities.StockPurchase, 22, daysAgo(200)),
+		movement(current, entities.StockDose, -12, daysAgo(30)),
+		movement(untracked, entities.StockAdjustment, 12.8, daysAgo(14)),
+		movement(untracked, entities.StockDose, -2.8, daysAgo(1)),
+	}}
+	chems := &mockChemicalRepo{chemicals: []entities.Chemical{stale, current, untracked}}
+	svc := NewUsageService(chems, movements, &mockUserRepo{users: []*entities.User{user}}, time.Hour)
+
+	changed, err := svc.UpdateUsage(context.Background(), now)
+	if err != nil {
+		t.Fatalf("UpdateUsage() error = %v", err)
+	}
+	if changed != 2 {
+		t.Errorf("changed = %d, want 2", changed)
+	}
+	want := map[string]float64{"Shock": 0, "Chlorine": 0.2, "Acid": 0.2}
+	for _, c := range chems.chemicals {
+		if c.DailyUsage != want[c.Name] {
+			t.Errorf("%s DailyUsage = %v, want %v", c.Name, c.DailyUsage, want[c.Name])
+		}
+	}
+}
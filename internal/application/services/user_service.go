@@ -3,20 +3,32 @@ package services
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/application/command"
 	"github.com/joshthewhite/poolvibes/internal/domain/entities"
 	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
 )
 
 type UserService struct {
-	repo        repositories.UserRepository
-	sessionRepo repositories.SessionRepository
+	repo         repositories.UserRepository
+	sessionRepo  repositories.SessionRepository
+	smsNotifier  Notifier // sends phone verification codes; nil if SMS isn't configured
+	pushNotifier Notifier // sends test push notifications
 }
 
-func NewUserService(repo repositories.UserRepository, sessionRepo repositories.SessionRepository) *UserService {
-	return &UserService{repo: repo, sessionRepo: sessionRepo}
+func NewUserService(repo repositories.UserRepository, sessionRepo repositories.SessionRepository, smsNotifier, pushNotifier Notifier) *UserService {
+	return &UserService{repo: repo, sessionRepo: sessionRepo, smsNotifier: smsNotifier, pushNotifier: pushNotifier}
+}
+
+// SMSEnabled reports whether phone numbers can be verified, i.e. whether
+// SMS is configured.
+func (s *UserService) SMSEnabled() bool {
+	return s.smsNotifier != nil
 }
 
 func (s *UserService) List(ctx context.Context) ([]entities.User, error) {
@@ -89,10 +101,157 @@ func (s *UserService) UpdatePreferences(ctx context.Context, cmd command.UpdateN
 	if user == nil {
 		return nil, fmt.Errorf("user not found")
 	}
-	user.Phone = cmd.Phone
-	user.NotifyEmail = cmd.NotifyEmail
-	user.NotifySMS = cmd.NotifySMS
+	// Numbers saved before they had to include a country code are kept
+	// until the user changes them, so the rest of the form can be saved.
+	if phone := strings.TrimSpace(cmd.Phone); phone != user.Phone {
+		if err := user.SetPhone(phone); err != nil {
+			return nil, err
+		}
+	}
 	user.PoolGallons = cmd.PoolGallons
+	if cmd.Timezone != "" {
+		if err := user.SetTimezone(cmd.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SendPhoneCode changes the current user's phone number, if it differs,
+// and texts a verification code to it. No other SMS is sent to the number
+// until the code is entered with VerifyPhone. Once the user is found it is
+// returned even on error, so the caller can show its saved number.
+func (s *UserService) SendPhoneCode(ctx context.Context, phone string) (*entities.User, error) {
+	if s.smsNotifier == nil {
+		return nil, fmt.Errorf("SMS isn't set up on this server")
+	}
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	saved := *user
+	if err := user.SetPhone(phone); err != nil {
+		return &saved, err
+	}
+	code, err := user.NewPhoneCode(time.Now())
+	if err != nil {
+		return &saved, err
+	}
+	// Save before sending, so the resend limit holds even if sending fails.
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	body := fmt.Sprintf("Your PoolVibes verification code is %s. It expires in %d minutes.", code, int(entities.PhoneCodeTTL.Minutes()))
+	if err := s.smsNotifier.Send(ctx, user.Phone, valueobjects.Message{Text: body}); err != nil {
+		slog.Warn("Sending phone verification code failed", "userID", user.ID, "error", err)
+		return user, fmt.Errorf("couldn't text %s; check the number and try again in a minute", user.Phone)
+	}
+	return user, nil
+}
+
+// VerifyPhone checks a code sent by SendPhoneCode and, if it is right,
+// marks the current user's phone number verified. The user is returned
+// even if the code is wrong, along with the error.
+func (s *UserService) VerifyPhone(ctx context.Context, code string) (*entities.User, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	verifyErr := user.VerifyPhone(code, time.Now())
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, verifyErr
+}
+
+// UpdatePush changes where the current user's push notifications are
+// sent.
+func (s *UserService) UpdatePush(ctx context.Context, cmd command.UpdatePush) (*entities.User, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	if err := user.SetPush(entities.NewPushTarget(cmd.Service, cmd.URL, cmd.Token)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SendTestPush sends a push notification to the current user's saved push
+// target straight away, so they can check it arrives.
+func (s *UserService) SendTestPush(ctx context.Context) error {
+	if s.pushNotifier == nil {
+		return fmt.Errorf("push notifications aren't set up on this server")
+	}
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("user not found")
+	}
+	if !user.Push.IsSet() {
+		return fmt.Errorf("add a push URL first")
+	}
+	msg := valueobjects.Message{
+		Subject:   "PoolVibes test notification",
+		Text:      "Push notifications are working. Choose what you're sent in Notification Preferences.",
+		Push:      valueobjects.PushOptions{Tags: "white_check_mark"},
+		PushToken: user.Push.Token,
+	}
+	if err := s.pushNotifier.Send(ctx, user.Push.Address(), msg); err != nil {
+		slog.Warn("Sending test push notification failed", "userID", user.ID, "error", err)
+		return fmt.Errorf("couldn't send the test notification: %w", err)
+	}
+	return nil
+}
+
+// RegenerateCalendarToken gives the current user a new calendar feed
+// token, enabling the feed if it was off and revoking any old feed URL.
+func (s *UserService) RegenerateCalendarToken(ctx context.Context) (*entities.User, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	user.RegenerateCalendarToken()
 	if err := s.repo.Update(ctx, user); err != nil {
 		return nil, err
 	}
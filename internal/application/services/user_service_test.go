@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+var phoneCodePattern = regexp.MustCompile(`\d{6}`)
+
+func newTestUserService(user *entities.User) (*UserService, *mockUserRepo, *recordingNotifier, context.Context) {
+	repo := &mockUserRepo{users: []*entities.User{user}}
+	sms := &recordingNotifier{}
+	return NewUserService(repo, &mockSessionRepo{}, sms, nil), repo, sms, WithUser(context.Background(), user)
+}
+
+// lastPhoneCode returns the verification code in the last text sent.
+func lastPhoneCode(t *testing.T, sms *recordingNotifier) string {
+	t.Helper()
+	if len(sms.msgs) == 0 {
+		t.Fatal("no code was texted")
+	}
+	code := phoneCodePattern.FindString(sms.msgs[len(sms.msgs)-1].Text)
+	if code == "" {
+		t.Fatalf("no code in %q", sms.msgs[len(sms.msgs)-1].Text)
+	}
+	return code
+}
+
+func TestUserService_UpdatePreferences_KeepsLegacyPhone(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Phone: "555-123-4567", Timezone: "UTC"}
+	svc, repo, _, ctx := newTestUserService(user)
+
+	_, err := svc.UpdatePreferences(ctx, command.UpdateNotificationPreferences{
+		Phone:       "555-123-4567",
+		PoolGallons: 15000,
+		Timezone:    "America/Los_Angeles",
+	})
+	if err != nil {
+		t.Fatalf("UpdatePreferences with an unchanged legacy number: %v", err)
+	}
+	saved, _ := repo.FindByID(ctx, user.ID)
+	if saved.Phone != "555-123-4567" || saved.Timezone != "America/Los_Angeles" || saved.PoolGallons != 15000 {
+		t.Errorf("saved user = phone %q, timezone %q, gallons %d", saved.Phone, saved.Timezone, saved.PoolGallons)
+	}
+
+	if _, err := svc.UpdatePreferences(ctx, command.UpdateNotificationPreferences{Phone: "555-765-4321"}); err == nil {
+		t.Error("expected error for a new number without a country code")
+	}
+	if _, err := svc.UpdatePreferences(ctx, command.UpdateNotificationPreferences{Phone: "+1 (555) 765-4321"}); err != nil {
+		t.Fatalf("UpdatePreferences with a new number: %v", err)
+	}
+	if saved, _ := repo.FindByID(ctx, user.ID); saved.Phone != "+15557654321" {
+		t.Errorf("Phone = %q, want +15557654321", saved.Phone)
+	}
+}
+
+func TestUserService_SendPhoneCode_ResendLimit(t *testing.T) {
+	user := &entities.User{ID: uuid.New()}
+	svc, repo, sms, ctx := newTestUserService(user)
+
+	if _, err := svc.SendPhoneCode(ctx, "+15551234567"); err != nil {
+		t.Fatalf("SendPhoneCode: %v", err)
+	}
+	if len(sms.sent) != 1 || sms.sent[0] != "+15551234567" {
+		t.Fatalf("sent to %v, want +15551234567", sms.sent)
+	}
+	if _, err := svc.SendPhoneCode(ctx, "+15551234567"); err == nil {
+		t.Error("expected error resending straight away")
+	}
+	if len(sms.sent) != 1 {
+		t.Errorf("sent %d texts, want 1 within the resend limit", len(sms.sent))
+	}
+
+	saved, _ := repo.FindByID(ctx, user.ID)
+	saved.PhoneCode.SentAt = saved.PhoneCode.SentAt.Add(-entities.PhoneCodeResendAfter)
+	if _, err := svc.SendPhoneCode(ctx, "+15551234567"); err != nil {
+		t.Fatalf("SendPhoneCode after the resend limit: %v", err)
+	}
+	if len(sms.sent) != 2 {
+		t.Errorf("sent %d texts, want 2", len(sms.sent))
+	}
+}
+
+func TestUserService_VerifyPhone(t *testing.T) {
+	user := &entities.User{ID: uuid.New()}
+	svc, repo, sms, ctx := newTestUserService(user)
+	if _, err := svc.SendPhoneCode(ctx, "+15551234567"); err != nil {
+		t.Fatalf("SendPhoneCode: %v", err)
+	}
+
+	got, err := svc.VerifyPhone(ctx, lastPhoneCode(t, sms))
+	if err != nil {
+		t.Fatalf("VerifyPhone: %v", err)
+	}
+	if !got.PhoneVerified() {
+		t.Error("phone should be verified")
+	}
+	if saved, _ := repo.FindByID(ctx, user.ID); !saved.PhoneVerified() || saved.PhoneCode != nil {
+		t.Errorf("saved user = verified %v, code %+v", saved.PhoneVerified(), saved.PhoneCode)
+	}
+}
+
+func TestUserService_VerifyPhone_Expired(t *testing.T) {
+	user := &entities.User{ID: uuid.New()}
+	svc, repo, sms, ctx := newTestUserService(user)
+	if _, err := svc.SendPhoneCode(ctx, "+15551234567"); err != nil {
+		t.Fatalf("SendPhoneCode: %v", err)
+	}
+	saved, _ := repo.FindByID(ctx, user.ID)
+	saved.PhoneCode.ExpiresAt = time.Now().Add(-time.Second)
+
+	if _, err := svc.VerifyPhone(ctx, lastPhoneCode(t, sms)); !errors.Is(err, entities.ErrPhoneCodeExpired) {
+		t.Fatalf("VerifyPhone error = %v, want %v", err, entities.ErrPhoneCodeExpired)
+	}
+	if saved, _ := repo.FindByID(ctx, user.ID); saved.PhoneVerified() {
+		t.Error("phone should not be verified with an expired code")
+	}
+}
+
+func TestUserService_VerifyPhone_AttemptLimit(t *testing.T) {
+	user := &entities.User{ID: uuid.New()}
+	svc, repo, sms, ctx := newTestUserService(user)
+	if _, err := svc.SendPhoneCode(ctx, "+15551234567"); err != nil {
+		t.Fatalf("SendPhoneCode: %v", err)
+	}
+	code := lastPhoneCode(t, sms)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 1; i < entities.MaxPhoneCodeAttempts; i++ {
+		if _, err := svc.VerifyPhone(ctx, wrong); !errors.Is(err, entities.ErrPhoneCodeWrong) {
+			t.Fatalf("attempt %d error = %v, want %v", i, err, entities.ErrPhoneCodeWrong)
+		}
+	}
+	if _, err := svc.VerifyPhone(ctx, wrong); !errors.Is(err, entities.ErrPhoneCodeAttempts) {
+		t.Fatalf("last attempt error = %v, want %v", err, entities.ErrPhoneCodeAttempts)
+	}
+	saved, _ := repo.FindByID(ctx, user.ID)
+	if saved.PhoneCode == nil || saved.PhoneCode.Attempts != entities.MaxPhoneCodeAttempts {
+		t.Fatalf("saved code = %+v, want %d attempts", saved.PhoneCode, entities.MaxPhoneCodeAttempts)
+	}
+	if _, err := svc.VerifyPhone(ctx, code); !errors.Is(err, entities.ErrPhoneCodeAttempts) {
+		t.Errorf("right code after the limit error = %v, want %v", err, entities.ErrPhoneCodeAttempts)
+	}
+	if saved.PhoneVerified() {
+		t.Error("phone should not be verified after too many wrong codes")
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// The "data" field of each webhook event. Field names are part of the
+// public payload format, so change them with care.
+
+type chemistryLogWebhook struct {
+	ID               uuid.UUID `json:"id"`
+	TestedAt         time.Time `json:"tested_at"`
+	PH               float64   `json:"ph"`
+	FreeChlorine     float64   `json:"free_chlorine"`
+	CombinedChlorine float64   `json:"combined_chlorine"`
+	TotalAlkalinity  float64   `json:"total_alkalinity"`
+	CYA              float64   `json:"cya"`
+	CalciumHardness  float64   `json:"calcium_hardness"`
+	Temperature      float64   `json:"temperature"`
+	Notes            string    `json:"notes"`
+}
+
+func chemistryLogWebhookData(l *entities.ChemistryLog) chemistryLogWebhook {
+	return chemistryLogWebhook{
+		ID:               l.ID,
+		TestedAt:         l.TestedAt,
+		PH:               l.PH,
+		FreeChlorine:     l.FreeChlorine,
+		CombinedChlorine: l.CombinedChlorine,
+		TotalAlkalinity:  l.TotalAlkalinity,
+		CYA:              l.CYA,
+		CalciumHardness:  l.CalciumHardness,
+		Temperature:      l.Temperature,
+		Notes:            l.Notes,
+	}
+}
+
+type taskWebhook struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	DueDate     string     `json:"due_date"` // YYYY-MM-DD
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func taskWebhookData(t *entities.Task) taskWebhook {
+	return taskWebhook{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		DueDate:     t.EffectiveDueDate().Format(time.DateOnly),
+		Status:      string(t.Status),
+		CompletedAt: t.CompletedAt,
+	}
+}
+
+type chemicalWebhook struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	StockAmount    float64   `json:"stock_amount"`
+	StockUnit      string    `json:"stock_unit"`
+	AlertThreshold float64   `json:"alert_threshold"`
+}
+
+func chemicalWebhookData(c *entities.Chemical) chemicalWebhook {
+	return chemicalWebhook{
+		ID:             c.ID,
+		Name:           c.Name,
+		Type:           string(c.Type),
+		StockAmount:    c.Stock.Amount,
+		StockUnit:      string(c.Stock.Unit),
+		AlertThreshold: c.AlertThreshold,
+	}
+}
+
+type milestoneWebhook struct {
+	Milestone string    `json:"milestone"`
+	EarnedAt  time.Time `json:"earned_at"`
+}
+
+func milestoneWebhookData(m *entities.Milestone) milestoneWebhook {
+	return milestoneWebhook{Milestone: string(m.Milestone), EarnedAt: m.EarnedAt}
+}
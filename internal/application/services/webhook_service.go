@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+// WebhookSender posts a webhook payload signed with secret, returning the
+// HTTP status of the response (0 if there was none) and an error unless it
+// was a success.
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret, event, deliveryID string, payload []byte) (int, error)
+}
+
+// recentDeliveries is how many deliveries the delivery log shows.
+const recentDeliveries = 25
+
+// webhookLease is how long a worker holds the deliveries it claims.
+// Deliveries are retried after it if the worker dies mid-send.
+const webhookLease = 10 * time.Minute
+
+// webhookBatch is how many deliveries the worker claims at a time.
+const webhookBatch = 50
+
+// WebhookService manages users' webhooks and delivers events to them.
+// Events are queued as deliveries and sent by the worker in Start, which
+// retries failures with exponential backoff.
+type WebhookService struct {
+	hookRepo     repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	taskRepo     repositories.TaskRepository
+	userRepo     repositories.UserRepository
+	notifRepo    repositories.TaskNotificationRepository
+	sender       WebhookSender
+	interval     time.Duration
+	wake         chan struct{}
+}
+
+func NewWebhookService(
+	hookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	taskRepo repositories.TaskRepository,
+	userRepo repositories.UserRepository,
+	notifRepo repositories.TaskNotificationRepository,
+	sender WebhookSender,
+	interval time.Duration,
+) *WebhookService {
+	return &WebhookService{
+		hookRepo:     hookRepo,
+		deliveryRepo: deliveryRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		notifRepo:    notifRepo,
+		sender:       sender,
+		interval:     interval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]entities.Webhook, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.hookRepo.FindAll(ctx, userID)
+}
+
+func (s *WebhookService) Create(ctx context.Context, cmd command.CreateWebhook) (*entities.Webhook, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]entities.WebhookEvent, len(cmd.Events))
+	for i, e := range cmd.Events {
+		events[i] = entities.WebhookEvent(e)
+	}
+	hook := entities.NewWebhook(userID, cmd.URL, events)
+	if err := hook.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if err := s.hookRepo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id string) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+	return s.hookRepo.Delete(ctx, userID, uid)
+}
+
+// Deliveries returns the current user's most recent deliveries.
+func (s *WebhookService) Deliveries(ctx context.Context) ([]entities.WebhookDelivery, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliveryRepo.FindRecent(ctx, userID, recentDeliveries)
+}
+
+// Replay queues a past delivery's payload again as a new delivery and wakes
+// the worker to send it straight away. If that attempt fails it is retried
+// like any other.
+func (s *WebhookService) Replay(ctx context.Context, id string) (*entities.WebhookDelivery, error) {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+	original, err := s.deliveryRepo.FindByID(ctx, userID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, fmt.Errorf("delivery not found")
+	}
+	hook, err := s.hookRepo.FindByID(ctx, userID, original.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if hook == nil {
+		return nil, fmt.Errorf("webhook no longer exists")
+	}
+	d := original.Replay()
+	if err := s.deliveryRepo.Create(ctx, d); err != nil {
+		return nil, err
+	}
+	s.signal()
+	return d, nil
+}
+
+// Publish queues event for each of the current user's webhooks that
+// subscribe to it. data becomes the payload's "data" field. A nil
+// service publishes nothing.
+func (s *WebhookService) Publish(ctx context.Context, event entities.WebhookEvent, data any) {
+	if s == nil {
+		return
+	}
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return
+	}
+	s.publish(ctx, userID, event, data)
+}
+
+// MilestoneEarned publishes EventMilestoneEarned for the current user.
+func (s *WebhookService) MilestoneEarned(ctx context.Context, m *entities.Milestone) {
+	s.Publish(ctx, entities.EventMilestoneEarned, milestoneWebhookData(m))
+}
+
+func (s *WebhookService) publish(ctx context.Context, userID uuid.UUID, event entities.WebhookEvent, data any) {
+	hooks, err := s.hookRepo.FindAll(ctx, userID)
+	if err != nil {
+		slog.Error("Webhook lookup error", "userID", userID, "error", err)
+		return
+	}
+	var payload []byte
+	queued := false
+	for i := range hooks {
+		hook := &hooks[i]
+		if !hook.Subscribes(event) {
+			continue
+		}
+		if payload == nil {
+			payload, err = webhookPayload(event, data)
+			if err != nil {
+				slog.Error("Webhook payload error", "event", event, "error", err)
+				return
+			}
+		}
+		if err := s.deliveryRepo.Create(ctx, entities.NewWebhookDelivery(hook, event, payload)); err != nil {
+			slog.Error("Webhook queue error", "webhookID", hook.ID, "event", event, "error", err)
+			continue
+		}
+		queued = true
+	}
+	if queued {
+		s.signal()
+	}
+}
+
+// signal wakes the worker to send newly queued deliveries.
+func (s *WebhookService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *WebhookService) Start(ctx context.Context) {
+	slog.Info("Webhook worker started", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.checkDueTasks(ctx, time.Now())
+	s.deliverDue(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Webhook worker stopped")
+			return
+		case <-s.wake:
+			s.deliverDue(ctx, time.Now())
+		case <-ticker.C:
+			s.checkDueTasks(ctx, time.Now())
+			s.deliverDue(ctx, time.Now())
+		}
+	}
+}
+
+// deliverDue attempts every pending delivery whose next attempt is due, a
+// batch at a time.
+func (s *WebhookService) deliverDue(ctx context.Context, now time.Time) {
+	for {
+		deliveries, err := s.deliveryRepo.ClaimDue(ctx, now, now.Add(webhookLease), webhookBatch)
+		if err != nil {
+			slog.Error("Webhook delivery error", "error", err)
+			return
+		}
+		for i := range deliveries {
+			s.deliver(ctx, &deliveries[i], now)
+		}
+		if len(deliveries) < webhookBatch || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// deliver sends a claimed delivery to its webhook, or abandons it if the
+// webhook was deleted.
+func (s *WebhookService) deliver(ctx context.Context, d *entities.WebhookDelivery, now time.Time) {
+	hook, err := s.hookRepo.FindByID(ctx, d.UserID, d.WebhookID)
+	if err != nil {
+		slog.Error("Webhook lookup error", "webhookID", d.WebhookID, "error", err)
+		return
+	}
+	if hook == nil {
+		d.Abandon("webhook no longer exists", now)
+		if err := s.deliveryRepo.Update(ctx, d); err != nil {
+			slog.Error("Webhook delivery update error", "deliveryID", d.ID, "error", err)
+		}
+		return
+	}
+	s.attempt(ctx, hook, d, now)
+}
+
+// attempt sends a delivery once and records the outcome.
+func (s *WebhookService) attempt(ctx context.Context, hook *entities.Webhook, d *entities.WebhookDelivery, now time.Time) {
+	code, err := s.sender.Send(ctx, hook.URL, hook.Secret, string(d.Event), d.ID.String(), d.Payload)
+	if err != nil {
+		d.RecordFailure(code, err.Error(), now)
+		slog.Warn("Webhook delivery failed", "deliveryID", d.ID, "event", d.Event, "attempts", d.Attempts, "status", d.Status, "error", err)
+	} else {
+		d.RecordSuccess(code, now)
+		slog.Info("Webhook delivered", "deliveryID", d.ID, "event", d.Event, "code", code)
+	}
+	if err := s.deliveryRepo.Update(ctx, d); err != nil {
+		slog.Error("Webhook delivery update error", "deliveryID", d.ID, "error", err)
+	}
+}
+
+// checkDueTasks publishes task.due for the tasks due today in each
+// timezone, once per task and due date, for users with a webhook that
+// subscribes to it.
+func (s *WebhookService) checkDueTasks(ctx context.Context, now time.Time) {
+	zones, err := s.userRepo.FindTimezones(ctx)
+	if err != nil {
+		slog.Error("Webhook task check error", "error", err)
+		return
+	}
+	for _, zone := range zones {
+		hooks, err := s.hookRepo.FindByTimezone(ctx, zone)
+		if err != nil {
+			slog.Error("Webhook task check error", "timezone", zone, "error", err)
+			continue
+		}
+		subscribed := make(map[uuid.UUID]bool)
+		for _, h := range hooks {
+			if h.Subscribes(entities.EventTaskDue) {
+				subscribed[h.UserID] = true
+			}
+		}
+		if len(subscribed) == 0 {
+			continue
+		}
+
+		today := entities.DateOf(now.In(entities.LoadTimezone(zone)))
+		tasks, err := s.taskRepo.FindDueOnDate(ctx, zone, today)
+		if err != nil {
+			slog.Error("Webhook task check error", "timezone", zone, "error", err)
+			continue
+		}
+		for i := range tasks {
+			task := &tasks[i]
+			if !subscribed[task.UserID] {
+				continue
+			}
+			claimed, err := s.notifRepo.Claim(ctx, entities.NewTaskEventNotification(task, entities.EventTaskDue, today))
+			if err != nil {
+				slog.Error("Webhook task claim error", "taskID", task.ID, "error", err)
+				continue
+			}
+			if claimed {
+				s.publish(ctx, task.UserID, entities.EventTaskDue, taskWebhookData(task))
+			}
+		}
+	}
+}
+
+// webhookPayload wraps data in the envelope every delivery carries.
+func webhookPayload(event entities.WebhookEvent, data any) ([]byte, error) {
+	return json.Marshal(struct {
+		ID        uuid.UUID             `json:"id"`
+		Event     entities.WebhookEvent `json:"event"`
+		CreatedAt time.Time             `json:"created_at"`
+		Data      any                   `json:"data"`
+	}{uuid.Must(uuid.NewV7()), event, time.Now().UTC(), data})
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type mockWebhookRepo struct {
+	hooks []entities.Webhook
+}
+
+func (m *mockWebhookRepo) FindAll(_ context.Context, userID uuid.UUID) ([]entities.Webhook, error) {
+	var out []entities.Webhook
+	for _, h := range m.hooks {
+		if h.UserID == userID {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockWebhookRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Webhook, error) {
+	for i := range m.hooks {
+		if m.hooks[i].UserID == userID && m.hooks[i].ID == id {
+			return &m.hooks[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockWebhookRepo) FindByTimezone(_ context.Context, timezone string) ([]entities.Webhook, error) {
+	return m.hooks, nil
+}
+
+func (m *mockWebhookRepo) Create(_ context.Context, hook *entities.Webhook) error {
+	m.hooks = append(m.hooks, *hook)
+	return nil
+}
+
+func (m *mockWebhookRepo) Delete(_ context.Context, userID uuid.UUID, id uuid.UUID) error {
+	for i, h := range m.hooks {
+		if h.UserID == userID && h.ID == id {
+			m.hooks = append(m.hooks[:i], m.hooks[i+1:]...)
+		}
+	}
+	return nil
+}
+
+type mockDeliveryRepo struct {
+	deliveries []*entities.WebhookDelivery
+}
+
+func (m *mockDeliveryRepo) FindRecent(_ context.Context, userID uuid.UUID, limit int) ([]entities.WebhookDelivery, error) {
+	var out []entities.WebhookDelivery
+	for i := len(m.deliveries) - 1; i >= 0 && len(out) < limit; i-- {
+		if m.deliveries[i].UserID == userID {
+			out = append(out, *m.deliveries[i])
+		}
+	}
+	return out, nil
+}
+
+func (m *mockDeliveryRepo) FindByID(_ context.Context, userID uuid.UUID, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	for _, d := range m.deliveries {
+		if d.UserID == userID && d.ID == id {
+			c := *d
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockDeliveryRepo) ClaimDue(_ context.Context, now, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	var out []entities.WebhookDelivery
+	for _, d := range m.deliveries {
+		if len(out) == limit {
+			break
+		}
+		if d.Status == entities.DeliveryPending && !d.NextAttemptAt.After(now) {
+			lease := leaseUntil
+			d.NextAttemptAt = &lease
+			out = append(out, *d)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockDeliveryRepo) Create(_ context.Context, d *entities.WebhookDelivery) error {
+	c := *d
+	m.deliveries = append(m.deliveries, &c)
+	return nil
+}
+
+func (m *mockDeliveryRepo) Update(_ context.Context, d *entities.WebhookDelivery) error {
+	for i, existing := range m.deliveries {
+		if existing.ID == d.ID {
+			c := *d
+			m.deliveries[i] = &c
+		}
+	}
+	return nil
+}
+
+// fakeWebhookSender records what it sends and fails while err is set.
+type fakeWebhookSender struct {
+	err    error
+	events []string
+}
+
+func (f *fakeWebhookSender) Send(_ context.Context, url, secret, event, deliveryID string, payload []byte) (int, error) {
+	f.events = append(f.events, event)
+	if f.err != nil {
+		return 503, f.err
+	}
+	return 200, nil
+}
+
+func newWebhookTest(user *entities.User, events ...entities.WebhookEvent) (*WebhookService, *mockDeliveryRepo, *fakeWebhookSender, *mockTaskRepo) {
+	hooks := &mockWebhookRepo{hooks: []entities.Webhook{*entities.NewWebhook(user.ID, "https://example.com/hook", events)}}
+	deliveries, sender, tasks := &mockDeliveryRepo{}, &fakeWebhookSender{}, &mockTaskRepo{}
+	svc := NewWebhookService(hooks, deliveries, tasks, &mockUserRepo{users: []*entities.User{user}}, &mockNotifRepo{}, sender, time.Minute)
+	return svc, deliveries, sender, tasks
+}
+
+func TestWebhookService_PublishOnlySubscribed(t *testing.T) {
+	user := alertUser()
+	svc, deliveries, _, _ := newWebhookTest(user, entities.EventChemistryLogCreated)
+	ctx := WithUser(context.Background(), user)
+	chemSvc := NewChemistryService(&mockChemLogRepo{}, nil, svc)
+
+	svc.MilestoneEarned(ctx, entities.NewMilestone(user.ID, entities.MilestoneFirstDip))
+	if len(deliveries.deliveries) != 0 {
+		t.Fatalf("queued %d deliveries for an unsubscribed event", len(deliveries.deliveries))
+	}
+	log, err := chemSvc.Create(ctx, command.CreateChemistryLog{PH: 7.4, FreeChlorine: 3, TestedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(deliveries.deliveries) != 1 {
+		t.Fatalf("queued %d deliveries, want 1", len(deliveries.deliveries))
+	}
+
+	var payload struct {
+		Event string `json:"event"`
+		Data  struct {
+			ID string  `json:"id"`
+			PH float64 `json:"ph"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(deliveries.deliveries[0].Payload, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.Event != string(entities.EventChemistryLogCreated) || payload.Data.ID != log.ID.String() || payload.Data.PH != 7.4 {
+		t.Errorf("payload = %s", deliveries.deliveries[0].Payload)
+	}
+}
+
+func TestWebhookService_RetriesWithBackoff(t *testing.T) {
+	user := alertUser()
+	svc, deliveries, sender, _ := newWebhookTest(user, entities.EventMilestoneEarned)
+	ctx := WithUser(context.Background(), user)
+	svc.MilestoneEarned(ctx, entities.NewMilestone(user.ID, entities.MilestoneFirstDip))
+
+	now := time.Now()
+	sender.err = errors.New("unavailable")
+	svc.deliverDue(ctx, now)
+	d := deliveries.deliveries[0]
+	if d.Attempts != 1 || d.Status != entities.DeliveryPending || d.ResponseCode != 503 {
+		t.Fatalf("after failure: %+v", d)
+	}
+
+	// Not retried until the backoff has passed.
+	svc.deliverDue(ctx, now.Add(10*time.Second))
+	if len(sender.events) != 1 {
+		t.Fatalf("retried after 10s, want to wait %v", entities.WebhookBackoff(1))
+	}
+	sender.err = nil
+	svc.deliverDue(ctx, now.Add(31*time.Second))
+	d = deliveries.deliveries[0]
+	if len(sender.events) != 2 || d.Status != entities.DeliverySucceeded || d.Attempts != 2 {
+		t.Errorf("after retry: sent %d, delivery %+v", len(sender.events), d)
+	}
+}
+
+func TestWebhookService_Replay(t *testing.T) {
+	user := alertUser()
+	svc, deliveries, sender, _ := newWebhookTest(user, entities.EventMilestoneEarned)
+	ctx := WithUser(context.Background(), user)
+	svc.MilestoneEarned(ctx, entities.NewMilestone(user.ID, entities.MilestoneFirstDip))
+	svc.deliverDue(ctx, time.Now())
+
+	replayed, err := svc.Replay(ctx, deliveries.deliveries[0].ID.String())
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if len(sender.events) != 1 || replayed.Status != entities.DeliveryPending {
+		t.Fatalf("Replay() sent %d, delivery %+v, want it queued for the worker", len(sender.events), replayed)
+	}
+	svc.deliverDue(ctx, time.Now())
+	if len(sender.events) != 2 || len(deliveries.deliveries) != 2 {
+		t.Fatalf("sent %d, stored %d deliveries, want 2 each", len(sender.events), len(deliveries.deliveries))
+	}
+	d := deliveries.deliveries[1]
+	if d.ID != replayed.ID || d.Status != entities.DeliverySucceeded || string(d.Payload) != string(deliveries.deliveries[0].Payload) {
+		t.Errorf("replayed delivery = %+v", d)
+	}
+
+	other := WithUser(context.Background(), alertUser())
+	if _, err := svc.Replay(other, deliveries.deliveries[0].ID.String()); err == nil {
+		t.Error("replayed another user's delivery")
+	}
+}
+
+func TestWebhookService_TaskDueOnce(t *testing.T) {
+	user := alertUser()
+	svc, deliveries, _, tasks := newWebhookTest(user, entities.EventTaskDue)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tasks.tasks = []entities.Task{
+		{ID: uuid.New(), UserID: user.ID, Name: "Clean filter", Status: entities.TaskStatusPending, DueDate: entities.DateOf(now)},
+		{ID: uuid.New(), UserID: user.ID, Name: "Check pH", Status: entities.TaskStatusPending, DueDate: entities.DateOf(now).AddDate(0, 0, 1)},
+	}
+
+	svc.checkDueTasks(context.Background(), now)
+	svc.checkDueTasks(context.Background(), now.Add(time.Hour))
+	if len(deliveries.deliveries) != 1 {
+		t.Fatalf("queued %d task.due deliveries, want 1", len(deliveries.deliveries))
+	}
+	if d := deliveries.deliveries[0]; d.Event != entities.EventTaskDue || d.UserID != user.ID {
+		t.Errorf("delivery = %+v", d)
+	}
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecklistItem is one step of a task. A series holds the checklist that new
+// occurrences start from; each occurrence keeps its own copy so checked
+// steps never carry over to the next recurrence.
+type ChecklistItem struct {
+	ID        uuid.UUID
+	Position  int
+	Name      string
+	Required  bool
+	CheckedAt *time.Time
+}
+
+// optionalPrefix marks a checklist line as an optional step.
+const optionalPrefix = "?"
+
+// ParseChecklist reads a checklist written one step per line. Blank lines
+// are ignored and a leading "?" marks the step as optional.
+func ParseChecklist(text string) []ChecklistItem {
+	var items []ChecklistItem
+	for _, line := range strings.Split(text, "\n") {
+		name := strings.TrimSpace(line)
+		required := true
+		if strings.HasPrefix(name, optionalPrefix) {
+			name = strings.TrimSpace(strings.TrimPrefix(name, optionalPrefix))
+			required = false
+		}
+		if name == "" {
+			continue
+		}
+		items = append(items, ChecklistItem{
+			ID:       uuid.Must(uuid.NewV7()),
+			Position: len(items),
+			Name:     name,
+			Required: required,
+		})
+	}
+	return items
+}
+
+// FormatChecklist writes items in the form read by ParseChecklist.
+func FormatChecklist(items []ChecklistItem) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		if item.Required {
+			lines[i] = item.Name
+		} else {
+			lines[i] = optionalPrefix + " " + item.Name
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// freshChecklist copies items with new IDs and nothing checked.
+func freshChecklist(items []ChecklistItem) []ChecklistItem {
+	if len(items) == 0 {
+		return nil
+	}
+	fresh := make([]ChecklistItem, len(items))
+	for i, item := range items {
+		fresh[i] = ChecklistItem{
+			ID:       uuid.Must(uuid.NewV7()),
+			Position: i,
+			Name:     item.Name,
+			Required: item.Required,
+		}
+	}
+	return fresh
+}
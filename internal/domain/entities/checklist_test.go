@@ -0,0 +1,34 @@
+package entities
+
+import "testing"
+
+func TestParseChecklist(t *testing.T) {
+	items := ParseChecklist("Remove cover\n\n  ? Scrub tile line  \nStart pump\n?\n")
+
+	want := []struct {
+		name     string
+		required bool
+	}{
+		{"Remove cover", true},
+		{"Scrub tile line", false},
+		{"Start pump", true},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, w := range want {
+		if items[i].Name != w.name || items[i].Required != w.required {
+			t.Errorf("item %d = %q required=%v, want %q required=%v", i, items[i].Name, items[i].Required, w.name, w.required)
+		}
+		if items[i].Position != i {
+			t.Errorf("item %d Position = %d", i, items[i].Position)
+		}
+	}
+}
+
+func TestFormatChecklist_RoundTrip(t *testing.T) {
+	text := "Remove cover\n? Scrub tile line\nStart pump"
+	if got := FormatChecklist(ParseChecklist(text)); got != text {
+		t.Errorf("FormatChecklist = %q, want %q", got, text)
+	}
+}
@@ -26,9 +26,12 @@ type Chemical struct {
 	Type           ChemicalType
 	Stock          valueobjects.Quantity
 	AlertThreshold float64
-	LastPurchased  *time.Time
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// DailyUsage is the average amount used a day, from recent doses, in
+	// the stock's unit. Zero when the usage isn't known yet.
+	DailyUsage    float64
+	LastPurchased *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 func NewChemical(userID uuid.UUID, name string, chemType ChemicalType, stock valueobjects.Quantity, alertThreshold float64) *Chemical {
@@ -60,8 +63,23 @@ func (c *Chemical) Validate() error {
 	return nil
 }
 
+// IsLowStock reports whether the stock is at or below the alert threshold,
+// or will run out within ReorderLeadDays at the current usage.
 func (c *Chemical) IsLowStock() bool {
-	return c.Stock.Amount <= c.AlertThreshold
+	return c.isLowAt(c.Stock.Amount)
+}
+
+func (c *Chemical) isLowAt(amount float64) bool {
+	if amount <= c.AlertThreshold {
+		return true
+	}
+	return c.DailyUsage > 0 && amount/c.DailyUsage <= ReorderLeadDays
+}
+
+// CrossedThreshold reports whether the stock has just become low from
+// before, the amount prior to a change.
+func (c *Chemical) CrossedThreshold(before float64) bool {
+	return !c.isLowAt(before) && c.IsLowStock()
 }
 
 func (c *Chemical) AdjustStock(delta float64) error {
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// UsageWindowDays is how far back doses count towards a chemical's
+	// daily usage.
+	UsageWindowDays = 60
+	// minUsageDays is the shortest span usage is averaged over, so a
+	// chemical dosed on its first day doesn't look like it runs out in a
+	// week.
+	minUsageDays = 7
+	// ReorderLeadDays is how long before running out a chemical should be
+	// reordered, to allow for delivery or a trip to the store.
+	ReorderLeadDays = 7
+	// ReorderCoverDays is how many days of usage a suggested order covers.
+	ReorderCoverDays = 30
+)
+
+// UpdateUsage sets the chemical's daily usage from its ledger: the amount
+// dosed in the last UsageWindowDays, or since the ledger started in the
+// current unit if that's more recent, averaged over that span.
+func (c *Chemical) UpdateUsage(movements []StockMovement, now time.Time) {
+	since := now.AddDate(0, 0, -UsageWindowDays)
+	var first *time.Time
+	var used float64
+	for i, m := range movements {
+		if m.Unit != c.Stock.Unit {
+			continue
+		}
+		if first == nil || m.CreatedAt.Before(*first) {
+			first = &movements[i].CreatedAt
+		}
+		if m.Kind == StockDose && !m.CreatedAt.Before(since) {
+			used -= m.Amount
+		}
+	}
+	if first == nil || used <= 0 {
+		c.DailyUsage = 0
+		return
+	}
+	if first.After(since) {
+		since = *first
+	}
+	days := math.Max(now.Sub(since).Hours()/24, minUsageDays)
+	c.DailyUsage = roundStock(used / days)
+}
+
+// StockForecast estimates when a chemical runs out and when and how much
+// to reorder, from its daily usage.
+type StockForecast struct {
+	DailyUsage float64
+	DaysLeft   int
+	RunsOutOn  time.Time
+	// ReorderOn is when the stock reaches the reorder point: its alert
+	// threshold, or ReorderLeadDays of usage if that's more. It is today if
+	// the stock is already there.
+	ReorderOn time.Time
+	// ReorderAmount brings the stock back up from the reorder point to
+	// cover ReorderCoverDays of usage past it.
+	ReorderAmount float64
+}
+
+// Forecast returns the chemical's stock forecast from today, a date, or
+// nil if its usage isn't known.
+func (c *Chemical) Forecast(today time.Time) *StockForecast {
+	if c.DailyUsage <= 0 {
+		return nil
+	}
+	today = DateOf(today)
+	daysLeft := int(math.Floor(roundStock(c.Stock.Amount / c.DailyUsage)))
+	reorderPoint := math.Max(c.AlertThreshold, c.DailyUsage*ReorderLeadDays)
+	daysToReorder := 0
+	if c.Stock.Amount > reorderPoint {
+		daysToReorder = int(math.Floor(roundStock((c.Stock.Amount - reorderPoint) / c.DailyUsage)))
+	}
+	need := reorderPoint + c.DailyUsage*ReorderCoverDays - math.Min(c.Stock.Amount, reorderPoint)
+	return &StockForecast{
+		DailyUsage:    c.DailyUsage,
+		DaysLeft:      daysLeft,
+		RunsOutOn:     today.AddDate(0, 0, daysLeft),
+		ReorderOn:     today.AddDate(0, 0, daysToReorder),
+		ReorderAmount: roundUpOrder(need),
+	}
+}
+
+// roundUpOrder rounds an order up to a whole unit, or a tenth for orders
+// under one unit.
+func roundUpOrder(amount float64) float64 {
+	if amount < 1 {
+		return math.Ceil(roundStock(amount)*10) / 10
+	}
+	return math.Ceil(roundStock(amount))
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+func TestChemical_UpdateUsage(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	lbs, kg := valueobjects.UnitPounds, valueobjects.UnitKg
+	tests := []struct {
+		name      string
+		movements []StockMovement
+		want      float64
+	}{
+		{"no movements", nil, 0},
+		{"no doses", []StockMovement{
+			{Kind: StockPurchase, Amount: 20, Unit: lbs, CreatedAt: daysAgo(30)},
+			{Kind: StockAdjustment, Amount: -5, Unit: lbs, CreatedAt: daysAgo(10)},
+		}, 0},
+		{"doses over the window", []StockMovement{
+			{Kind: StockAdjustment, Amount: 50, Unit: lbs, CreatedAt: daysAgo(200)},
+			{Kind: StockDose, Amount: -10, Unit: lbs, CreatedAt: daysAgo(90)},
+			{Kind: StockDose, Amount: -6, Unit: lbs, CreatedAt: daysAgo(40)},
+			{Kind: StockDose, Amount: -6, Unit: lbs, CreatedAt: daysAgo(5)},
+		}, 0.2},
+		{"young ledger", []StockMovement{
+			{Kind: StockPurchase, Amount: 10, Unit: lbs, CreatedAt: daysAgo(20)},
+			{Kind: StockDose, Amount: -4, Unit: lbs, CreatedAt: daysAgo(10)},
+		}, 0.2},
+		{"first day", []StockMovement{
+			{Kind: StockPurchase, Amount: 10, Unit: lbs, CreatedAt: now.Add(-time.Hour)},
+			{Kind: StockDose, Amount: -1.4, Unit: lbs, CreatedAt: now},
+		}, 0.2},
+		{"old unit ignored", []StockMovement{
+			{Kind: StockDose, Amount: -30, Unit: kg, CreatedAt: daysAgo(50)},
+			{Kind: StockAdjustment, Amount: 10, Unit: lbs, CreatedAt: daysAgo(14)},
+			{Kind: StockDose, Amount: -2.8, Unit: lbs, CreatedAt: daysAgo(1)},
+		}, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Chemical{Stock: valueobjects.Quantity{Amount: 10, Unit: lbs}, DailyUsage: 9}
+			c.UpdateUsage(tt.movements, now)
+			if c.DailyUsage != tt.want {
+				t.Errorf("DailyUsage = %v, want %v", c.DailyUsage, tt.want)
+			}
+		})
+	}
+}
+
+func TestChemical_Forecast(t *testing.T) {
+	today := time.Date(2026, 7, 1, 15, 30, 0, 0, time.UTC)
+	date := func(days int) time.Time { return time.Date(2026, 7, 1+days, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name      string
+		stock     float64
+		threshold float64
+		usage     float64
+		want      *StockForecast
+	}{
+		{"unknown usage", 10, 2, 0, nil},
+		{"lead time sets the reorder point", 20, 1, 0.5, &StockForecast{
+			DailyUsage: 0.5, DaysLeft: 40, RunsOutOn: date(40), ReorderOn: date(33), ReorderAmount: 15,
+		}},
+		{"threshold sets the reorder point", 20, 8, 0.5, &StockForecast{
+			DailyUsage: 0.5, DaysLeft: 40, RunsOutOn: date(40), ReorderOn: date(24), ReorderAmount: 15,
+		}},
+		{"already past the reorder point", 2, 1, 0.5, &StockForecast{
+			DailyUsage: 0.5, DaysLeft: 4, RunsOutOn: date(4), ReorderOn: date(0), ReorderAmount: 17,
+		}},
+		{"small order", 1, 0, 0.01, &StockForecast{
+			DailyUsage: 0.01, DaysLeft: 100, RunsOutOn: date(100), ReorderOn: date(93), ReorderAmount: 0.3,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Chemical{
+				Stock:          valueobjects.Quantity{Amount: tt.stock, Unit: valueobjects.UnitPounds},
+				AlertThreshold: tt.threshold,
+				DailyUsage:     tt.usage,
+			}
+			got := c.Forecast(today)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("Forecast() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && *got != *tt.want {
+				t.Errorf("Forecast() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestChemical_LowStockFromUsage(t *testing.T) {
+	c := NewChemical(uuid.New(), "Shock", ChemicalTypeShock, valueobjects.Quantity{Amount: 5, Unit: valueobjects.UnitPounds}, 1)
+	if c.IsLowStock() {
+		t.Fatal("IsLowStock() with no usage and stock above the threshold")
+	}
+	c.DailyUsage = 0.75
+	if !c.IsLowStock() {
+		t.Error("IsLowStock() = false with 6 days left, want true within the lead time")
+	}
+	c.DailyUsage = 0.25
+	if c.IsLowStock() {
+		t.Error("IsLowStock() = true with 20 days left")
+	}
+	c.Stock.Amount = 1.5
+	if !c.CrossedThreshold(2) {
+		t.Error("CrossedThreshold() = false going from 8 days to 6 days left")
+	}
+	if c.CrossedThreshold(1.75) {
+		t.Error("CrossedThreshold() = true when already within the lead time")
+	}
+}
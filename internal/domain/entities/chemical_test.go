@@ -109,3 +109,29 @@ func TestChemical_AdjustStock(t *testing.T) {
 		})
 	}
 }
+
+func TestChemical_CrossedThreshold(t *testing.T) {
+	tests := []struct {
+		name   string
+		before float64
+		after  float64
+		want   bool
+	}{
+		{"drops below", 8, 3, true},
+		{"drops to threshold", 6, 5, true},
+		{"already low", 4, 3, false},
+		{"stays above", 9, 7, false},
+		{"restocked", 3, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Chemical{
+				Stock:          valueobjects.Quantity{Amount: tt.after, Unit: valueobjects.UnitPounds},
+				AlertThreshold: 5,
+			}
+			if got := c.CrossedThreshold(tt.before); got != tt.want {
+				t.Errorf("CrossedThreshold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
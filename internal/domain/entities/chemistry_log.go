@@ -79,3 +79,20 @@ func (c *ChemistryLog) CYAInRange() bool { return c.CYA >= 30 && c.CYA <= 50 }
 func (c *ChemistryLog) CalciumHardnessInRange() bool {
 	return c.CalciumHardness >= 200 && c.CalciumHardness <= 400
 }
+
+// DangerousReadings describes readings far enough out of range to be
+// unsafe, not merely off balance: free chlorine below 1 ppm, or pH below
+// 7.0 or above 8.0.
+func (c *ChemistryLog) DangerousReadings() []string {
+	var readings []string
+	if c.FreeChlorine < 1.0 {
+		readings = append(readings, fmt.Sprintf("Free chlorine is %.1f ppm (below 1.0)", c.FreeChlorine))
+	}
+	if c.PH < 7.0 {
+		readings = append(readings, fmt.Sprintf("pH is %.1f (below 7.0)", c.PH))
+	}
+	if c.PH > 8.0 {
+		readings = append(readings, fmt.Sprintf("pH is %.1f (above 8.0)", c.PH))
+	}
+	return readings
+}
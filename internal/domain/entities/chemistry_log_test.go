@@ -131,3 +131,27 @@ func TestChemistryLog_CalciumHardnessInRange(t *testing.T) {
 		})
 	}
 }
+
+func TestChemistryLog_DangerousReadings(t *testing.T) {
+	tests := []struct {
+		name string
+		ph   float64
+		fc   float64
+		want int
+	}{
+		{"balanced", 7.4, 3, 0},
+		{"slightly off", 7.8, 1.5, 0},
+		{"low chlorine", 7.4, 0.5, 1},
+		{"high pH", 8.2, 3, 1},
+		{"low pH", 6.8, 3, 1},
+		{"both", 8.4, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ChemistryLog{PH: tt.ph, FreeChlorine: tt.fc}
+			if got := c.DangerousReadings(); len(got) != tt.want {
+				t.Errorf("DangerousReadings() = %v, want %d readings", got, tt.want)
+			}
+		})
+	}
+}
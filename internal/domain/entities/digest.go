@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default digest schedule for new users: Sunday mornings.
+const (
+	DefaultDigestWeekday = time.Sunday
+	DefaultDigestHour    = 8
+)
+
+// DigestSchedule is when a user's weekly digest is sent, in their timezone.
+type DigestSchedule struct {
+	Weekday time.Weekday
+	Hour    int // 0-23
+}
+
+func (d DigestSchedule) Validate() error {
+	if d.Weekday < time.Sunday || d.Weekday > time.Saturday {
+		return fmt.Errorf("digest day must be a day of the week")
+	}
+	if d.Hour < 0 || d.Hour > 23 {
+		return fmt.Errorf("digest hour must be between 0 and 23")
+	}
+	return nil
+}
+
+// IsSendTime reports whether the digest is due at now, which should be in
+// the user's timezone: on the chosen weekday, from the chosen hour.
+func (d DigestSchedule) IsSendTime(now time.Time) bool {
+	return now.Weekday() == d.Weekday && now.Hour() >= d.Hour
+}
+
+// Label describes the schedule, e.g. "Sundays at 8 AM".
+func (d DigestSchedule) Label() string {
+	return d.Weekday.String() + "s at " + FormatHour(d.Hour)
+}
+
+// WeekOf returns the Monday starting day's week. Digests are claimed per
+// week, so changing the digest day never sends a second one that week.
+func WeekOf(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return DateOf(day).AddDate(0, 0, -offset)
+}
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigestSchedule_IsSendTime(t *testing.T) {
+	d := DigestSchedule{Weekday: time.Sunday, Hour: 8}
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{time.Date(2025, 3, 9, 7, 59, 0, 0, time.UTC), false},
+		{time.Date(2025, 3, 9, 8, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 3, 9, 23, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := d.IsSendTime(tt.now); got != tt.want {
+			t.Errorf("IsSendTime(%v) = %v, want %v", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestDigestSchedule_Validate(t *testing.T) {
+	if err := (DigestSchedule{Weekday: time.Saturday, Hour: 23}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, d := range []DigestSchedule{{Weekday: 7, Hour: 8}, {Weekday: -1, Hour: 8}, {Weekday: time.Monday, Hour: 24}} {
+		if err := d.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded, want error", d)
+		}
+	}
+}
+
+func TestWeekOf(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	for d := 0; d < 7; d++ {
+		day := monday.AddDate(0, 0, d).Add(15 * time.Hour)
+		if got := WeekOf(day); !got.Equal(monday) {
+			t.Errorf("WeekOf(%s) = %s, want %s", day.Weekday(), got, monday)
+		}
+	}
+}
@@ -29,6 +29,7 @@ type Equipment struct {
 	InstallDate    *time.Time
 	WarrantyExpiry *time.Time
 	ServiceRecords []ServiceRecord
+	UpcomingTasks  []Task // open tasks that maintain this equipment
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -68,3 +69,14 @@ func (e *Equipment) IsWarrantyActive() bool {
 	}
 	return time.Now().Before(*e.WarrantyExpiry)
 }
+
+// WarrantyExpiresWithin reports whether the warranty runs out between
+// today and days from now.
+func (e *Equipment) WarrantyExpiresWithin(today time.Time, days int) bool {
+	if e.WarrantyExpiry == nil {
+		return false
+	}
+	expiry := DateOf(*e.WarrantyExpiry)
+	today = DateOf(today)
+	return !expiry.Before(today) && !expiry.After(today.AddDate(0, 0, days))
+}
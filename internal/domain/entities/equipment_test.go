@@ -27,3 +27,31 @@ func TestEquipment_IsWarrantyActive(t *testing.T) {
 		})
 	}
 }
+
+func TestEquipment_WarrantyExpiresWithin(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) *time.Time {
+		t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name   string
+		expiry *time.Time
+		want   bool
+	}{
+		{"nil expiry", nil, false},
+		{"already expired", date(2025, 3, 9), false},
+		{"expires today", date(2025, 3, 10), true},
+		{"in 30 days", date(2025, 4, 9), true},
+		{"in 31 days", date(2025, 4, 10), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Equipment{WarrantyExpiry: tt.expiry}
+			if got := e.WarrantyExpiresWithin(today, 30); got != tt.want {
+				t.Errorf("WarrantyExpiresWithin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,29 @@ func (k MilestoneKey) Valid() bool {
 	return false
 }
 
+func (k MilestoneKey) Label() string {
+	switch k {
+	case MilestoneFirstDip:
+		return "First Dip"
+	case MilestoneBalanced:
+		return "Balanced"
+	case MilestoneConsistent:
+		return "Consistent"
+	case MilestoneDevoted:
+		return "Devoted"
+	case MilestoneOnIt:
+		return "On It"
+	case MilestoneStockedUp:
+		return "Stocked Up"
+	case MilestoneCleanRecord:
+		return "Clean Record"
+	case MilestonePoolPro:
+		return "Pool Pro"
+	default:
+		return string(k)
+	}
+}
+
 type Milestone struct {
 	ID        uuid.UUID
 	UserID    uuid.UUID
@@ -0,0 +1,102 @@
+package entities
+
+import "github.com/google/uuid"
+
+// Notification channels.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// NotificationChannels lists the channels in display order.
+var NotificationChannels = []string{ChannelEmail, ChannelSMS, ChannelPush}
+
+// NotificationCategory is a kind of notification a user opts into per
+// channel.
+type NotificationCategory string
+
+const (
+	// CategoryReminders is task reminders, sent by the user's reminder
+	// rules.
+	CategoryReminders NotificationCategory = "reminders"
+	// CategoryChemistry alerts on a chemistry log with dangerous readings.
+	CategoryChemistry NotificationCategory = "chemistry"
+	// CategoryLowStock alerts when a chemical drops to its alert threshold.
+	CategoryLowStock NotificationCategory = "low_stock"
+	// CategoryWarranty alerts when an equipment warranty is about to expire.
+	CategoryWarranty NotificationCategory = "warranty"
+	// CategoryNoTest alerts when the pool hasn't been tested in a while.
+	CategoryNoTest NotificationCategory = "no_test"
+	// CategoryDigest is the weekly summary, sent on the user's
+	// DigestSchedule.
+	CategoryDigest NotificationCategory = "digest"
+)
+
+// NotificationCategories lists the categories in display order.
+var NotificationCategories = []NotificationCategory{
+	CategoryReminders, CategoryChemistry, CategoryLowStock, CategoryWarranty, CategoryNoTest, CategoryDigest,
+}
+
+func (c NotificationCategory) Label() string {
+	switch c {
+	case CategoryReminders:
+		return "Task reminders"
+	case CategoryChemistry:
+		return "Unsafe water chemistry"
+	case CategoryLowStock:
+		return "Low chemical stock"
+	case CategoryWarranty:
+		return "Expiring warranties"
+	case CategoryNoTest:
+		return "No recent water test"
+	case CategoryDigest:
+		return "Weekly digest"
+	default:
+		return string(c)
+	}
+}
+
+// NotificationPreferences records which categories a user has opted into
+// on each channel. Everything is off until the user turns it on, except
+// that new users start with DefaultNotificationPreferences.
+type NotificationPreferences struct {
+	UserID  uuid.UUID
+	enabled map[NotificationCategory]map[string]bool
+}
+
+func NewNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{UserID: userID}
+}
+
+// DefaultNotificationPreferences are a new user's preferences: task
+// reminders by email.
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	p := NewNotificationPreferences(userID)
+	p.Set(CategoryReminders, ChannelEmail, true)
+	return p
+}
+
+func (p *NotificationPreferences) IsEnabled(category NotificationCategory, channel string) bool {
+	return p.enabled[category][channel]
+}
+
+func (p *NotificationPreferences) Set(category NotificationCategory, channel string, on bool) {
+	if p.enabled == nil {
+		p.enabled = make(map[NotificationCategory]map[string]bool)
+	}
+	if p.enabled[category] == nil {
+		p.enabled[category] = make(map[string]bool)
+	}
+	p.enabled[category][channel] = on
+}
+
+// Wants reports whether the user has opted into category on any channel.
+func (p *NotificationPreferences) Wants(category NotificationCategory) bool {
+	for _, on := range p.enabled[category] {
+		if on {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	p := NewNotificationPreferences(uuid.New())
+	if p.IsEnabled(CategoryChemistry, ChannelEmail) || p.Wants(CategoryChemistry) {
+		t.Fatal("new preferences should have everything off")
+	}
+
+	p.Set(CategoryChemistry, ChannelSMS, true)
+	if !p.IsEnabled(CategoryChemistry, ChannelSMS) {
+		t.Error("IsEnabled(chemistry, sms) = false after Set")
+	}
+	if p.IsEnabled(CategoryChemistry, ChannelEmail) {
+		t.Error("Set on sms also enabled email")
+	}
+	if !p.Wants(CategoryChemistry) || p.Wants(CategoryLowStock) {
+		t.Error("Wants() doesn't match the opt-ins")
+	}
+
+	p.Set(CategoryChemistry, ChannelSMS, false)
+	if p.Wants(CategoryChemistry) {
+		t.Error("Wants(chemistry) = true after turning it off")
+	}
+}
@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// Outbox message statuses.
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	// OutboxDead marks a message that ran out of attempts. It stays in the
+	// outbox for inspection but is not sent again.
+	OutboxDead = "dead"
+)
+
+// MaxOutboxAttempts is how many times a message is tried before it is
+// dead-lettered. With OutboxBackoff that spans about four hours.
+const MaxOutboxAttempts = 10
+
+// OutboxMessage is an email, SMS or push notification waiting to be sent, or the record of
+// one that was. Messages are queued together with the claim that stops
+// them being sent twice, then sent by a worker that retries failures.
+type OutboxMessage struct {
+	ID             uuid.UUID
+	NotificationID uuid.UUID // the TaskNotification claimed for this message
+	UserID         uuid.UUID
+	Channel        string // ChannelEmail, ChannelSMS or ChannelPush
+	Kind           string // the claim's kind: NotificationKindDue, NotificationKindOverdue or an alert category
+	Recipient      string
+	Subject        string
+	Body           string                   // plain text
+	HTMLBody       string                   // optional HTML alternative, emails only
+	Headers        map[string]string        // extra email headers, e.g. List-Unsubscribe
+	Push           valueobjects.PushOptions // push notifications only
+	Status         string
+	Attempts       int
+	LastError      string
+	NextAttemptAt  *time.Time // nil once sent or dead
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SentAt         *time.Time
+}
+
+// NewOutboxMessage creates a pending message for the notification claim
+// notif, sent on the claim's channel to recipient.
+func NewOutboxMessage(notif *TaskNotification, recipient, subject, body string) *OutboxMessage {
+	now := time.Now()
+	return &OutboxMessage{
+		ID:             uuid.Must(uuid.NewV7()),
+		NotificationID: notif.ID,
+		UserID:         notif.UserID,
+		Channel:        notif.Type,
+		Kind:           notif.Kind,
+		Recipient:      recipient,
+		Subject:        subject,
+		Body:           body,
+		Status:         OutboxPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// RecordSuccess marks the message as sent.
+func (m *OutboxMessage) RecordSuccess(now time.Time) {
+	m.Attempts++
+	m.Status = OutboxSent
+	m.LastError = ""
+	m.NextAttemptAt = nil
+	m.SentAt = &now
+	m.UpdatedAt = now
+}
+
+// RecordFailure records a failed attempt and schedules the next one, or
+// dead-letters the message once it has run out of attempts.
+func (m *OutboxMessage) RecordFailure(errMsg string, now time.Time) {
+	m.Attempts++
+	m.LastError = errMsg
+	m.UpdatedAt = now
+	if m.Attempts >= MaxOutboxAttempts {
+		m.Status = OutboxDead
+		m.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(OutboxBackoff(m.Attempts))
+	m.NextAttemptAt = &next
+}
+
+// Abandon dead-letters the message without another attempt, e.g. because
+// its channel is no longer configured.
+func (m *OutboxMessage) Abandon(errMsg string, now time.Time) {
+	m.Status = OutboxDead
+	m.LastError = errMsg
+	m.NextAttemptAt = nil
+	m.UpdatedAt = now
+}
+
+// OutboxBackoff is the wait after the given number of failed attempts:
+// 1m, 2m, 4m, and so on, up to an hour.
+func OutboxBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts >= 7 {
+		return time.Hour
+	}
+	return time.Minute << (attempts - 1)
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{6, 32 * time.Minute},
+		{7, time.Hour},
+		{9, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := OutboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("OutboxBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestOutboxMessage_DeadLetters(t *testing.T) {
+	rule := &ReminderRule{UserID: uuid.New(), OffsetDays: 0, SendHour: 7}
+	msg := NewOutboxMessage(NewBatchNotification(rule, ChannelEmail, time.Now(), nil), "a@example.com", "subject", "body")
+	if msg.Channel != ChannelEmail || msg.Kind != NotificationKindDue || msg.Status != OutboxPending {
+		t.Fatalf("new message = %+v", msg)
+	}
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	msg.RecordFailure("resend: 503", now)
+	if msg.Status != OutboxPending || !msg.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("after 1 failure: status %s, next %v", msg.Status, msg.NextAttemptAt)
+	}
+	for msg.Attempts < MaxOutboxAttempts {
+		msg.RecordFailure("resend: 503", now)
+	}
+	if msg.Status != OutboxDead || msg.NextAttemptAt != nil || msg.LastError != "resend: 503" {
+		t.Errorf("after %d failures: %+v, want dead", msg.Attempts, msg)
+	}
+}
+
+func TestOutboxMessage_RecordSuccess(t *testing.T) {
+	rule := &ReminderRule{UserID: uuid.New(), OffsetDays: 0, SendHour: 7}
+	msg := NewOutboxMessage(NewBatchNotification(rule, ChannelSMS, time.Now(), nil), "+15551234567", "subject", "body")
+	now := time.Now()
+	msg.RecordFailure("twilio: timeout", now)
+	msg.RecordSuccess(now)
+	if msg.Status != OutboxSent || msg.Attempts != 2 || msg.LastError != "" || msg.NextAttemptAt != nil || msg.SentAt == nil {
+		t.Errorf("after success: %+v", msg)
+	}
+}
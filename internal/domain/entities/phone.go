@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Phone verification limits.
+const (
+	PhoneCodeDigits = 6
+	// PhoneCodeTTL is how long a verification code can be used.
+	PhoneCodeTTL = 10 * time.Minute
+	// MaxPhoneCodeAttempts is how many wrong codes are accepted before a
+	// new one has to be sent.
+	MaxPhoneCodeAttempts = 5
+	// PhoneCodeResendAfter is how long to wait before sending another code.
+	PhoneCodeResendAfter = time.Minute
+)
+
+var (
+	ErrPhoneCodeExpired  = errors.New("the code has expired; send a new one")
+	ErrPhoneCodeAttempts = errors.New("too many wrong codes; send a new one")
+	ErrPhoneCodeWrong    = errors.New("that code isn't right")
+)
+
+// NormalizePhone returns a phone number in E.164 form, e.g. +15551234567.
+// Spaces, dashes, dots and parentheses are ignored, and a leading 00 is
+// read as +. The country code is required. An empty number stays empty.
+func NormalizePhone(raw string) (string, error) {
+	s := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+	if s == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(s, "00") {
+		s = "+" + s[2:]
+	}
+	if !strings.HasPrefix(s, "+") {
+		return "", fmt.Errorf("include the country code, e.g. +15551234567")
+	}
+	digits := s[1:]
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", fmt.Errorf("phone number is invalid")
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("phone number is invalid")
+		}
+	}
+	return s, nil
+}
+
+// PhoneCode is a verification code sent to the user's phone. Only its hash
+// is stored.
+type PhoneCode struct {
+	Hash      string
+	SentAt    time.Time
+	ExpiresAt time.Time
+	Attempts  int // wrong codes entered so far
+}
+
+// SetPhone changes the user's phone number, normalized to E.164. A new
+// number has to be verified before any SMS is sent to it, and starts
+// opted in.
+func (u *User) SetPhone(raw string) error {
+	phone, err := NormalizePhone(raw)
+	if err != nil {
+		return err
+	}
+	if phone == u.Phone {
+		return nil
+	}
+	u.Phone = phone
+	u.PhoneVerifiedAt = nil
+	u.PhoneCode = nil
+	u.SMSOptedOut = false
+	return nil
+}
+
+// PhoneVerified reports whether the user has confirmed their phone number.
+func (u *User) PhoneVerified() bool {
+	return u.Phone != "" && u.PhoneVerifiedAt != nil
+}
+
+// NewPhoneCode starts verifying the user's phone number. It returns the
+// code to text them; only its hash is kept.
+func (u *User) NewPhoneCode(now time.Time) (string, error) {
+	if u.Phone == "" {
+		return "", fmt.Errorf("add a phone number first")
+	}
+	if u.PhoneVerified() {
+		return "", fmt.Errorf("phone number is already verified")
+	}
+	if u.PhoneCode != nil && now.Before(u.PhoneCode.SentAt.Add(PhoneCodeResendAfter)) {
+		return "", fmt.Errorf("wait a minute before sending another code")
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("generating code: %w", err)
+	}
+	code := fmt.Sprintf("%0*d", PhoneCodeDigits, n.Int64())
+	u.PhoneCode = &PhoneCode{
+		Hash:      hashPhoneCode(code),
+		SentAt:    now,
+		ExpiresAt: now.Add(PhoneCodeTTL),
+	}
+	return code, nil
+}
+
+// VerifyPhone checks a code entered by the user and, if it matches the
+// one sent, marks their phone number verified. A wrong code counts
+// towards MaxPhoneCodeAttempts, so the caller must save the user either
+// way.
+func (u *User) VerifyPhone(code string, now time.Time) error {
+	pc := u.PhoneCode
+	if pc == nil {
+		return fmt.Errorf("send a code first")
+	}
+	if !now.Before(pc.ExpiresAt) {
+		return ErrPhoneCodeExpired
+	}
+	if pc.Attempts >= MaxPhoneCodeAttempts {
+		return ErrPhoneCodeAttempts
+	}
+	got := hashPhoneCode(strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(got), []byte(pc.Hash)) != 1 {
+		pc.Attempts++
+		if pc.Attempts >= MaxPhoneCodeAttempts {
+			return ErrPhoneCodeAttempts
+		}
+		return ErrPhoneCodeWrong
+	}
+	u.PhoneVerifiedAt = &now
+	u.PhoneCode = nil
+	return nil
+}
+
+func hashPhoneCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"+15551234567", "+15551234567", false},
+		{" +1 (555) 123-4567 ", "+15551234567", false},
+		{"0044 20 7946 0958", "+442079460958", false},
+		{"+61.2.9876.5432", "+61298765432", false},
+		{"", "", false},
+		{"5551234567", "", true},
+		{"+1555", "", true},
+		{"+0551234567", "", true},
+		{"+1555123456x", "", true},
+		{"+1234567890123456", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, %v; want %q, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestUser_SetPhone(t *testing.T) {
+	u := NewUser("a@example.com", "hash")
+	verified := time.Now()
+	u.Phone, u.PhoneVerifiedAt, u.SMSOptedOut = "+15551234567", &verified, true
+
+	if err := u.SetPhone("+1 555 123 4567"); err != nil {
+		t.Fatalf("SetPhone: %v", err)
+	}
+	if !u.PhoneVerified() || !u.SMSOptedOut {
+		t.Error("re-entering the same number reset its state")
+	}
+	if err := u.SetPhone("+15559876543"); err != nil {
+		t.Fatalf("SetPhone: %v", err)
+	}
+	if u.PhoneVerified() || u.SMSOptedOut {
+		t.Error("a new number should be unverified and opted in")
+	}
+	if u.Address(ChannelSMS) != "" {
+		t.Errorf("Address(sms) = %q for an unverified number", u.Address(ChannelSMS))
+	}
+	if err := u.SetPhone("555"); err == nil {
+		t.Error("SetPhone accepted an invalid number")
+	}
+}
+
+func TestUser_VerifyPhone(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	u := NewUser("a@example.com", "hash")
+	if _, err := u.NewPhoneCode(now); err == nil {
+		t.Fatal("sent a code without a phone number")
+	}
+	u.SetPhone("+15551234567")
+
+	code, err := u.NewPhoneCode(now)
+	if err != nil {
+		t.Fatalf("NewPhoneCode: %v", err)
+	}
+	if len(code) != PhoneCodeDigits || u.PhoneCode.Hash == code {
+		t.Fatalf("code = %q, hash = %q", code, u.PhoneCode.Hash)
+	}
+	if _, err := u.NewPhoneCode(now.Add(30 * time.Second)); err == nil {
+		t.Error("resent a code within a minute")
+	}
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	if err := u.VerifyPhone(wrong, now); !errors.Is(err, ErrPhoneCodeWrong) {
+		t.Errorf("wrong code: err = %v", err)
+	}
+	if err := u.VerifyPhone(code, now.Add(PhoneCodeTTL)); !errors.Is(err, ErrPhoneCodeExpired) {
+		t.Errorf("expired code: err = %v", err)
+	}
+	if err := u.VerifyPhone(" "+code+" ", now.Add(time.Minute)); err != nil {
+		t.Fatalf("VerifyPhone: %v", err)
+	}
+	if !u.PhoneVerified() || u.PhoneCode != nil || u.Address(ChannelSMS) != "+15551234567" {
+		t.Error("phone not verified after the right code")
+	}
+}
+
+func TestUser_VerifyPhone_AttemptLimit(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	u := NewUser("a@example.com", "hash")
+	u.SetPhone("+15551234567")
+	code, _ := u.NewPhoneCode(now)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 1; i < MaxPhoneCodeAttempts; i++ {
+		if err := u.VerifyPhone(wrong, now); !errors.Is(err, ErrPhoneCodeWrong) {
+			t.Fatalf("attempt %d: err = %v", i, err)
+		}
+	}
+	if err := u.VerifyPhone(wrong, now); !errors.Is(err, ErrPhoneCodeAttempts) {
+		t.Fatalf("last attempt: err = %v", err)
+	}
+	if err := u.VerifyPhone(code, now); !errors.Is(err, ErrPhoneCodeAttempts) {
+		t.Errorf("right code after the limit: err = %v", err)
+	}
+
+	code, err := u.NewPhoneCode(now.Add(PhoneCodeResendAfter))
+	if err != nil {
+		t.Fatalf("NewPhoneCode: %v", err)
+	}
+	if err := u.VerifyPhone(code, now.Add(PhoneCodeResendAfter)); err != nil {
+		t.Errorf("new code after the limit: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Push notification services.
+const (
+	PushNtfy   = "ntfy"
+	PushGotify = "gotify"
+)
+
+// PushServices lists the push services in display order.
+var PushServices = []string{PushNtfy, PushGotify}
+
+// PushTarget is where a user's push notifications are posted: an ntfy
+// topic or a Gotify server, usually self-hosted.
+type PushTarget struct {
+	Service string // PushNtfy or PushGotify
+	// URL is the ntfy topic's URL, e.g. https://ntfy.sh/my-pool, or the
+	// Gotify server's, e.g. https://gotify.example.com.
+	URL string
+	// Token is an ntfy access token, needed only for protected topics, or
+	// the Gotify application token.
+	Token string
+}
+
+// NewPushTarget returns a push target with surrounding spaces and any
+// trailing slash trimmed. An empty URL gives the zero target, which
+// sends nothing.
+func NewPushTarget(service, rawURL, token string) PushTarget {
+	rawURL = strings.TrimRight(strings.TrimSpace(rawURL), "/")
+	if rawURL == "" {
+		return PushTarget{}
+	}
+	return PushTarget{
+		Service: strings.ToLower(strings.TrimSpace(service)),
+		URL:     rawURL,
+		Token:   strings.TrimSpace(token),
+	}
+}
+
+// IsSet reports whether the user has set up push notifications.
+func (p PushTarget) IsSet() bool {
+	return p.URL != ""
+}
+
+func (p PushTarget) Validate() error {
+	if !p.IsSet() {
+		return nil
+	}
+	if !slices.Contains(PushServices, p.Service) {
+		return fmt.Errorf("unknown push service %q", p.Service)
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("push URL must be an absolute http or https URL")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("push URL can't have a query string")
+	}
+	switch p.Service {
+	case PushNtfy:
+		if strings.Trim(u.Path, "/") == "" {
+			return fmt.Errorf("include the topic in the ntfy URL, e.g. https://ntfy.sh/my-pool")
+		}
+	case PushGotify:
+		if p.Token == "" {
+			return fmt.Errorf("a Gotify application token is required")
+		}
+	}
+	if strings.ContainsAny(p.Token, " \t\r\n") {
+		return fmt.Errorf("push token can't contain spaces")
+	}
+	return nil
+}
+
+// Address is the push target as the recipient of a message, e.g.
+// "ntfy:https://ntfy.sh/my-pool". It leaves out the token.
+func (p PushTarget) Address() string {
+	if !p.IsSet() {
+		return ""
+	}
+	return p.Service + ":" + p.URL
+}
+
+// ParsePushAddress splits an Address into its service and URL.
+func ParsePushAddress(addr string) (service, url string, ok bool) {
+	service, url, ok = strings.Cut(addr, ":")
+	if !ok || !slices.Contains(PushServices, service) {
+		return "", "", false
+	}
+	return service, url, true
+}
+
+// SetPush changes where the user's push notifications are sent. An empty
+// URL turns them off.
+func (u *User) SetPush(target PushTarget) error {
+	if err := target.Validate(); err != nil {
+		return err
+	}
+	u.Push = target
+	return nil
+}
@@ -0,0 +1,63 @@
+package entities
+
+import "testing"
+
+func TestPushTarget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  PushTarget
+		wantErr bool
+	}{
+		{"ntfy topic", NewPushTarget("ntfy", " https://ntfy.sh/my-pool/ ", ""), false},
+		{"ntfy with token", NewPushTarget("NTFY", "http://ntfy.lan:8080/pool", "tk_abc"), false},
+		{"gotify", NewPushTarget("gotify", "https://gotify.example.com", "AbC.123"), false},
+		{"off", NewPushTarget("ntfy", "", ""), false},
+		{"ntfy without topic", NewPushTarget("ntfy", "https://ntfy.sh/", ""), true},
+		{"gotify without token", NewPushTarget("gotify", "https://gotify.example.com", ""), true},
+		{"unknown service", NewPushTarget("pushover", "https://api.pushover.net", "x"), true},
+		{"not http", NewPushTarget("ntfy", "ftp://ntfy.sh/pool", ""), true},
+		{"file", NewPushTarget("gotify", "file:///etc/passwd", "x"), true},
+		{"relative", NewPushTarget("ntfy", "ntfy.sh/pool", ""), true},
+		{"query string", NewPushTarget("gotify", "https://gotify.example.com?token=x", "x"), true},
+		{"token with spaces", NewPushTarget("ntfy", "https://ntfy.sh/pool", "tk abc"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.target.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%+v) = %v, want error %v", tt.target, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPushTarget_Address(t *testing.T) {
+	u := NewUser("a@example.com", "hash")
+	if u.Address(ChannelPush) != "" {
+		t.Errorf("Address(push) = %q before push was set up", u.Address(ChannelPush))
+	}
+	if err := u.SetPush(NewPushTarget("ntfy", "https://ntfy.sh/my-pool/", "tk_secret")); err != nil {
+		t.Fatalf("SetPush: %v", err)
+	}
+	addr := u.Address(ChannelPush)
+	if addr != "ntfy:https://ntfy.sh/my-pool" {
+		t.Errorf("Address(push) = %q", addr)
+	}
+	service, url, ok := ParsePushAddress(addr)
+	if !ok || service != PushNtfy || url != "https://ntfy.sh/my-pool" {
+		t.Errorf("ParsePushAddress(%q) = %q, %q, %v", addr, service, url, ok)
+	}
+	if _, _, ok := ParsePushAddress("https://ntfy.sh/my-pool"); ok {
+		t.Error("ParsePushAddress accepted an address without a service")
+	}
+
+	if err := u.SetPush(NewPushTarget("gotify", "https://gotify.example.com", "")); err == nil {
+		t.Error("SetPush accepted an invalid target")
+	}
+	if u.Push.Token != "tk_secret" {
+		t.Error("a rejected target replaced the saved one")
+	}
+	u.SetPush(NewPushTarget("ntfy", "", "tk_secret"))
+	if u.Push.IsSet() || u.Address(ChannelPush) != "" {
+		t.Error("an empty URL didn't turn push off")
+	}
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default quiet hours, used once a user turns them on.
+const (
+	DefaultQuietStart = 22
+	DefaultQuietEnd   = 7
+)
+
+// QuietHours is a nightly window, in the user's timezone, during which SMS
+// is held back. Messages due in the window are deferred to its end rather
+// than dropped. Start and End are hours of the day; a window from 22 to 7
+// runs overnight.
+type QuietHours struct {
+	Enabled bool
+	Start   int // 0-23
+	End     int // 0-23
+}
+
+func (q QuietHours) Validate() error {
+	if q.Start < 0 || q.Start > 23 || q.End < 0 || q.End > 23 {
+		return fmt.Errorf("quiet hours must be between 0 and 23")
+	}
+	if q.Enabled && q.Start == q.End {
+		return fmt.Errorf("quiet hours must start and end at different times")
+	}
+	return nil
+}
+
+// Contains reports whether now, which should be in the user's timezone, is
+// within quiet hours.
+func (q QuietHours) Contains(now time.Time) bool {
+	if !q.Enabled || q.Start == q.End {
+		return false
+	}
+	h := now.Hour()
+	if q.Start < q.End {
+		return h >= q.Start && h < q.End
+	}
+	return h >= q.Start || h < q.End
+}
+
+// Ends returns when the quiet hours containing now end, or now itself if
+// it isn't within quiet hours.
+func (q QuietHours) Ends(now time.Time) time.Time {
+	if !q.Contains(now) {
+		return now
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), q.End, 0, 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// Label describes the window, e.g. "10 PM to 7 AM".
+func (q QuietHours) Label() string {
+	return FormatHour(q.Start) + " to " + FormatHour(q.End)
+}
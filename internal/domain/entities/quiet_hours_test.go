@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHours_Contains(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2025, 3, 10, h, 30, 0, 0, time.UTC) }
+	overnight := QuietHours{Enabled: true, Start: 22, End: 7}
+	daytime := QuietHours{Enabled: true, Start: 13, End: 15}
+	tests := []struct {
+		name string
+		q    QuietHours
+		hour int
+		want bool
+	}{
+		{"overnight before", overnight, 21, false},
+		{"overnight start", overnight, 22, true},
+		{"overnight after midnight", overnight, 3, true},
+		{"overnight end", overnight, 7, false},
+		{"daytime inside", daytime, 14, true},
+		{"daytime before", daytime, 12, false},
+		{"daytime end", daytime, 15, false},
+		{"disabled", QuietHours{Start: 22, End: 7}, 23, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.Contains(at(tt.hour)); got != tt.want {
+				t.Errorf("Contains(%d:30) = %v, want %v", tt.hour, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHours_Ends(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	q := QuietHours{Enabled: true, Start: 22, End: 7}
+
+	evening := time.Date(2025, 3, 10, 23, 15, 0, 0, loc)
+	if got, want := q.Ends(evening), time.Date(2025, 3, 11, 7, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Ends(evening) = %v, want %v", got, want)
+	}
+	early := time.Date(2025, 3, 11, 5, 0, 0, 0, loc)
+	if got, want := q.Ends(early), time.Date(2025, 3, 11, 7, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Ends(early morning) = %v, want %v", got, want)
+	}
+	noon := time.Date(2025, 3, 11, 12, 0, 0, 0, loc)
+	if got := q.Ends(noon); !got.Equal(noon) {
+		t.Errorf("Ends(noon) = %v, want noon", got)
+	}
+}
+
+func TestQuietHours_Validate(t *testing.T) {
+	if err := (QuietHours{Enabled: true, Start: 22, End: 7}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, q := range []QuietHours{{Enabled: true, Start: 8, End: 8}, {Start: 24, End: 7}, {Start: 22, End: -1}} {
+		if err := q.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded, want error", q)
+		}
+	}
+}
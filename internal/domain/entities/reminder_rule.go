@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder offsets are days relative to a task's due date: negative is
+// before it, zero is the due day and positive is that many days overdue.
+const (
+	MinReminderOffset = -7
+	MaxReminderOffset = 30
+)
+
+// ReminderRule tells the notification scheduler when to remind a user
+// about their tasks, e.g. the day before at 6pm or the morning of at 7am.
+type ReminderRule struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	OffsetDays int // days relative to the due date
+	SendHour   int // hour of day in the user's timezone, 0-23
+	CreatedAt  time.Time
+}
+
+func NewReminderRule(userID uuid.UUID, offsetDays, sendHour int) *ReminderRule {
+	return &ReminderRule{
+		ID:         uuid.Must(uuid.NewV7()),
+		UserID:     userID,
+		OffsetDays: offsetDays,
+		SendHour:   sendHour,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// DefaultReminderRules are used for users who haven't set their own:
+// the morning a task is due, and a follow-up once it is a day overdue.
+func DefaultReminderRules(userID uuid.UUID) []ReminderRule {
+	return []ReminderRule{
+		*NewReminderRule(userID, 0, 7),
+		*NewReminderRule(userID, 1, 9),
+	}
+}
+
+func (r *ReminderRule) Validate() error {
+	if r.OffsetDays < MinReminderOffset || r.OffsetDays > MaxReminderOffset {
+		return fmt.Errorf("reminder must be between %d days before and %d days after the due date", -MinReminderOffset, MaxReminderOffset)
+	}
+	if r.SendHour < 0 || r.SendHour > 23 {
+		return fmt.Errorf("send hour must be between 0 and 23")
+	}
+	return nil
+}
+
+// Key identifies the rule when claiming notifications, so each rule sends
+// at most once per channel per due date. It depends only on when the rule
+// fires, so a default rule and the same rule once saved share a key.
+func (r *ReminderRule) Key() string {
+	return fmt.Sprintf("%+d@%02d", r.OffsetDays, r.SendHour)
+}
+
+// Kind is NotificationKindOverdue for follow-ups after the due date and
+// NotificationKindDue otherwise.
+func (r *ReminderRule) Kind() string {
+	if r.OffsetDays > 0 {
+		return NotificationKindOverdue
+	}
+	return NotificationKindDue
+}
+
+// DueDate returns the due date of the tasks the rule reminds about on
+// today, the user's local calendar date.
+func (r *ReminderRule) DueDate(today time.Time) time.Time {
+	return DateOf(today).AddDate(0, 0, -r.OffsetDays)
+}
+
+// IsSendTime reports whether the rule's send hour has been reached on
+// now's day. now is in the user's timezone.
+func (r *ReminderRule) IsSendTime(now time.Time) bool {
+	return now.Hour() >= r.SendHour
+}
+
+// Label describes the rule, e.g. "Day before at 6 PM".
+func (r *ReminderRule) Label() string {
+	var when string
+	switch {
+	case r.OffsetDays == -1:
+		when = "Day before"
+	case r.OffsetDays < 0:
+		when = fmt.Sprintf("%d days before", -r.OffsetDays)
+	case r.OffsetDays == 0:
+		when = "Morning of"
+		if r.SendHour >= 12 {
+			when = "Day of"
+		}
+	case r.OffsetDays == 1:
+		when = "1 day overdue"
+	default:
+		when = fmt.Sprintf("%d days overdue", r.OffsetDays)
+	}
+	return when + " at " + FormatHour(r.SendHour)
+}
+
+// FormatHour formats an hour of day as e.g. "7 AM" or "6 PM".
+func FormatHour(hour int) string {
+	return time.Date(2000, 1, 1, hour, 0, 0, 0, time.UTC).Format("3 PM")
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestReminderRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  int
+		hour    int
+		wantErr bool
+	}{
+		{"day before", -1, 18, false},
+		{"morning of", 0, 7, false},
+		{"overdue follow-up", 3, 9, false},
+		{"too early", MinReminderOffset - 1, 9, true},
+		{"too late", MaxReminderOffset + 1, 9, true},
+		{"negative hour", 0, -1, true},
+		{"hour 24", 0, 24, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewReminderRule(uuid.New(), tt.offset, tt.hour).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReminderRule_DueDate(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		offset int
+		want   time.Time
+	}{
+		{-1, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{0, today},
+		{3, time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		r := ReminderRule{OffsetDays: tt.offset}
+		if got := r.DueDate(today); !got.Equal(tt.want) {
+			t.Errorf("offset %d: DueDate() = %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestReminderRule_KeyAndKind(t *testing.T) {
+	tests := []struct {
+		offset, hour int
+		key, kind    string
+	}{
+		{-1, 18, "-1@18", NotificationKindDue},
+		{0, 7, "+0@07", NotificationKindDue},
+		{2, 9, "+2@09", NotificationKindOverdue},
+	}
+	for _, tt := range tests {
+		r := ReminderRule{OffsetDays: tt.offset, SendHour: tt.hour}
+		if got := r.Key(); got != tt.key {
+			t.Errorf("Key() = %q, want %q", got, tt.key)
+		}
+		if got := r.Kind(); got != tt.kind {
+			t.Errorf("%s: Kind() = %q, want %q", tt.key, got, tt.kind)
+		}
+	}
+}
+
+func TestReminderRule_Label(t *testing.T) {
+	tests := []struct {
+		offset, hour int
+		want         string
+	}{
+		{-1, 18, "Day before at 6 PM"},
+		{-3, 9, "3 days before at 9 AM"},
+		{0, 7, "Morning of at 7 AM"},
+		{0, 12, "Day of at 12 PM"},
+		{1, 9, "1 day overdue at 9 AM"},
+		{5, 0, "5 days overdue at 12 AM"},
+	}
+	for _, tt := range tests {
+		r := ReminderRule{OffsetDays: tt.offset, SendHour: tt.hour}
+		if got := r.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SMSAction is what a text message reply asks for.
+type SMSAction string
+
+const (
+	SMSActionDone   SMSAction = "done"   // complete tasks from the last reminder
+	SMSActionSnooze SMSAction = "snooze" // put them off a day
+	SMSActionStop   SMSAction = "stop"   // opt out of text messages
+	SMSActionStart  SMSAction = "start"  // opt back in
+	SMSActionHelp   SMSAction = "help"   // answered by the carrier, not us
+	SMSActionOther  SMSAction = "other"  // anything we don't understand
+)
+
+// smsKeywords are the carrier opt-out, opt-in and help keywords. They only
+// count when they are the whole message.
+var smsKeywords = map[string]SMSAction{
+	"STOP":        SMSActionStop,
+	"STOPALL":     SMSActionStop,
+	"UNSUBSCRIBE": SMSActionStop,
+	"CANCEL":      SMSActionStop,
+	"END":         SMSActionStop,
+	"QUIT":        SMSActionStop,
+	"REVOKE":      SMSActionStop,
+	"OPTOUT":      SMSActionStop,
+	"START":       SMSActionStart,
+	"UNSTOP":      SMSActionStart,
+	"YES":         SMSActionStart,
+	"HELP":        SMSActionHelp,
+	"INFO":        SMSActionHelp,
+}
+
+// SMSReply is a parsed reply to a text message reminder.
+type SMSReply struct {
+	Action SMSAction
+	// Task is the 1-based position of the task in the reminder's list, or
+	// 0 for every task in it.
+	Task int
+}
+
+// ParseSMSReply reads a reply such as "DONE", "done 2" or "Snooze 1".
+// Case and surrounding whitespace don't matter.
+func ParseSMSReply(body string) SMSReply {
+	fields := strings.Fields(strings.ToUpper(body))
+	if len(fields) == 0 {
+		return SMSReply{Action: SMSActionOther}
+	}
+	if len(fields) == 1 {
+		if action, ok := smsKeywords[fields[0]]; ok {
+			return SMSReply{Action: action}
+		}
+	}
+
+	var reply SMSReply
+	switch fields[0] {
+	case "DONE":
+		reply.Action = SMSActionDone
+	case "SNOOZE":
+		reply.Action = SMSActionSnooze
+	default:
+		return SMSReply{Action: SMSActionOther}
+	}
+	switch len(fields) {
+	case 1:
+	case 2:
+		n, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+		if err != nil || n < 1 {
+			return SMSReply{Action: SMSActionOther}
+		}
+		reply.Task = n
+	default:
+		return SMSReply{Action: SMSActionOther}
+	}
+	return reply
+}
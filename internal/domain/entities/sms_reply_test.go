@@ -0,0 +1,31 @@
+package entities
+
+import "testing"
+
+func TestParseSMSReply(t *testing.T) {
+	tests := []struct {
+		body string
+		want SMSReply
+	}{
+		{"DONE", SMSReply{Action: SMSActionDone}},
+		{"  done\n", SMSReply{Action: SMSActionDone}},
+		{"Done 2", SMSReply{Action: SMSActionDone, Task: 2}},
+		{"DONE #3", SMSReply{Action: SMSActionDone, Task: 3}},
+		{"snooze", SMSReply{Action: SMSActionSnooze}},
+		{"SNOOZE 1", SMSReply{Action: SMSActionSnooze, Task: 1}},
+		{"STOP", SMSReply{Action: SMSActionStop}},
+		{"unsubscribe", SMSReply{Action: SMSActionStop}},
+		{"Start", SMSReply{Action: SMSActionStart}},
+		{"HELP", SMSReply{Action: SMSActionHelp}},
+		{"DONE 0", SMSReply{Action: SMSActionOther}},
+		{"DONE two", SMSReply{Action: SMSActionOther}},
+		{"DONE 1 2", SMSReply{Action: SMSActionOther}},
+		{"please stop", SMSReply{Action: SMSActionOther}},
+		{"", SMSReply{Action: SMSActionOther}},
+	}
+	for _, tt := range tests {
+		if got := ParseSMSReply(tt.body); got != tt.want {
+			t.Errorf("ParseSMSReply(%q) = %+v, want %+v", tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type StockMovementKind string
+
+const (
+	StockPurchase   StockMovementKind = "purchase"
+	StockDose       StockMovementKind = "dose"
+	StockAdjustment StockMovementKind = "adjustment"
+	StockDisposal   StockMovementKind = "disposal"
+)
+
+// StockMovementKinds lists the movement kinds in display order.
+var StockMovementKinds = []StockMovementKind{StockPurchase, StockDose, StockAdjustment, StockDisposal}
+
+// StockMovement is one entry in a chemical's stock ledger. A chemical's
+// stock is the sum of its movements' amounts.
+type StockMovement struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	ChemicalID uuid.UUID
+	Kind       StockMovementKind
+	// Amount is the signed change in stock: positive for purchases,
+	// negative for doses and disposals, and either for adjustments.
+	Amount         float64
+	Unit           valueobjects.Unit
+	Reason         string
+	Cost           *float64
+	ChemistryLogID *uuid.UUID
+	CreatedAt      time.Time
+}
+
+// RecordMovement applies a movement of the given kind to the chemical's
+// stock and returns it for the ledger. amount is the quantity moved, so it
+// is positive for every kind but adjustments, where it is the signed
+// correction.
+func (c *Chemical) RecordMovement(kind StockMovementKind, amount float64, reason string, cost *float64) (*StockMovement, error) {
+	delta := amount
+	switch kind {
+	case StockPurchase:
+	case StockDose, StockDisposal:
+		delta = -amount
+	case StockAdjustment:
+		if amount == 0 {
+			return nil, fmt.Errorf("adjustment can't be zero")
+		}
+	default:
+		return nil, fmt.Errorf("invalid stock movement kind: %s", kind)
+	}
+	if kind != StockAdjustment && amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if cost != nil && *cost < 0 {
+		return nil, fmt.Errorf("cost cannot be negative")
+	}
+	if kind == StockPurchase {
+		c.RecordPurchase(delta)
+	} else if err := c.AdjustStock(delta); err != nil {
+		return nil, err
+	}
+	return c.newMovement(kind, delta, reason, cost), nil
+}
+
+// OpeningMovement returns the adjustment that opens a new chemical's
+// ledger with its starting stock, or nil if it starts empty.
+func (c *Chemical) OpeningMovement() *StockMovement {
+	if c.Stock.Amount == 0 {
+		return nil
+	}
+	m := c.newMovement(StockAdjustment, c.Stock.Amount, "Opening stock", nil)
+	m.CreatedAt = c.CreatedAt
+	return m
+}
+
+// CorrectStock sets the stock to an amount counted by hand, possibly in a
+// different unit, and returns the adjustments that bring the ledger in
+// line. A unit change zeroes the old unit's balance before adding the new
+// amount, so the ledger's sum stays in the chemical's current unit.
+func (c *Chemical) CorrectStock(stock valueobjects.Quantity, reason string) []StockMovement {
+	var movements []StockMovement
+	if stock.Unit != c.Stock.Unit {
+		if c.Stock.Amount != 0 {
+			movements = append(movements, *c.newMovement(StockAdjustment, -c.Stock.Amount, reason, nil))
+		}
+		c.Stock = valueobjects.Quantity{Unit: stock.Unit}
+	}
+	if delta := stock.Amount - c.Stock.Amount; delta != 0 {
+		movements = append(movements, *c.newMovement(StockAdjustment, delta, reason, nil))
+	}
+	c.Stock = stock
+	return movements
+}
+
+// Reconcile sets the stock to the ledger's balance, the sum of every
+// movement, rounded to drop floating point drift.
+func (c *Chemical) Reconcile(balance float64) {
+	c.Stock.Amount = roundStock(balance)
+}
+
+func (c *Chemical) newMovement(kind StockMovementKind, delta float64, reason string, cost *float64) *StockMovement {
+	return &StockMovement{
+		ID:         uuid.Must(uuid.NewV7()),
+		UserID:     c.UserID,
+		ChemicalID: c.ID,
+		Kind:       kind,
+		Amount:     delta,
+		Unit:       c.Stock.Unit,
+		Reason:     reason,
+		Cost:       cost,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func roundStock(amount float64) float64 {
+	return math.Round(amount*1e4) / 1e4
+}
+
+// StockLedgerEntry is a movement with the chemical's stock after it.
+type StockLedgerEntry struct {
+	StockMovement
+	Balance float64
+}
+
+// StockLedger is a chemical's stock history with totals by kind. Totals
+// count only movements in the chemical's current unit.
+type StockLedger struct {
+	Entries   []StockLedgerEntry // newest first
+	Purchased float64
+	Used      float64
+	Disposed  float64
+	Adjusted  float64 // net
+	Spent     float64
+}
+
+// NewStockLedger builds the ledger of a chemical measured in unit from its
+// movements, oldest first.
+func NewStockLedger(unit valueobjects.Unit, movements []StockMovement) *StockLedger {
+	ledger := &StockLedger{Entries: make([]StockLedgerEntry, len(movements))}
+	var balance float64
+	for i, m := range movements {
+		balance = roundStock(balance + m.Amount)
+		ledger.Entries[len(movements)-1-i] = StockLedgerEntry{StockMovement: m, Balance: balance}
+		if m.Cost != nil {
+			ledger.Spent += *m.Cost
+		}
+		if m.Unit != unit {
+			continue
+		}
+		switch m.Kind {
+		case StockPurchase:
+			ledger.Purchased += m.Amount
+		case StockDose:
+			ledger.Used -= m.Amount
+		case StockDisposal:
+			ledger.Disposed -= m.Amount
+		case StockAdjustment:
+			ledger.Adjusted += m.Amount
+		}
+	}
+	ledger.Purchased = roundStock(ledger.Purchased)
+	ledger.Used = roundStock(ledger.Used)
+	ledger.Disposed = roundStock(ledger.Disposed)
+	ledger.Adjusted = roundStock(ledger.Adjusted)
+	ledger.Spent = math.Round(ledger.Spent*100) / 100
+	return ledger
+}
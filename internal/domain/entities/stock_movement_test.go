@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+func TestChemical_RecordMovement(t *testing.T) {
+	cost := 24.99
+	negative := -1.0
+	tests := []struct {
+		name       string
+		kind       StockMovementKind
+		amount     float64
+		cost       *float64
+		wantAmount float64
+		wantStock  float64
+		wantErr    bool
+	}{
+		{"purchase", StockPurchase, 25, &cost, 25, 35, false},
+		{"dose", StockDose, 2.5, nil, -2.5, 7.5, false},
+		{"disposal", StockDisposal, 10, nil, -10, 0, false},
+		{"adjustment down", StockAdjustment, -1.5, nil, -1.5, 8.5, false},
+		{"adjustment up", StockAdjustment, 3, nil, 3, 13, false},
+		{"dose below zero", StockDose, 11, nil, 0, 10, true},
+		{"negative dose", StockDose, -2, nil, 0, 10, true},
+		{"zero purchase", StockPurchase, 0, nil, 0, 10, true},
+		{"zero adjustment", StockAdjustment, 0, nil, 0, 10, true},
+		{"negative cost", StockPurchase, 5, &negative, 0, 10, true},
+		{"unknown kind", StockMovementKind("spill"), 1, nil, 0, 10, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChemical(uuid.New(), "Chlorine", ChemicalTypeSanitizer, valueobjects.Quantity{Amount: 10, Unit: valueobjects.UnitPounds}, 5)
+			m, err := c.RecordMovement(tt.kind, tt.amount, "reason", tt.cost)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RecordMovement() error = %v, want error %v", err, tt.wantErr)
+			}
+			if c.Stock.Amount != tt.wantStock {
+				t.Errorf("Stock.Amount = %v, want %v", c.Stock.Amount, tt.wantStock)
+			}
+			if err != nil {
+				return
+			}
+			if m.Amount != tt.wantAmount || m.Unit != valueobjects.UnitPounds || m.ChemicalID != c.ID || m.UserID != c.UserID {
+				t.Errorf("movement = %+v", m)
+			}
+			if (tt.kind == StockPurchase) != (c.LastPurchased != nil) {
+				t.Errorf("LastPurchased = %v after a %s", c.LastPurchased, tt.kind)
+			}
+		})
+	}
+}
+
+func TestChemical_CorrectStock(t *testing.T) {
+	c := NewChemical(uuid.New(), "Acid", ChemicalTypeBalancer, valueobjects.Quantity{Amount: 10, Unit: valueobjects.UnitPounds}, 1)
+	if m := c.OpeningMovement(); m == nil || m.Amount != 10 || m.Kind != StockAdjustment {
+		t.Fatalf("OpeningMovement() = %+v", m)
+	}
+	movements := []StockMovement{*c.OpeningMovement()}
+
+	got := c.CorrectStock(valueobjects.Quantity{Amount: 8, Unit: valueobjects.UnitPounds}, "Counted")
+	if len(got) != 1 || got[0].Amount != -2 {
+		t.Fatalf("CorrectStock(same unit) = %+v", got)
+	}
+	movements = append(movements, got...)
+	if c.CorrectStock(c.Stock, "Counted") != nil {
+		t.Error("CorrectStock recorded a movement for an unchanged stock")
+	}
+
+	got = c.CorrectStock(valueobjects.Quantity{Amount: 3, Unit: valueobjects.UnitGallons}, "Switched to liquid")
+	if len(got) != 2 || got[0].Amount != -8 || got[0].Unit != valueobjects.UnitPounds || got[1].Amount != 3 || got[1].Unit != valueobjects.UnitGallons {
+		t.Fatalf("CorrectStock(new unit) = %+v", got)
+	}
+	movements = append(movements, got...)
+
+	var sum float64
+	for _, m := range movements {
+		sum += m.Amount
+	}
+	c.Reconcile(sum)
+	if c.Stock.Amount != 3 || c.Stock.Unit != valueobjects.UnitGallons {
+		t.Errorf("Stock = %+v after reconciling, want 3 gal", c.Stock)
+	}
+
+	empty := NewChemical(uuid.New(), "Clarifier", ChemicalTypeClarifier, valueobjects.Quantity{Unit: valueobjects.UnitOunces}, 0)
+	if empty.OpeningMovement() != nil {
+		t.Error("OpeningMovement() returned a movement for an empty chemical")
+	}
+}
+
+func TestNewStockLedger(t *testing.T) {
+	cost1, cost2 := 20.0, 12.5
+	lbs := valueobjects.UnitPounds
+	movements := []StockMovement{
+		{Kind: StockAdjustment, Amount: 2, Unit: valueobjects.UnitKg},
+		{Kind: StockAdjustment, Amount: -2, Unit: valueobjects.UnitKg},
+		{Kind: StockPurchase, Amount: 10, Unit: lbs, Cost: &cost1},
+		{Kind: StockDose, Amount: -0.1, Unit: lbs},
+		{Kind: StockDose, Amount: -0.2, Unit: lbs},
+		{Kind: StockDisposal, Amount: -1, Unit: lbs},
+		{Kind: StockPurchase, Amount: 5, Unit: lbs, Cost: &cost2},
+		{Kind: StockAdjustment, Amount: -0.7, Unit: lbs},
+	}
+	ledger := NewStockLedger(lbs, movements)
+
+	if len(ledger.Entries) != len(movements) {
+		t.Fatalf("len(Entries) = %d", len(ledger.Entries))
+	}
+	if e := ledger.Entries[0]; e.Amount != -0.7 || e.Balance != 13 {
+		t.Errorf("newest entry = %+v, want -0.7 leaving 13", e)
+	}
+	if e := ledger.Entries[3]; e.Balance != 9.7 {
+		t.Errorf("balance after the doses = %v, want 9.7", e.Balance)
+	}
+	if ledger.Purchased != 15 || ledger.Used != 0.3 || ledger.Disposed != 1 || ledger.Adjusted != -0.7 || ledger.Spent != 32.5 {
+		t.Errorf("totals = %+v", ledger)
+	}
+}
@@ -14,26 +14,44 @@ const (
 	TaskStatusPending   TaskStatus = "pending"
 	TaskStatusCompleted TaskStatus = "completed"
 	TaskStatusOverdue   TaskStatus = "overdue"
+	TaskStatusSkipped   TaskStatus = "skipped"
 )
 
+// MaxSnoozeDays caps how far a single snooze can push an occurrence.
+const MaxSnoozeDays = 30
+
 type Task struct {
 	ID          uuid.UUID
 	UserID      uuid.UUID
+	SeriesID    uuid.UUID // shared by every occurrence of a recurring task
 	Name        string
 	Description string
 	Recurrence  valueobjects.Recurrence
+	// EquipmentID is the equipment the task maintains, if any.
+	EquipmentID *uuid.UUID
 	DueDate     time.Time
 	Status      TaskStatus
 	CompletedAt *time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// SkippedAt is set when the occurrence was deliberately skipped.
+	SkippedAt *time.Time
+	// SnoozedUntil postpones when the occurrence is due without moving
+	// DueDate, so the recurrence stays on its original schedule.
+	SnoozedUntil *time.Time
+	// OverdueAt is when the occurrence went overdue. It stays set once the
+	// overdue occurrence is completed or skipped.
+	OverdueAt *time.Time
+	Checklist []ChecklistItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 func NewTask(userID uuid.UUID, name, description string, recurrence valueobjects.Recurrence, dueDate time.Time) *Task {
 	now := time.Now()
+	id := uuid.Must(uuid.NewV7())
 	return &Task{
-		ID:          uuid.Must(uuid.NewV7()),
+		ID:          id,
 		UserID:      userID,
+		SeriesID:    id,
 		Name:        name,
 		Description: description,
 		Recurrence:  recurrence,
@@ -54,18 +72,184 @@ func (t *Task) Validate() error {
 	return nil
 }
 
-func (t *Task) Complete() *Task {
+// Complete closes the occurrence as done. The caller schedules the next
+// occurrence from the task's series.
+func (t *Task) Complete() {
 	now := time.Now()
 	t.Status = TaskStatusCompleted
 	t.CompletedAt = &now
 	t.UpdatedAt = now
+}
+
+// Skip closes the occurrence without doing it. The caller schedules the
+// next occurrence as it would after a completion.
+func (t *Task) Skip() error {
+	if !t.IsOpen() {
+		return fmt.Errorf("only open tasks can be skipped")
+	}
+	now := time.Now()
+	t.Status = TaskStatusSkipped
+	t.SkippedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// Snooze postpones the occurrence by days, counted from its current
+// effective due date or from today if that has already passed. now is in
+// the user's timezone.
+func (t *Task) Snooze(days int, now time.Time) error {
+	if !t.IsOpen() {
+		return fmt.Errorf("only open tasks can be snoozed")
+	}
+	if days < 1 || days > MaxSnoozeDays {
+		return fmt.Errorf("snooze must be between 1 and %d days", MaxSnoozeDays)
+	}
+	from := t.EffectiveDueDate()
+	if today := DateOf(now); from.Before(today) {
+		from = today
+	}
+	until := from.AddDate(0, 0, days)
+	t.SnoozedUntil = &until
+	t.Status = TaskStatusPending
+	t.OverdueAt = nil
+	t.UpdatedAt = now
+	return nil
+}
+
+// EffectiveDueDate is when the occurrence is actually expected to be done,
+// taking any snooze into account.
+func (t *Task) EffectiveDueDate() time.Time {
+	if t.SnoozedUntil != nil {
+		return *t.SnoozedUntil
+	}
+	return t.DueDate
+}
+
+// ClosedAt is when the occurrence was completed or skipped, or nil while it
+// is still open.
+func (t *Task) ClosedAt() *time.Time {
+	if t.CompletedAt != nil {
+		return t.CompletedAt
+	}
+	return t.SkippedAt
+}
+
+// IsOpen reports whether the occurrence still needs doing.
+func (t *Task) IsOpen() bool {
+	return t.Status == TaskStatusPending || t.Status == TaskStatusOverdue
+}
+
+// CheckItem marks a checklist step as done or not done.
+func (t *Task) CheckItem(itemID uuid.UUID, checked bool) error {
+	if !t.IsOpen() {
+		return fmt.Errorf("only open tasks can be updated")
+	}
+	for i := range t.Checklist {
+		if t.Checklist[i].ID != itemID {
+			continue
+		}
+		now := time.Now()
+		if checked {
+			t.Checklist[i].CheckedAt = &now
+		} else {
+			t.Checklist[i].CheckedAt = nil
+		}
+		t.UpdatedAt = now
+		return nil
+	}
+	return fmt.Errorf("checklist item not found")
+}
+
+// SetChecklist replaces the checklist, keeping steps that are already
+// checked checked if a step with the same name remains.
+func (t *Task) SetChecklist(items []ChecklistItem) {
+	checked := make(map[string]*time.Time)
+	for _, item := range t.Checklist {
+		if item.CheckedAt != nil {
+			checked[item.Name] = item.CheckedAt
+		}
+	}
+	t.Checklist = freshChecklist(items)
+	for i := range t.Checklist {
+		t.Checklist[i].CheckedAt = checked[t.Checklist[i].Name]
+	}
+}
+
+// ChecklistProgress returns how many steps are checked out of the total.
+func (t *Task) ChecklistProgress() (checked, total int) {
+	for _, item := range t.Checklist {
+		if item.CheckedAt != nil {
+			checked++
+		}
+	}
+	return checked, len(t.Checklist)
+}
+
+// RequiredItemsRemaining counts the required steps not yet checked. A task
+// can only be completed once this is zero.
+func (t *Task) RequiredItemsRemaining() int {
+	remaining := 0
+	for _, item := range t.Checklist {
+		if item.Required && item.CheckedAt == nil {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// UpcomingDueDates returns when this occurrence and the ones after it are
+// expected to be due, up to and including until. Later occurrences are
+// projected from the recurrence as if each were done on time.
+func (t *Task) UpcomingDueDates(until time.Time) []time.Time {
+	var dates []time.Time
+	if due := t.EffectiveDueDate(); !due.After(until) {
+		dates = append(dates, due)
+	}
+	due := t.DueDate
+	for {
+		next := t.Recurrence.NextDueDate(due)
+		if !next.After(due) || next.After(until) {
+			return dates
+		}
+		due = next
+		if due.After(t.EffectiveDueDate()) {
+			dates = append(dates, due)
+		}
+	}
+}
+
+// DateOf returns the calendar date of t in t's own location, as midnight
+// UTC. Due dates are stored this way, so a time converted to the user's
+// timezone can be compared with them directly.
+func DateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IsPastDue reports whether the occurrence's due day is over, given now in
+// the user's timezone. A task is not overdue on the day it is due.
+func (t *Task) IsPastDue(now time.Time) bool {
+	return t.EffectiveDueDate().Before(DateOf(now))
+}
 
-	next := NewTask(t.UserID, t.Name, t.Description, t.Recurrence, t.Recurrence.NextDueDate(t.DueDate))
-	return next
+// CompletedOnTime reports whether the occurrence was completed on or
+// before its due day in loc.
+func (t *Task) CompletedOnTime(loc *time.Location) bool {
+	return t.Status == TaskStatusCompleted && t.CompletedAt != nil &&
+		!DateOf(t.CompletedAt.In(loc)).After(t.EffectiveDueDate())
 }
 
-func (t *Task) CheckOverdue() {
-	if t.Status == TaskStatusPending && time.Now().After(t.DueDate) {
+// CheckOverdue moves an open occurrence between pending and overdue to
+// match its due date, e.g. after the due date was edited. now is in the
+// user's timezone. Pending tasks are otherwise marked overdue in bulk by
+// the overdue job.
+func (t *Task) CheckOverdue(now time.Time) {
+	switch {
+	case t.Status == TaskStatusPending && t.IsPastDue(now):
+		overdueAt := now.UTC()
 		t.Status = TaskStatusOverdue
+		t.OverdueAt = &overdueAt
+	case t.Status == TaskStatusOverdue && !t.IsPastDue(now):
+		t.Status = TaskStatusPending
+		t.OverdueAt = nil
 	}
 }
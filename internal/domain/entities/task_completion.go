@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxCompletionPhotos    = 4
+	MaxCompletionPhotoSize = 5 << 20 // 5 MB
+	maxCompletionDuration  = 24 * 60 // minutes
+)
+
+// TaskCompletion records what was actually done when a task occurrence was
+// completed: notes, time spent, photos and optional links to a chemistry log
+// or equipment service record.
+type TaskCompletion struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	TaskID          uuid.UUID
+	SeriesID        uuid.UUID
+	Notes           string
+	DurationMinutes int
+	ChemistryLogID  *uuid.UUID
+	ServiceRecordID *uuid.UUID
+	Photos          []TaskPhoto
+	CompletedAt     time.Time
+	CreatedAt       time.Time
+}
+
+// TaskPhoto is an image attached to a task completion. Data is only loaded
+// when the photo itself is requested.
+type TaskPhoto struct {
+	ID           uuid.UUID
+	CompletionID uuid.UUID
+	UserID       uuid.UUID
+	Filename     string
+	ContentType  string
+	Data         []byte
+	CreatedAt    time.Time
+}
+
+func NewTaskCompletion(t *Task, notes string, durationMinutes int, chemistryLogID, serviceRecordID *uuid.UUID) *TaskCompletion {
+	now := time.Now()
+	completedAt := now
+	if t.CompletedAt != nil {
+		completedAt = *t.CompletedAt
+	}
+	return &TaskCompletion{
+		ID:              uuid.Must(uuid.NewV7()),
+		UserID:          t.UserID,
+		TaskID:          t.ID,
+		SeriesID:        t.SeriesID,
+		Notes:           notes,
+		DurationMinutes: durationMinutes,
+		ChemistryLogID:  chemistryLogID,
+		ServiceRecordID: serviceRecordID,
+		CompletedAt:     completedAt,
+		CreatedAt:       now,
+	}
+}
+
+// AddPhoto attaches an image to the completion. The content type is sniffed
+// from the data rather than trusted from the client.
+func (c *TaskCompletion) AddPhoto(filename string, data []byte) error {
+	if len(c.Photos) >= MaxCompletionPhotos {
+		return fmt.Errorf("at most %d photos are allowed", MaxCompletionPhotos)
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("photo is empty")
+	}
+	if len(data) > MaxCompletionPhotoSize {
+		return fmt.Errorf("photo %q exceeds %d MB", filename, MaxCompletionPhotoSize>>20)
+	}
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+	default:
+		return fmt.Errorf("unsupported photo type: %s", contentType)
+	}
+	c.Photos = append(c.Photos, TaskPhoto{
+		ID:           uuid.Must(uuid.NewV7()),
+		CompletionID: c.ID,
+		UserID:       c.UserID,
+		Filename:     filename,
+		ContentType:  contentType,
+		Data:         data,
+		CreatedAt:    time.Now(),
+	})
+	return nil
+}
+
+func (c *TaskCompletion) Validate() error {
+	if c.TaskID == uuid.Nil {
+		return fmt.Errorf("task ID is required")
+	}
+	if c.DurationMinutes < 0 {
+		return fmt.Errorf("duration cannot be negative")
+	}
+	if c.DurationMinutes > maxCompletionDuration {
+		return fmt.Errorf("duration cannot exceed 24 hours")
+	}
+	if len(c.Photos) > MaxCompletionPhotos {
+		return fmt.Errorf("at most %d photos are allowed", MaxCompletionPhotos)
+	}
+	return nil
+}
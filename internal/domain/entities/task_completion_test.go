@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestNewTaskCompletion(t *testing.T) {
+	completedAt := time.Date(2025, 3, 2, 9, 30, 0, 0, time.UTC)
+	task := &Task{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		SeriesID:    uuid.New(),
+		CompletedAt: &completedAt,
+	}
+	logID := uuid.New()
+
+	c := NewTaskCompletion(task, "Brushed walls", 25, &logID, nil)
+
+	if c.TaskID != task.ID {
+		t.Errorf("TaskID = %v, want %v", c.TaskID, task.ID)
+	}
+	if c.SeriesID != task.SeriesID {
+		t.Errorf("SeriesID = %v, want %v", c.SeriesID, task.SeriesID)
+	}
+	if c.UserID != task.UserID {
+		t.Errorf("UserID = %v, want %v", c.UserID, task.UserID)
+	}
+	if !c.CompletedAt.Equal(completedAt) {
+		t.Errorf("CompletedAt = %v, want %v", c.CompletedAt, completedAt)
+	}
+	if c.ChemistryLogID == nil || *c.ChemistryLogID != logID {
+		t.Errorf("ChemistryLogID = %v, want %v", c.ChemistryLogID, logID)
+	}
+	if c.ServiceRecordID != nil {
+		t.Errorf("ServiceRecordID = %v, want nil", c.ServiceRecordID)
+	}
+}
+
+func TestTaskCompletion_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       TaskCompletion
+		wantErr string
+	}{
+		{
+			name:    "missing task",
+			c:       TaskCompletion{},
+			wantErr: "task ID is required",
+		},
+		{
+			name:    "negative duration",
+			c:       TaskCompletion{TaskID: uuid.New(), DurationMinutes: -5},
+			wantErr: "duration cannot be negative",
+		},
+		{
+			name:    "duration over a day",
+			c:       TaskCompletion{TaskID: uuid.New(), DurationMinutes: 24*60 + 1},
+			wantErr: "duration cannot exceed 24 hours",
+		},
+		{
+			name: "valid",
+			c:    TaskCompletion{TaskID: uuid.New(), DurationMinutes: 30},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.c.Validate()
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if err.Error() != tt.wantErr {
+					t.Errorf("error = %q, want %q", err.Error(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskCompletion_AddPhoto(t *testing.T) {
+	c := &TaskCompletion{ID: uuid.New(), UserID: uuid.New(), TaskID: uuid.New()}
+
+	if err := c.AddPhoto("filter.png", pngHeader); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.Photos) != 1 {
+		t.Fatalf("len(Photos) = %d, want 1", len(c.Photos))
+	}
+	p := c.Photos[0]
+	if p.ContentType != "image/png" {
+		t.Errorf("ContentType = %q, want image/png", p.ContentType)
+	}
+	if p.CompletionID != c.ID || p.UserID != c.UserID {
+		t.Error("photo should inherit completion and user IDs")
+	}
+
+	if err := c.AddPhoto("notes.txt", []byte("just some text")); err == nil {
+		t.Error("expected error for non-image data")
+	}
+	if err := c.AddPhoto("empty.png", nil); err == nil {
+		t.Error("expected error for empty data")
+	}
+	big := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, MaxCompletionPhotoSize)...)
+	if err := c.AddPhoto("huge.png", big); err == nil {
+		t.Error("expected error for oversized photo")
+	}
+
+	for len(c.Photos) < MaxCompletionPhotos {
+		if err := c.AddPhoto("more.png", pngHeader); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := c.AddPhoto("one-too-many.png", pngHeader); err == nil {
+		t.Error("expected error when exceeding photo limit")
+	}
+}
@@ -6,11 +6,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// Notification kinds: reminders before or on the due date, and follow-ups
+// once tasks are overdue.
+const (
+	NotificationKindDue     = "due"
+	NotificationKindOverdue = "overdue"
+)
+
 type TaskNotification struct {
 	ID      uuid.UUID
 	TaskID  uuid.UUID
 	UserID  uuid.UUID
-	Type    string // "email" or "sms"
+	Type    string      // "email" or "sms"
+	Kind    string      // NotificationKindDue, NotificationKindOverdue or an alert category
+	Rule    string      // ReminderRule.Key of the rule that sent it, or the alert's key
+	TaskIDs []uuid.UUID // the tasks a reminder batch covered, in the order its message lists them
 	DueDate time.Time
 	SentAt  time.Time
 }
@@ -21,19 +31,72 @@ func NewTaskNotification(taskID, userID uuid.UUID, notifType string, dueDate tim
 		TaskID:  taskID,
 		UserID:  userID,
 		Type:    notifType,
+		Kind:    NotificationKindDue,
 		DueDate: dueDate,
 		SentAt:  time.Now(),
 	}
 }
 
-// NewBatchNotification creates a notification record for a batched daily digest.
-// TaskID is left as zero since the notification covers all tasks for the day.
-func NewBatchNotification(userID uuid.UUID, notifType string, dueDate time.Time) *TaskNotification {
+// NewBatchNotification creates a notification record for one reminder rule's
+// batch of tasks due on dueDate. TaskID is left as zero since the
+// notification covers all of those tasks; they are listed in TaskIDs.
+func NewBatchNotification(rule *ReminderRule, notifType string, dueDate time.Time, tasks []Task) *TaskNotification {
+	ids := make([]uuid.UUID, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
 	return &TaskNotification{
 		ID:      uuid.Must(uuid.NewV7()),
-		UserID:  userID,
+		UserID:  rule.UserID,
 		Type:    notifType,
+		Kind:    rule.Kind(),
+		Rule:    rule.Key(),
+		TaskIDs: ids,
 		DueDate: dueDate,
 		SentAt:  time.Now(),
 	}
 }
+
+// IsReminder reports whether the notification is a task reminder rather
+// than an alert or webhook event.
+func (n *TaskNotification) IsReminder() bool {
+	return n.Kind == NotificationKindDue || n.Kind == NotificationKindOverdue
+}
+
+// Category is the notification category the user opted into to get this
+// notification.
+func (n *TaskNotification) Category() NotificationCategory {
+	if n.IsReminder() {
+		return CategoryReminders
+	}
+	return NotificationCategory(n.Kind)
+}
+
+// NewAlertNotification creates a notification record for an alert. key
+// and date identify the alert, so it is sent at most once per channel.
+func NewAlertNotification(userID uuid.UUID, channel string, category NotificationCategory, key string, date time.Time) *TaskNotification {
+	return &TaskNotification{
+		ID:      uuid.Must(uuid.NewV7()),
+		UserID:  userID,
+		Type:    channel,
+		Kind:    string(category),
+		Rule:    key,
+		DueDate: date,
+		SentAt:  time.Now(),
+	}
+}
+
+// NewTaskEventNotification creates a record of a task event published to
+// the user's webhooks, so each event is published once per task and date.
+func NewTaskEventNotification(task *Task, event WebhookEvent, date time.Time) *TaskNotification {
+	return &TaskNotification{
+		ID:      uuid.Must(uuid.NewV7()),
+		TaskID:  task.ID,
+		UserID:  task.UserID,
+		Type:    "webhook",
+		Kind:    string(event),
+		Rule:    string(event) + ":" + task.ID.String(),
+		DueDate: date,
+		SentAt:  time.Now(),
+	}
+}
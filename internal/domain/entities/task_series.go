@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// TaskSeries is the template behind a recurring task. Each occurrence is a
+// Task sharing the series ID; the series decides the name, description and
+// recurrence of occurrences that have not been created yet.
+type TaskSeries struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	Recurrence  valueobjects.Recurrence
+	// EquipmentID is the equipment the task maintains, if any.
+	EquipmentID *uuid.UUID
+	// Checklist is the set of steps every new occurrence starts with.
+	Checklist []ChecklistItem
+	EndedAt   *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewTaskSeries(userID uuid.UUID, name, description string, recurrence valueobjects.Recurrence) *TaskSeries {
+	now := time.Now()
+	return &TaskSeries{
+		ID:          uuid.Must(uuid.NewV7()),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		Recurrence:  recurrence,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func (s *TaskSeries) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return nil
+}
+
+// NewOccurrence creates a pending task in this series due on dueDate, with
+// a fresh copy of the series checklist.
+func (s *TaskSeries) NewOccurrence(dueDate time.Time) *Task {
+	t := NewTask(s.UserID, s.Name, s.Description, s.Recurrence, dueDate)
+	t.SeriesID = s.ID
+	t.EquipmentID = s.EquipmentID
+	t.Checklist = freshChecklist(s.Checklist)
+	return t
+}
+
+// NextOccurrence creates the occurrence that follows prev using the series'
+// current recurrence. now is in the user's timezone. Completion-anchored
+// series count from the day prev was closed there, or from today if it
+// never was (e.g. it was deleted).
+func (s *TaskSeries) NextOccurrence(prev *Task, now time.Time) *Task {
+	closedAt := now
+	if c := prev.ClosedAt(); c != nil {
+		closedAt = *c
+	}
+	return s.NewOccurrence(s.Recurrence.NextDueDateAfter(prev.DueDate, closedAt, now.Location()))
+}
+
+// Apply copies the series details, equipment and checklist onto an
+// existing occurrence. Steps already checked on it stay checked.
+func (s *TaskSeries) Apply(t *Task) {
+	t.Name = s.Name
+	t.Description = s.Description
+	t.Recurrence = s.Recurrence
+	t.EquipmentID = s.EquipmentID
+	t.SetChecklist(s.Checklist)
+}
+
+func (s *TaskSeries) End() {
+	now := time.Now()
+	s.EndedAt = &now
+	s.UpdatedAt = now
+}
+
+func (s *TaskSeries) IsActive() bool {
+	return s.EndedAt == nil
+}
@@ -0,0 +1,178 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+func TestTaskSeries_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		series  TaskSeries
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			series:  TaskSeries{},
+			wantErr: "name is required",
+		},
+		{
+			name:   "valid",
+			series: TaskSeries{Name: "Clean filter"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.series.Validate()
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if err.Error() != tt.wantErr {
+					t.Errorf("error = %q, want %q", err.Error(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskSeries_NewOccurrence(t *testing.T) {
+	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 2)
+	s := NewTaskSeries(uuid.New(), "Clean filter", "Hose down cartridges", rec)
+	dueDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	occ := s.NewOccurrence(dueDate)
+
+	if occ.SeriesID != s.ID {
+		t.Errorf("SeriesID = %v, want %v", occ.SeriesID, s.ID)
+	}
+	if occ.ID == s.ID {
+		t.Error("occurrence should get its own ID")
+	}
+	if occ.UserID != s.UserID || occ.Name != s.Name || occ.Description != s.Description {
+		t.Error("occurrence should copy series details")
+	}
+	if occ.Recurrence != rec {
+		t.Errorf("Recurrence = %v, want %v", occ.Recurrence, rec)
+	}
+	if occ.Status != TaskStatusPending {
+		t.Errorf("Status = %v, want %v", occ.Status, TaskStatusPending)
+	}
+	if !occ.DueDate.Equal(dueDate) {
+		t.Errorf("DueDate = %v, want %v", occ.DueDate, dueDate)
+	}
+}
+
+func TestTaskSeries_NextOccurrence(t *testing.T) {
+	weekly, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 1)
+	monthly, _ := valueobjects.NewRecurrence(valueobjects.FrequencyMonthly, 1)
+	s := NewTaskSeries(uuid.New(), "Clean filter", "", monthly)
+
+	// The previous occurrence was edited on its own; the series still wins.
+	prev := s.NewOccurrence(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	prev.Name = "Clean filter (deep clean)"
+	prev.Recurrence = weekly
+
+	next := s.NextOccurrence(prev, time.Now())
+
+	if next.Name != "Clean filter" {
+		t.Errorf("Name = %q, want %q", next.Name, "Clean filter")
+	}
+	wantDue := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	if !next.DueDate.Equal(wantDue) {
+		t.Errorf("DueDate = %v, want %v", next.DueDate, wantDue)
+	}
+	if next.SeriesID != s.ID {
+		t.Errorf("SeriesID = %v, want %v", next.SeriesID, s.ID)
+	}
+}
+
+func TestTaskSeries_NextOccurrence_Anchors(t *testing.T) {
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2025, 3, 11, 16, 0, 0, 0, time.UTC) // ten days late
+	skippedAt := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		anchor  valueobjects.Anchor
+		prev    Task
+		wantDue time.Time
+	}{
+		{"due date anchor after late completion", valueobjects.AnchorDueDate,
+			Task{DueDate: due, Status: TaskStatusCompleted, CompletedAt: &completedAt}, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"completion anchor after late completion", valueobjects.AnchorCompletionDate,
+			Task{DueDate: due, Status: TaskStatusCompleted, CompletedAt: &completedAt}, time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{"completion anchor after skip", valueobjects.AnchorCompletionDate,
+			Task{DueDate: due, Status: TaskStatusSkipped, SkippedAt: &skippedAt}, time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyDaily, 14)
+			rec, _ = rec.WithAnchor(tt.anchor)
+			s := NewTaskSeries(uuid.New(), "Clean filter", "", rec)
+			next := s.NextOccurrence(&tt.prev, time.Now().UTC())
+			if !next.DueDate.Equal(tt.wantDue) {
+				t.Errorf("DueDate = %v, want %v", next.DueDate, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestTaskSeries_Apply(t *testing.T) {
+	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyDaily, 3)
+	s := &TaskSeries{Name: "Skim", Description: "Skim surface", Recurrence: rec}
+	dueDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	task := &Task{Name: "Old", Description: "Old desc", DueDate: dueDate}
+
+	s.Apply(task)
+
+	if task.Name != "Skim" || task.Description != "Skim surface" || task.Recurrence != rec {
+		t.Errorf("Apply did not copy series details: %+v", task)
+	}
+	if !task.DueDate.Equal(dueDate) {
+		t.Error("Apply should not change the due date")
+	}
+}
+
+func TestTaskSeries_End(t *testing.T) {
+	s := &TaskSeries{Name: "Skim"}
+	if !s.IsActive() {
+		t.Fatal("new series should be active")
+	}
+	s.End()
+	if s.IsActive() || s.EndedAt == nil {
+		t.Error("ended series should not be active")
+	}
+}
+
+func TestTaskSeries_NewOccurrence_FreshChecklist(t *testing.T) {
+	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyMonthly, 12)
+	s := NewTaskSeries(uuid.New(), "Open pool", "", rec)
+	s.Checklist = ParseChecklist("Remove cover\nStart pump")
+
+	first := s.NewOccurrence(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	_ = first.CheckItem(first.Checklist[0].ID, true)
+	next := s.NextOccurrence(first, time.Now())
+
+	if len(next.Checklist) != 2 {
+		t.Fatalf("got %d items, want 2", len(next.Checklist))
+	}
+	for i, item := range next.Checklist {
+		if item.CheckedAt != nil {
+			t.Errorf("item %d should start unchecked", i)
+		}
+		if item.ID == first.Checklist[i].ID {
+			t.Errorf("item %d should get its own ID", i)
+		}
+	}
+	if s.Checklist[0].CheckedAt != nil {
+		t.Error("checking an occurrence should not touch the series checklist")
+	}
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// TaskTemplate is a reusable maintenance schedule. Applying it creates one
+// recurring task series per item. Templates without a UserID are site-wide
+// and offered to everyone; built-in templates ship with PoolVibes and are
+// never stored.
+type TaskTemplate struct {
+	ID          uuid.UUID
+	UserID      *uuid.UUID
+	Name        string
+	Description string
+	BuiltIn     bool
+	Items       []TaskTemplateItem
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TaskTemplateItem describes one recurring task in a template. DueInDays is
+// how many days after the template is applied the first occurrence is due.
+type TaskTemplateItem struct {
+	ID          uuid.UUID
+	TemplateID  uuid.UUID
+	Position    int
+	Name        string
+	Description string
+	Recurrence  valueobjects.Recurrence
+	DueInDays   int
+}
+
+func NewTaskTemplate(userID *uuid.UUID, name, description string) *TaskTemplate {
+	now := time.Now()
+	return &TaskTemplate{
+		ID:          uuid.Must(uuid.NewV7()),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// AddItem appends a task to the template.
+func (t *TaskTemplate) AddItem(name, description string, recurrence valueobjects.Recurrence, dueInDays int) {
+	t.Items = append(t.Items, TaskTemplateItem{
+		ID:          uuid.Must(uuid.NewV7()),
+		TemplateID:  t.ID,
+		Position:    len(t.Items),
+		Name:        name,
+		Description: description,
+		Recurrence:  recurrence,
+		DueInDays:   dueInDays,
+	})
+}
+
+func (t *TaskTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(t.Items) == 0 {
+		return fmt.Errorf("template must have at least one task")
+	}
+	for _, item := range t.Items {
+		if item.Name == "" {
+			return fmt.Errorf("task name is required")
+		}
+		if item.DueInDays < 0 {
+			return fmt.Errorf("days until first due date cannot be negative")
+		}
+	}
+	return nil
+}
+
+// IsSiteWide reports whether the template is offered to every user.
+func (t *TaskTemplate) IsSiteWide() bool {
+	return t.UserID == nil
+}
+
+// Series creates a task series for the user from the item.
+func (i *TaskTemplateItem) Series(userID uuid.UUID) *TaskSeries {
+	return NewTaskSeries(userID, i.Name, i.Description, i.Recurrence)
+}
+
+// DueDate returns the first due date when the template is applied on start.
+func (i *TaskTemplateItem) DueDate(start time.Time) time.Time {
+	return start.AddDate(0, 0, i.DueInDays)
+}
@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// builtInTemplateNamespace seeds the stable IDs of built-in templates so
+// they can be referenced across restarts without being stored.
+var builtInTemplateNamespace = uuid.MustParse("5b0f3c1e-9a53-4d3e-8f43-6f1f0c2d7a10")
+
+type templateItemSpec struct {
+	name        string
+	description string
+	frequency   valueobjects.Frequency
+	interval    int
+	anchor      valueobjects.Anchor
+	dueInDays   int
+}
+
+type templateSpec struct {
+	name        string
+	description string
+	items       []templateItemSpec
+}
+
+var builtInTemplateSpecs = []templateSpec{
+	{
+		name:        "Weekly Essentials",
+		description: "The weekly routine every pool needs.",
+		items: []templateItemSpec{
+			{"Brush walls and floor", "Brush walls, steps and floor toward the main drain.", valueobjects.FrequencyWeekly, 1, valueobjects.AnchorDueDate, 0},
+			{"Vacuum pool", "Vacuum debris from the floor and empty the pump basket.", valueobjects.FrequencyWeekly, 1, valueobjects.AnchorDueDate, 1},
+			{"Empty skimmer baskets", "", valueobjects.FrequencyWeekly, 1, valueobjects.AnchorDueDate, 0},
+			{"Test water chemistry", "Test and log chlorine, pH and alkalinity.", valueobjects.FrequencyWeekly, 1, valueobjects.AnchorDueDate, 0},
+		},
+	},
+	{
+		name:        "Cartridge Filter Care",
+		description: "Cleaning schedule for cartridge filters.",
+		items: []templateItemSpec{
+			{"Rinse filter cartridges", "Hose down cartridges when pressure is 8-10 psi above clean.", valueobjects.FrequencyWeekly, 2, valueobjects.AnchorCompletionDate, 14},
+			{"Deep clean filter cartridges", "Soak cartridges in filter cleaner overnight.", valueobjects.FrequencyMonthly, 6, valueobjects.AnchorCompletionDate, 90},
+		},
+	},
+	{
+		name:        "Sand Filter Care",
+		description: "Backwash and media schedule for sand filters.",
+		items: []templateItemSpec{
+			{"Backwash sand filter", "Backwash until the sight glass runs clear, then rinse.", valueobjects.FrequencyWeekly, 2, valueobjects.AnchorCompletionDate, 14},
+			{"Inspect sand filter media", "Check for channeling or clumping; replace sand every 5-7 years.", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 180},
+		},
+	},
+	{
+		name:        "DE Filter Care",
+		description: "Backwash and recharge schedule for diatomaceous earth filters.",
+		items: []templateItemSpec{
+			{"Backwash and recharge DE", "Backwash, then add fresh DE through the skimmer.", valueobjects.FrequencyWeekly, 4, valueobjects.AnchorCompletionDate, 28},
+			{"Clean DE grids", "Remove and hose down grids; inspect for tears.", valueobjects.FrequencyMonthly, 6, valueobjects.AnchorCompletionDate, 180},
+		},
+	},
+	{
+		name:        "Salt System",
+		description: "Upkeep for salt chlorine generators.",
+		items: []templateItemSpec{
+			{"Test salt level", "Confirm salt is within the cell manufacturer's range.", valueobjects.FrequencyMonthly, 1, valueobjects.AnchorDueDate, 7},
+			{"Inspect salt cell", "Check plates for scale; clean with a mild acid wash if needed.", valueobjects.FrequencyMonthly, 3, valueobjects.AnchorCompletionDate, 30},
+		},
+	},
+	{
+		name:        "Season Opening",
+		description: "Yearly checklist for opening the pool.",
+		items: []templateItemSpec{
+			{"Remove and clean winter cover", "", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 0},
+			{"Reinstall plugs, ladders and equipment", "Remove winterizing plugs and reconnect pump and filter.", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 0},
+			{"Top up water and start circulation", "", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 1},
+			{"Balance and shock opening water", "Test, balance and shock before swimming.", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 2},
+		},
+	},
+	{
+		name:        "Season Closing",
+		description: "Yearly checklist for winterizing the pool.",
+		items: []templateItemSpec{
+			{"Balance water and add winterizer", "", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 0},
+			{"Lower water and blow out lines", "Blow out plumbing and plug return and skimmer lines.", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 1},
+			{"Drain and store equipment", "Drain pump, filter and heater; store ladders and hoses.", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 1},
+			{"Install winter cover", "", valueobjects.FrequencyMonthly, 12, valueobjects.AnchorDueDate, 2},
+		},
+	},
+}
+
+// BuiltInTaskTemplates returns the templates that ship with PoolVibes.
+func BuiltInTaskTemplates() []TaskTemplate {
+	templates := make([]TaskTemplate, 0, len(builtInTemplateSpecs))
+	for _, spec := range builtInTemplateSpecs {
+		t := TaskTemplate{
+			ID:          uuid.NewSHA1(builtInTemplateNamespace, []byte(spec.name)),
+			Name:        spec.name,
+			Description: spec.description,
+			BuiltIn:     true,
+		}
+		for i, s := range spec.items {
+			rec, err := valueobjects.NewRecurrence(s.frequency, s.interval)
+			if err != nil {
+				panic("built-in template " + spec.name + ": " + err.Error())
+			}
+			rec, _ = rec.WithAnchor(s.anchor)
+			t.Items = append(t.Items, TaskTemplateItem{
+				ID:          uuid.NewSHA1(t.ID, []byte(s.name)),
+				TemplateID:  t.ID,
+				Position:    i,
+				Name:        s.name,
+				Description: s.description,
+				Recurrence:  rec,
+				DueInDays:   s.dueInDays,
+			})
+		}
+		templates = append(templates, t)
+	}
+	return templates
+}
+
+// FindBuiltInTaskTemplate returns the built-in template with the given ID,
+// or nil if there is none.
+func FindBuiltInTaskTemplate(id uuid.UUID) *TaskTemplate {
+	for _, t := range BuiltInTaskTemplates() {
+		if t.ID == id {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+func TestTaskTemplate_Validate(t *testing.T) {
+	weekly, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 1)
+	withItem := func(name string, dueInDays int) TaskTemplate {
+		tmpl := TaskTemplate{Name: "Weekly"}
+		tmpl.AddItem(name, "", weekly, dueInDays)
+		return tmpl
+	}
+
+	tests := []struct {
+		name     string
+		template TaskTemplate
+		wantErr  string
+	}{
+		{"missing name", TaskTemplate{}, "name is required"},
+		{"no items", TaskTemplate{Name: "Weekly"}, "template must have at least one task"},
+		{"item missing name", withItem("", 0), "task name is required"},
+		{"negative due offset", withItem("Brush", -1), "days until first due date cannot be negative"},
+		{"valid", withItem("Brush", 0), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Validate()
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if err.Error() != tt.wantErr {
+					t.Errorf("error = %q, want %q", err.Error(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskTemplate_AddItem(t *testing.T) {
+	weekly, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 1)
+	tmpl := NewTaskTemplate(nil, "Weekly", "")
+	tmpl.AddItem("Brush", "", weekly, 0)
+	tmpl.AddItem("Vacuum", "", weekly, 1)
+
+	if !tmpl.IsSiteWide() {
+		t.Error("template without a user should be site-wide")
+	}
+	for i, item := range tmpl.Items {
+		if item.Position != i {
+			t.Errorf("item %d Position = %d", i, item.Position)
+		}
+		if item.TemplateID != tmpl.ID {
+			t.Errorf("item %d TemplateID = %v, want %v", i, item.TemplateID, tmpl.ID)
+		}
+	}
+}
+
+func TestTaskTemplateItem_SeriesAndDueDate(t *testing.T) {
+	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyWeekly, 2)
+	item := TaskTemplateItem{Name: "Rinse filter", Description: "Hose down", Recurrence: rec, DueInDays: 14}
+	userID := uuid.New()
+
+	s := item.Series(userID)
+	if s.UserID != userID || s.Name != item.Name || s.Description != item.Description || s.Recurrence != rec {
+		t.Errorf("Series did not copy item details: %+v", s)
+	}
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	if got := item.DueDate(start); !got.Equal(want) {
+		t.Errorf("DueDate = %v, want %v", got, want)
+	}
+}
+
+func TestBuiltInTaskTemplates(t *testing.T) {
+	templates := BuiltInTaskTemplates()
+	if len(templates) == 0 {
+		t.Fatal("expected built-in templates")
+	}
+	seen := make(map[uuid.UUID]bool)
+	for _, tmpl := range templates {
+		if err := tmpl.Validate(); err != nil {
+			t.Errorf("%s: %v", tmpl.Name, err)
+		}
+		if !tmpl.BuiltIn {
+			t.Errorf("%s: BuiltIn = false", tmpl.Name)
+		}
+		if seen[tmpl.ID] {
+			t.Errorf("%s: duplicate ID %v", tmpl.Name, tmpl.ID)
+		}
+		seen[tmpl.ID] = true
+	}
+
+	// IDs must be stable so templates can be applied by ID.
+	first := templates[0]
+	found := FindBuiltInTaskTemplate(first.ID)
+	if found == nil || found.Name != first.Name {
+		t.Errorf("FindBuiltInTaskTemplate(%v) = %v, want %s", first.ID, found, first.Name)
+	}
+	if FindBuiltInTaskTemplate(uuid.New()) != nil {
+		t.Error("expected nil for unknown ID")
+	}
+}
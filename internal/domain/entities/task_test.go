@@ -49,107 +49,297 @@ func TestTask_Validate(t *testing.T) {
 }
 
 func TestTask_CheckOverdue(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
 	tests := []struct {
-		name       string
-		status     TaskStatus
-		dueDate    time.Time
-		wantStatus TaskStatus
+		name        string
+		status      TaskStatus
+		dueDate     time.Time
+		wantStatus  TaskStatus
+		wantOverdue bool
 	}{
 		{
-			name:       "pending past due becomes overdue",
+			name:        "pending past due becomes overdue",
+			status:      TaskStatusPending,
+			dueDate:     time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+			wantStatus:  TaskStatusOverdue,
+			wantOverdue: true,
+		},
+		{
+			name:       "pending due today stays pending",
 			status:     TaskStatusPending,
-			dueDate:    time.Now().Add(-24 * time.Hour),
-			wantStatus: TaskStatusOverdue,
+			dueDate:    time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			wantStatus: TaskStatusPending,
 		},
 		{
 			name:       "pending future due stays pending",
 			status:     TaskStatusPending,
-			dueDate:    time.Now().Add(24 * time.Hour),
+			dueDate:    time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+			wantStatus: TaskStatusPending,
+		},
+		{
+			name:       "overdue moved to a future date becomes pending",
+			status:     TaskStatusOverdue,
+			dueDate:    time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC),
 			wantStatus: TaskStatusPending,
 		},
 		{
 			name:       "completed past due stays completed",
 			status:     TaskStatusCompleted,
-			dueDate:    time.Now().Add(-24 * time.Hour),
+			dueDate:    time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
 			wantStatus: TaskStatusCompleted,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &Task{Status: tt.status, DueDate: tt.dueDate}
-			task.CheckOverdue()
+			task.CheckOverdue(now)
 			if task.Status != tt.wantStatus {
 				t.Errorf("Status = %v, want %v", task.Status, tt.wantStatus)
 			}
+			if (task.OverdueAt != nil) != tt.wantOverdue {
+				t.Errorf("OverdueAt = %v, want set %v", task.OverdueAt, tt.wantOverdue)
+			}
+		})
+	}
+}
+
+func TestTask_IsPastDue_AcrossTimezones(t *testing.T) {
+	task := &Task{Status: TaskStatusPending, DueDate: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)}
+	// 2025-03-09 14:00 UTC is already the 10th in Sydney but still the
+	// morning of the 9th in Los Angeles.
+	instant := time.Date(2025, 3, 9, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		zone string
+		want bool
+	}{
+		{"Australia/Sydney", true},
+		{"UTC", false},
+		{"America/Los_Angeles", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			if got := task.IsPastDue(instant.In(LoadTimezone(tt.zone))); got != tt.want {
+				t.Errorf("IsPastDue() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
 
+func TestTask_CompletedOnTime_AcrossTimezones(t *testing.T) {
+	// Done at 2025-03-09 20:00 UTC: the evening of the due day in Los
+	// Angeles, the next morning in Sydney.
+	completedAt := time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC)
+	task := &Task{
+		Status:      TaskStatusCompleted,
+		DueDate:     time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+		CompletedAt: &completedAt,
+	}
+	if !task.CompletedOnTime(LoadTimezone("America/Los_Angeles")) {
+		t.Error("expected on time in Los Angeles")
+	}
+	if task.CompletedOnTime(LoadTimezone("Australia/Sydney")) {
+		t.Error("expected late in Sydney")
+	}
+}
+
 func TestTask_Complete(t *testing.T) {
-	userID := uuid.New()
 	rec, _ := valueobjects.NewRecurrence(valueobjects.FrequencyDaily, 1)
-	dueDate := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
-
 	task := &Task{
-		ID:          uuid.New(),
-		UserID:      userID,
-		Name:        "Skim pool",
-		Description: "Skim debris",
-		Recurrence:  rec,
-		DueDate:     dueDate,
-		Status:      TaskStatusPending,
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		Name:       "Skim pool",
+		Recurrence: rec,
+		DueDate:    time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Status:     TaskStatusOverdue,
 	}
 
-	next := task.Complete()
+	task.Complete()
 
 	if task.Status != TaskStatusCompleted {
-		t.Errorf("original Status = %v, want %v", task.Status, TaskStatusCompleted)
+		t.Errorf("Status = %v, want %v", task.Status, TaskStatusCompleted)
 	}
 	if task.CompletedAt == nil {
-		t.Fatal("original CompletedAt should be set")
+		t.Fatal("CompletedAt should be set")
+	}
+	if task.IsOpen() {
+		t.Error("completed task should not be open")
+	}
+	if got := task.ClosedAt(); got != task.CompletedAt {
+		t.Errorf("ClosedAt() = %v, want CompletedAt", got)
+	}
+}
+
+func TestTask_CheckOverdue_Snoozed(t *testing.T) {
+	now := time.Now()
+	until := now.Add(48 * time.Hour)
+	task := &Task{Status: TaskStatusPending, DueDate: now.AddDate(0, 0, -3), SnoozedUntil: &until}
+	task.CheckOverdue(now)
+	if task.Status != TaskStatusPending {
+		t.Errorf("Status = %v, want %v", task.Status, TaskStatusPending)
+	}
+}
+
+func TestTask_Skip(t *testing.T) {
+	task := &Task{Status: TaskStatusOverdue, DueDate: time.Now().Add(-24 * time.Hour)}
+	if err := task.Skip(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	if next.Name != task.Name {
-		t.Errorf("next Name = %v, want %v", next.Name, task.Name)
+	if task.Status != TaskStatusSkipped {
+		t.Errorf("Status = %v, want %v", task.Status, TaskStatusSkipped)
 	}
-	if next.UserID != userID {
-		t.Errorf("next UserID = %v, want %v", next.UserID, userID)
+	if task.SkippedAt == nil {
+		t.Error("SkippedAt should be set")
 	}
-	if next.Status != TaskStatusPending {
-		t.Errorf("next Status = %v, want %v", next.Status, TaskStatusPending)
+	if task.IsOpen() {
+		t.Error("skipped task should not be open")
 	}
-	expectedDue := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
-	if !next.DueDate.Equal(expectedDue) {
-		t.Errorf("next DueDate = %v, want %v", next.DueDate, expectedDue)
+	if err := task.Skip(); err == nil {
+		t.Error("expected error skipping an already skipped task")
 	}
 }
 
-func TestTask_Complete_WeeklyAndMonthly(t *testing.T) {
-	userID := uuid.New()
-	dueDate := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+func TestTask_Snooze(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		status    TaskStatus
+		dueDate   time.Time
+		snoozed   *time.Time
+		days      int
+		wantUntil time.Time
+		wantErr   bool
+	}{
+		{name: "future task moves from due date", status: TaskStatusPending, dueDate: day(12), days: 2, wantUntil: day(14)},
+		{name: "overdue task moves from today", status: TaskStatusOverdue, dueDate: day(5), days: 1, wantUntil: day(11)},
+		{name: "due today", status: TaskStatusPending, dueDate: day(10), days: 3, wantUntil: day(13)},
+		{name: "snoozing again stacks", status: TaskStatusPending, dueDate: day(5), snoozed: timePtr(day(12)), days: 2, wantUntil: day(14)},
+		{name: "zero days", status: TaskStatusPending, dueDate: day(12), days: 0, wantErr: true},
+		{name: "too many days", status: TaskStatusPending, dueDate: day(12), days: MaxSnoozeDays + 1, wantErr: true},
+		{name: "completed task", status: TaskStatusCompleted, dueDate: day(12), days: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Status: tt.status, DueDate: tt.dueDate, SnoozedUntil: tt.snoozed}
+			if tt.status == TaskStatusOverdue {
+				task.OverdueAt = timePtr(tt.dueDate.AddDate(0, 0, 1))
+			}
+			err := task.Snooze(tt.days, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !task.EffectiveDueDate().Equal(tt.wantUntil) {
+				t.Errorf("EffectiveDueDate = %v, want %v", task.EffectiveDueDate(), tt.wantUntil)
+			}
+			if !task.DueDate.Equal(tt.dueDate) {
+				t.Errorf("DueDate changed to %v; snooze should keep the schedule", task.DueDate)
+			}
+			if task.Status != TaskStatusPending || task.OverdueAt != nil {
+				t.Errorf("Status = %v, OverdueAt = %v; want pending with no OverdueAt", task.Status, task.OverdueAt)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestTask_CheckItem(t *testing.T) {
+	task := &Task{Status: TaskStatusPending, Checklist: ParseChecklist("Remove cover\n? Scrub tile line")}
+
+	if err := task.CheckItem(task.Checklist[0].ID, true); err != nil {
+		t.Fatalf("CheckItem: %v", err)
+	}
+	if checked, total := task.ChecklistProgress(); checked != 1 || total != 2 {
+		t.Errorf("ChecklistProgress = %d/%d, want 1/2", checked, total)
+	}
+	if err := task.CheckItem(task.Checklist[0].ID, false); err != nil {
+		t.Fatalf("CheckItem: %v", err)
+	}
+	if task.Checklist[0].CheckedAt != nil {
+		t.Error("expected item to be unchecked")
+	}
+	if err := task.CheckItem(uuid.New(), true); err == nil {
+		t.Error("expected error for unknown item")
+	}
+
+	task.Status = TaskStatusCompleted
+	if err := task.CheckItem(task.Checklist[0].ID, true); err == nil {
+		t.Error("expected error checking items on a closed task")
+	}
+}
+
+func TestTask_RequiredItemsRemaining(t *testing.T) {
+	task := &Task{Status: TaskStatusPending, Checklist: ParseChecklist("Remove cover\n? Scrub tile line\nStart pump")}
+
+	if got := task.RequiredItemsRemaining(); got != 2 {
+		t.Errorf("RequiredItemsRemaining = %d, want 2", got)
+	}
+	_ = task.CheckItem(task.Checklist[0].ID, true)
+	_ = task.CheckItem(task.Checklist[2].ID, true)
+	if got := task.RequiredItemsRemaining(); got != 0 {
+		t.Errorf("RequiredItemsRemaining = %d, want 0 with only optional steps left", got)
+	}
+	if got := (&Task{}).RequiredItemsRemaining(); got != 0 {
+		t.Errorf("RequiredItemsRemaining without checklist = %d, want 0", got)
+	}
+}
+
+func TestTask_SetChecklist_KeepsChecked(t *testing.T) {
+	task := &Task{Status: TaskStatusPending, Checklist: ParseChecklist("Remove cover\nStart pump")}
+	_ = task.CheckItem(task.Checklist[0].ID, true)
+
+	task.SetChecklist(ParseChecklist("Remove cover\nTop up water\nStart pump"))
+
+	if len(task.Checklist) != 3 {
+		t.Fatalf("got %d items, want 3", len(task.Checklist))
+	}
+	if task.Checklist[0].CheckedAt == nil {
+		t.Error("existing checked step should stay checked")
+	}
+	if task.Checklist[1].CheckedAt != nil || task.Checklist[2].CheckedAt != nil {
+		t.Error("other steps should be unchecked")
+	}
+}
+
+func TestTask_UpcomingDueDates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	weekly := valueobjects.Recurrence{Frequency: valueobjects.FrequencyWeekly, Interval: 1}
 
 	tests := []struct {
 		name    string
-		freq    valueobjects.Frequency
-		intv    int
-		wantDue time.Time
+		rec     valueobjects.Recurrence
+		dueDate time.Time
+		snoozed *time.Time
+		until   time.Time
+		want    []time.Time
 	}{
-		{"weekly", valueobjects.FrequencyWeekly, 1, time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)},
-		{"biweekly", valueobjects.FrequencyWeekly, 2, time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)},
-		{"monthly", valueobjects.FrequencyMonthly, 1, time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "expands within horizon", rec: weekly, dueDate: day(1), until: day(20), want: []time.Time{day(1), day(8), day(15)}},
+		{name: "horizon is inclusive", rec: weekly, dueDate: day(1), until: day(15), want: []time.Time{day(1), day(8), day(15)}},
+		{name: "due after horizon", rec: weekly, dueDate: day(25), until: day(20), want: nil},
+		{name: "snoozed keeps later schedule", rec: weekly, dueDate: day(1), snoozed: timePtr(day(3)), until: day(10), want: []time.Time{day(3), day(8)}},
+		{name: "snoozed past next occurrence", rec: weekly, dueDate: day(1), snoozed: timePtr(day(10)), until: day(16), want: []time.Time{day(10), day(15)}},
+		{name: "invalid recurrence stops", rec: valueobjects.Recurrence{}, dueDate: day(1), until: day(20), want: []time.Time{day(1)}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rec, _ := valueobjects.NewRecurrence(tt.freq, tt.intv)
-			task := &Task{
-				UserID:     userID,
-				Name:       "Task",
-				Recurrence: rec,
-				DueDate:    dueDate,
-				Status:     TaskStatusPending,
+			task := &Task{Recurrence: tt.rec, DueDate: tt.dueDate, SnoozedUntil: tt.snoozed}
+			got := task.UpcomingDueDates(tt.until)
+			if len(got) != len(tt.want) {
+				t.Fatalf("UpcomingDueDates() = %v, want %v", got, tt.want)
 			}
-			next := task.Complete()
-			if !next.DueDate.Equal(tt.wantDue) {
-				t.Errorf("next DueDate = %v, want %v", next.DueDate, tt.wantDue)
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("date %d = %v, want %v", i, got[i], tt.want[i])
+				}
 			}
 		})
 	}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"net/mail"
 	"strings"
@@ -17,27 +19,124 @@ type User struct {
 	IsDisabled    bool
 	IsDemo        bool
 	DemoExpiresAt *time.Time
-	Phone         string
-	NotifyEmail   bool
-	NotifySMS     bool
-	PoolGallons   int
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Phone is in E.164 form. See SetPhone.
+	Phone string
+	// PhoneVerifiedAt is when the user confirmed Phone with a code texted
+	// to it. SMS is only sent to verified numbers.
+	PhoneVerifiedAt *time.Time
+	// PhoneCode is the verification code last sent to Phone, while it is
+	// unverified.
+	PhoneCode *PhoneCode
+	// SMSOptedOut is set when the user replies STOP to a text message. No
+	// SMS is sent to them until they reply START.
+	SMSOptedOut bool
+	// Push is the ntfy topic or Gotify server push notifications are
+	// posted to, if the user has set one up.
+	Push        PushTarget
+	PoolGallons int
+	// Timezone is an IANA zone name such as "America/Los_Angeles". Due
+	// dates, reminders and streaks follow the user's local day.
+	Timezone string
+	// CalendarToken is the secret in the user's calendar feed URL. Empty
+	// until the feed is first enabled.
+	CalendarToken string
+	// NoTestAlertDays is how long without a chemistry test before a
+	// CategoryNoTest alert is sent.
+	NoTestAlertDays int
+	// Digest is when the weekly digest is sent, if the user opts into
+	// CategoryDigest.
+	Digest DigestSchedule
+	// QuietHours holds back SMS overnight, or whenever the user chooses.
+	QuietHours QuietHours
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
+// DefaultNoTestAlertDays is how long without a test before users are
+// alerted, unless they choose otherwise.
+const DefaultNoTestAlertDays = 7
+
 func NewUser(email, passwordHash string) *User {
 	now := time.Now()
 	return &User{
-		ID:           uuid.Must(uuid.NewV7()),
-		Email:        strings.ToLower(strings.TrimSpace(email)),
-		PasswordHash: passwordHash,
-		IsAdmin:      false,
-		IsDisabled:   false,
-		NotifyEmail:  true,
-		NotifySMS:    false,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:              uuid.Must(uuid.NewV7()),
+		Email:           strings.ToLower(strings.TrimSpace(email)),
+		PasswordHash:    passwordHash,
+		IsAdmin:         false,
+		IsDisabled:      false,
+		Timezone:        "UTC",
+		NoTestAlertDays: DefaultNoTestAlertDays,
+		Digest:          DigestSchedule{Weekday: DefaultDigestWeekday, Hour: DefaultDigestHour},
+		QuietHours:      QuietHours{Start: DefaultQuietStart, End: DefaultQuietEnd},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// Address returns where the user receives notifications on channel: their
+// email address, phone number or push target's Address. It is empty if
+// they haven't given one, haven't verified their phone number, or have
+// opted out of text messages.
+func (u *User) Address(channel string) string {
+	switch channel {
+	case ChannelEmail:
+		return u.Email
+	case ChannelSMS:
+		if !u.PhoneVerified() || u.SMSOptedOut {
+			return ""
+		}
+		return u.Phone
+	case ChannelPush:
+		return u.Push.Address()
+	default:
+		return ""
+	}
+}
+
+// Location returns the user's timezone, or UTC if it is unset or unknown.
+func (u *User) Location() *time.Location {
+	return LoadTimezone(u.Timezone)
+}
+
+// SetTimezone changes the user's timezone to the named IANA zone.
+func (u *User) SetTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("timezone is required")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unknown timezone %q", name)
+	}
+	u.Timezone = name
+	return nil
+}
+
+// LoadTimezone returns the named IANA zone, or UTC if it is empty or
+// unknown, so a bad stored value never stops reminders going out.
+func LoadTimezone(name string) *time.Location {
+	if name == "" || name == "Local" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// RegenerateCalendarToken replaces the calendar feed token, so any
+// previously shared feed URL stops working.
+func (u *User) RegenerateCalendarToken() {
+	u.CalendarToken = newSecretToken()
+	u.UpdatedAt = time.Now()
+}
+
+// newSecretToken returns a random URL-safe token with 256 bits of entropy.
+func newSecretToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("reading random bytes: %v", err))
 	}
+	return base64.RawURLEncoding.EncodeToString(b)
 }
 
 func (u *User) IsDemoExpired() bool {
@@ -1,6 +1,9 @@
 package entities
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestUser_Validate(t *testing.T) {
 	tests := []struct {
@@ -46,3 +49,52 @@ func TestUser_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_RegenerateCalendarToken(t *testing.T) {
+	u := &User{}
+	u.RegenerateCalendarToken()
+	first := u.CalendarToken
+	if len(first) < 40 {
+		t.Fatalf("token %q is too short", first)
+	}
+	u.RegenerateCalendarToken()
+	if u.CalendarToken == first {
+		t.Error("regenerating should change the token")
+	}
+}
+
+func TestUser_SetTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		wantErr bool
+	}{
+		{"iana zone", "Australia/Sydney", false},
+		{"utc", "UTC", false},
+		{"empty", "", true},
+		{"local", "Local", true},
+		{"unknown", "Mars/Olympus_Mons", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &User{Timezone: "UTC"}
+			err := u.SetTimezone(tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetTimezone(%q) error = %v, wantErr %v", tt.zone, err, tt.wantErr)
+			}
+			if tt.wantErr && u.Timezone != "UTC" {
+				t.Errorf("Timezone changed to %q on error", u.Timezone)
+			}
+			if !tt.wantErr && u.Location().String() != tt.zone {
+				t.Errorf("Location() = %v, want %v", u.Location(), tt.zone)
+			}
+		})
+	}
+}
+
+func TestUser_Location_FallsBackToUTC(t *testing.T) {
+	u := &User{Timezone: "Not/AZone"}
+	if u.Location() != time.UTC {
+		t.Errorf("Location() = %v, want UTC", u.Location())
+	}
+}
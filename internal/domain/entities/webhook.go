@@ -0,0 +1,221 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEvent is an event a webhook can subscribe to.
+type WebhookEvent string
+
+const (
+	EventChemistryLogCreated WebhookEvent = "chemistry_log.created"
+	EventTaskDue             WebhookEvent = "task.due"
+	EventTaskCompleted       WebhookEvent = "task.completed"
+	EventLowStock            WebhookEvent = "chemical.low_stock"
+	EventMilestoneEarned     WebhookEvent = "milestone.earned"
+)
+
+// WebhookEvents lists the events in display order.
+var WebhookEvents = []WebhookEvent{
+	EventChemistryLogCreated, EventTaskDue, EventTaskCompleted, EventLowStock, EventMilestoneEarned,
+}
+
+func (e WebhookEvent) Valid() bool {
+	for _, ev := range WebhookEvents {
+		if e == ev {
+			return true
+		}
+	}
+	return false
+}
+
+func (e WebhookEvent) Label() string {
+	switch e {
+	case EventChemistryLogCreated:
+		return "Chemistry log created"
+	case EventTaskDue:
+		return "Task due"
+	case EventTaskCompleted:
+		return "Task completed"
+	case EventLowStock:
+		return "Low stock"
+	case EventMilestoneEarned:
+		return "Milestone earned"
+	default:
+		return string(e)
+	}
+}
+
+// Webhook is a user's endpoint for receiving events. Each delivery is
+// signed with Secret so the receiver can check it came from PoolVibes.
+type Webhook struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	URL       string
+	Secret    string
+	Events    []WebhookEvent
+	CreatedAt time.Time
+}
+
+func NewWebhook(userID uuid.UUID, url string, events []WebhookEvent) *Webhook {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("generating webhook secret: %v", err))
+	}
+	return &Webhook{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    userID,
+		URL:       url,
+		Secret:    "whsec_" + hex.EncodeToString(b),
+		Events:    events,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("URL must be an absolute http or https URL")
+	}
+	if len(w.Events) == 0 {
+		return fmt.Errorf("choose at least one event")
+	}
+	for _, e := range w.Events {
+		if !e.Valid() {
+			return fmt.Errorf("unknown event %q", e)
+		}
+	}
+	return nil
+}
+
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// MaxWebhookAttempts is how many times a delivery is tried before it is
+// marked failed.
+const MaxWebhookAttempts = 6
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook. Failed
+// attempts are retried with exponential backoff until MaxWebhookAttempts.
+type WebhookDelivery struct {
+	ID            uuid.UUID
+	WebhookID     uuid.UUID
+	UserID        uuid.UUID
+	Event         WebhookEvent
+	Payload       []byte // JSON body
+	Status        string
+	Attempts      int
+	ResponseCode  int // HTTP status of the last attempt, 0 if it got no response
+	LastError     string
+	NextAttemptAt *time.Time // nil once succeeded or failed
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func NewWebhookDelivery(hook *Webhook, event WebhookEvent, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.Must(uuid.NewV7()),
+		WebhookID:     hook.ID,
+		UserID:        hook.UserID,
+		Event:         event,
+		Payload:       payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// RecordSuccess marks the delivery as delivered.
+func (d *WebhookDelivery) RecordSuccess(code int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.ResponseCode = code
+	d.LastError = ""
+	d.NextAttemptAt = nil
+	d.UpdatedAt = now
+}
+
+// RecordFailure records a failed attempt and schedules the next one, or
+// marks the delivery failed once it has run out of attempts.
+func (d *WebhookDelivery) RecordFailure(code int, errMsg string, now time.Time) {
+	d.Attempts++
+	d.ResponseCode = code
+	d.LastError = errMsg
+	d.UpdatedAt = now
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = DeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(WebhookBackoff(d.Attempts))
+	d.NextAttemptAt = &next
+}
+
+// Abandon marks the delivery failed without another attempt, e.g. because
+// its webhook was deleted.
+func (d *WebhookDelivery) Abandon(errMsg string, now time.Time) {
+	d.Status = DeliveryFailed
+	d.LastError = errMsg
+	d.NextAttemptAt = nil
+	d.UpdatedAt = now
+}
+
+// Replay returns a new pending delivery of the same event and payload.
+func (d *WebhookDelivery) Replay() *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.Must(uuid.NewV7()),
+		WebhookID:     d.WebhookID,
+		UserID:        d.UserID,
+		Event:         d.Event,
+		Payload:       d.Payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// WebhookBackoff is the wait after the given number of failed attempts:
+// 30s, 1m, 2m, 4m, and so on.
+func WebhookBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return 30 * time.Second << (attempts - 1)
+}
+
+// SignWebhook returns the signature header value for a payload sent at ts:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<payload>" keyed by secret>".
+// Including the timestamp lets receivers reject old, replayed requests.
+func SignWebhook(secret string, ts time.Time, payload []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(payload)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
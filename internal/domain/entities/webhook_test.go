@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		events  []WebhookEvent
+		wantErr bool
+	}{
+		{"valid", "https://example.com/hook", []WebhookEvent{EventTaskDue}, false},
+		{"http", "http://localhost:9000/hook", []WebhookEvent{EventTaskDue}, false},
+		{"relative URL", "/hook", []WebhookEvent{EventTaskDue}, true},
+		{"other scheme", "ftp://example.com/hook", []WebhookEvent{EventTaskDue}, true},
+		{"no events", "https://example.com/hook", nil, true},
+		{"unknown event", "https://example.com/hook", []WebhookEvent{"task.deleted"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewWebhook(uuid.New(), tt.url, tt.events).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWebhook_Secret(t *testing.T) {
+	a := NewWebhook(uuid.New(), "https://example.com", []WebhookEvent{EventTaskDue})
+	b := NewWebhook(uuid.New(), "https://example.com", []WebhookEvent{EventTaskDue})
+	if !strings.HasPrefix(a.Secret, "whsec_") || a.Secret == b.Secret {
+		t.Errorf("secrets %q and %q, want distinct whsec_ values", a.Secret, b.Secret)
+	}
+}
+
+func TestWebhookDelivery_RecordFailure(t *testing.T) {
+	hook := NewWebhook(uuid.New(), "https://example.com", []WebhookEvent{EventTaskDue})
+	d := NewWebhookDelivery(hook, EventTaskDue, []byte(`{}`))
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	d.RecordFailure(500, "server error", now)
+	if d.Status != DeliveryPending || !d.NextAttemptAt.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("after 1 failure: status %s, next %v", d.Status, d.NextAttemptAt)
+	}
+	d.RecordFailure(500, "server error", now)
+	if !d.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("after 2 failures: next %v, want +1m", d.NextAttemptAt)
+	}
+	for d.Attempts < MaxWebhookAttempts {
+		d.RecordFailure(0, "connection refused", now)
+	}
+	if d.Status != DeliveryFailed || d.NextAttemptAt != nil {
+		t.Errorf("after %d failures: status %s, next %v, want failed", d.Attempts, d.Status, d.NextAttemptAt)
+	}
+	if d.LastError != "connection refused" {
+		t.Errorf("LastError = %q", d.LastError)
+	}
+
+	r := d.Replay()
+	if r.ID == d.ID || r.Status != DeliveryPending || r.Attempts != 0 || string(r.Payload) != "{}" {
+		t.Errorf("Replay() = %+v, want a fresh pending delivery of the same payload", r)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	ts := time.Unix(1741600000, 0)
+	payload := []byte(`{"event":"task.due"}`)
+	got := SignWebhook("whsec_test", ts, payload)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1741600000." + string(payload)))
+	want := "t=1741600000,v1=" + hex.EncodeToString(mac.Sum(nil))
+	if got != want {
+		t.Errorf("SignWebhook() = %q, want %q", got, want)
+	}
+}
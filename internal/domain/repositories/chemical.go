@@ -10,7 +10,12 @@ import (
 type ChemicalRepository interface {
 	FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Chemical, error)
 	FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Chemical, error)
+	// Create inserts the chemical along with the adjustment that opens its
+	// stock ledger.
 	Create(ctx context.Context, chemical *entities.Chemical) error
 	Update(ctx context.Context, chemical *entities.Chemical) error
+	// UpdateUsage saves only the chemical's daily usage, so a stock change
+	// recorded meanwhile isn't overwritten.
+	UpdateUsage(ctx context.Context, chemical *entities.Chemical) error
 	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
 }
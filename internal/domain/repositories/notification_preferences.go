@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type NotificationPreferenceRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error)
+	// FindByTimezone returns the preferences of every enabled user in the
+	// timezone who has opted into anything.
+	FindByTimezone(ctx context.Context, timezone string) ([]*entities.NotificationPreferences, error)
+	// Save replaces all of the user's preferences.
+	Save(ctx context.Context, prefs *entities.NotificationPreferences) error
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type OutboxRepository interface {
+	// Enqueue claims notif and queues msg in one transaction. Returns false,
+	// queueing nothing, if the notification was already claimed (see
+	// TaskNotificationRepository.Claim).
+	Enqueue(ctx context.Context, notif *entities.TaskNotification, msg *entities.OutboxMessage) (bool, error)
+	// ClaimDue leases up to limit pending messages whose next attempt is at
+	// or before now, moving their next attempt to leaseUntil so no other
+	// worker picks them up meanwhile. If the worker dies mid-send, they are
+	// retried once the lease runs out.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error)
+	Update(ctx context.Context, msg *entities.OutboxMessage) error
+	// FindRecent returns the user's latest messages, newest first.
+	FindRecent(ctx context.Context, userID uuid.UUID, limit int) ([]entities.OutboxMessage, error)
+	// FindRecentErrors returns the messages whose last attempt failed, most
+	// recently attempted first, across all users.
+	FindRecentErrors(ctx context.Context, limit int) ([]entities.OutboxMessage, error)
+	// Stats counts the messages queued since the given time by channel and
+	// outcome. Channels with no messages are left out.
+	Stats(ctx context.Context, since time.Time) ([]OutboxStats, error)
+}
+
+// OutboxStats counts one channel's messages by outcome.
+type OutboxStats struct {
+	Channel  string
+	Sent     int
+	Pending  int // waiting for a first attempt
+	Retrying int // pending after at least one failed attempt
+	Dead     int
+}
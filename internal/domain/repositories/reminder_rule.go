@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type ReminderRuleRepository interface {
+	FindAll(ctx context.Context, userID uuid.UUID) ([]entities.ReminderRule, error)
+	// FindByTimezone returns the saved rules of every enabled user in the
+	// timezone, for the notification scheduler.
+	FindByTimezone(ctx context.Context, timezone string) ([]entities.ReminderRule, error)
+	Create(ctx context.Context, rule *entities.ReminderRule) error
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
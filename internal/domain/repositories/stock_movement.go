@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type StockMovementRepository interface {
+	// FindByChemicalID returns the chemical's stock ledger, oldest first.
+	FindByChemicalID(ctx context.Context, userID uuid.UUID, chemicalID uuid.UUID) ([]entities.StockMovement, error)
+	// Record inserts the movements and saves the chemical atomically, with
+	// its stock reconciled to the ledger's new balance.
+	Record(ctx context.Context, chemical *entities.Chemical, movements ...entities.StockMovement) error
+}
@@ -9,10 +9,22 @@ import (
 )
 
 type TaskRepository interface {
+	// FindAll, FindByID and FindBySeriesID populate each task's checklist.
 	FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Task, error)
 	FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Task, error)
-	FindDueOnDate(ctx context.Context, date time.Time) ([]entities.Task, error)
+	FindBySeriesID(ctx context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.Task, error)
+	// FindDueOnDate and MarkOverdue work across all users in one timezone,
+	// so that date is each user's local calendar date. FindDueOnDate
+	// returns open (pending or overdue) tasks due on date.
+	FindDueOnDate(ctx context.Context, timezone string, date time.Time) ([]entities.Task, error)
+	// MarkOverdue moves every pending task due before dueBefore to overdue
+	// in a single statement, so concurrent runs on several instances mark
+	// each task once. It returns how many tasks were marked.
+	MarkOverdue(ctx context.Context, timezone string, dueBefore, now time.Time) (int, error)
+	// Create inserts the task and its checklist atomically.
 	Create(ctx context.Context, task *entities.Task) error
+	// Update saves the task's own fields; use SaveChecklist for its checklist.
 	Update(ctx context.Context, task *entities.Task) error
+	SaveChecklist(ctx context.Context, task *entities.Task) error
 	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type TaskCompletionRepository interface {
+	// FindBySeriesID returns completions for every occurrence of a series,
+	// newest first. Photos are populated without their image data.
+	FindBySeriesID(ctx context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.TaskCompletion, error)
+	// FindPhoto returns a single photo including its image data.
+	FindPhoto(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskPhoto, error)
+	// Create inserts the completion and its photos atomically.
+	Create(ctx context.Context, completion *entities.TaskCompletion) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -9,8 +9,11 @@ import (
 
 type TaskNotificationRepository interface {
 	// Claim atomically inserts a notification record. Returns true if this
-	// caller claimed it, false if another instance already did (UNIQUE conflict).
+	// caller claimed it, false if another instance already did (UNIQUE
+	// conflict on user, channel, reminder rule and due date).
 	Claim(ctx context.Context, notif *entities.TaskNotification) (bool, error)
-	// Delete removes a notification record (used to release a claim on send failure).
-	Delete(ctx context.Context, id uuid.UUID) error
+	// FindLastSentReminder returns the claim of the reminder most recently
+	// sent to the user on channel, or nil if none has been sent. Reminders
+	// still waiting in the outbox don't count.
+	FindLastSentReminder(ctx context.Context, userID uuid.UUID, channel string) (*entities.TaskNotification, error)
 }
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// TaskSeriesRepository stores series along with their checklist, which
+// Create and Update replace as a whole.
+type TaskSeriesRepository interface {
+	FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskSeries, error)
+	Create(ctx context.Context, series *entities.TaskSeries) error
+	Update(ctx context.Context, series *entities.TaskSeries) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
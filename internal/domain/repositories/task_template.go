@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type TaskTemplateRepository interface {
+	// FindAvailable returns site-wide templates and the user's own, with items.
+	FindAvailable(ctx context.Context, userID uuid.UUID) ([]entities.TaskTemplate, error)
+	// FindSiteWide returns templates offered to every user, with items.
+	FindSiteWide(ctx context.Context) ([]entities.TaskTemplate, error)
+	// FindByID returns a stored template of any owner, with items.
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.TaskTemplate, error)
+	// Create inserts the template and its items atomically.
+	Create(ctx context.Context, template *entities.TaskTemplate) error
+	Update(ctx context.Context, template *entities.TaskTemplate) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -12,10 +12,19 @@ type UserRepository interface {
 	FindAll(ctx context.Context) ([]entities.User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
+	FindByCalendarToken(ctx context.Context, token string) (*entities.User, error)
 	Create(ctx context.Context, user *entities.User) error
 	Update(ctx context.Context, user *entities.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindExpiredDemo(ctx context.Context, now time.Time) ([]entities.User, error)
 	CountDemo(ctx context.Context) (int, error)
 	CountAdmins(ctx context.Context) (int, error)
+	// FindTimezones returns the distinct timezones of enabled users, so
+	// scheduled jobs can work out each user's local day.
+	FindTimezones(ctx context.Context) ([]string, error)
+	// FindByTimezone returns the enabled users in one timezone.
+	FindByTimezone(ctx context.Context, timezone string) ([]entities.User, error)
+	// FindByPhone returns the enabled users with a phone number, so
+	// replies to text messages can be matched to them.
+	FindByPhone(ctx context.Context, phone string) ([]entities.User, error)
 }
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type WebhookRepository interface {
+	FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Webhook, error)
+	FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Webhook, error)
+	// FindByTimezone returns the webhooks of enabled users in the timezone.
+	FindByTimezone(ctx context.Context, timezone string) ([]entities.Webhook, error)
+	Create(ctx context.Context, hook *entities.Webhook) error
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// FindRecent returns the user's latest deliveries, newest first.
+	FindRecent(ctx context.Context, userID uuid.UUID, limit int) ([]entities.WebhookDelivery, error)
+	FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.WebhookDelivery, error)
+	// ClaimDue leases up to limit pending deliveries whose next attempt is
+	// at or before now, moving their next attempt to leaseUntil so no other
+	// worker picks them up meanwhile (see OutboxRepository.ClaimDue).
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error)
+	Create(ctx context.Context, delivery *entities.WebhookDelivery) error
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
@@ -0,0 +1,40 @@
+package valueobjects
+
+// Message is the content of a notification. HTML and Headers are optional
+// and only sent by email providers.
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+	// Headers are extra email headers, e.g. List-Unsubscribe.
+	Headers map[string]string
+	// Push styles push notifications.
+	Push PushOptions
+	// PushToken is the user's access token for their ntfy topic or Gotify
+	// server. It is looked up when the message is sent and never queued.
+	PushToken string
+	// Metadata identifies the message to providers that can tag it, so
+	// their logs and bounce reports can be matched to the outbox.
+	Metadata map[string]string
+	// URL is the page in the app the message is about. Push notifications
+	// open it when tapped.
+	URL string
+}
+
+// PushOptions style a push notification. They are named after ntfy's;
+// push notifiers map them onto their service's API. They hold no
+// credentials: the user's token is looked up when the message is sent.
+type PushOptions struct {
+	Priority int // 1 (min) to 5 (max); 0 means PushPriorityDefault
+	// Tags is a comma-separated list of tags. ntfy shows tags that are
+	// emoji shortcodes, e.g. "warning", as emoji.
+	Tags  string
+	Click string // the URL opened when the notification is tapped
+}
+
+// Push priorities, for PushOptions.
+const (
+	PushPriorityLow     = 2
+	PushPriorityDefault = 3
+	PushPriorityHigh    = 4
+)
@@ -13,9 +13,22 @@ const (
 	FrequencyMonthly Frequency = "monthly"
 )
 
+// Anchor decides what the next due date is counted from.
+type Anchor string
+
+const (
+	// AnchorDueDate keeps a fixed schedule: the next occurrence is counted
+	// from the previous due date, however late it was done.
+	AnchorDueDate Anchor = "due_date"
+	// AnchorCompletionDate suits interval-based maintenance: the next
+	// occurrence is counted from the day the previous one was done.
+	AnchorCompletionDate Anchor = "completion_date"
+)
+
 type Recurrence struct {
 	Frequency Frequency
 	Interval  int
+	Anchor    Anchor
 }
 
 func NewRecurrence(frequency Frequency, interval int) (Recurrence, error) {
@@ -27,7 +40,21 @@ func NewRecurrence(frequency Frequency, interval int) (Recurrence, error) {
 	default:
 		return Recurrence{}, fmt.Errorf("invalid frequency: %s", frequency)
 	}
-	return Recurrence{Frequency: frequency, Interval: interval}, nil
+	return Recurrence{Frequency: frequency, Interval: interval, Anchor: AnchorDueDate}, nil
+}
+
+// WithAnchor returns a copy of the recurrence using the given anchor. An
+// empty anchor means AnchorDueDate.
+func (r Recurrence) WithAnchor(anchor Anchor) (Recurrence, error) {
+	switch anchor {
+	case "":
+		anchor = AnchorDueDate
+	case AnchorDueDate, AnchorCompletionDate:
+	default:
+		return Recurrence{}, fmt.Errorf("invalid anchor: %s", anchor)
+	}
+	r.Anchor = anchor
+	return r, nil
 }
 
 func (r Recurrence) NextDueDate(from time.Time) time.Time {
@@ -42,3 +69,16 @@ func (r Recurrence) NextDueDate(from time.Time) time.Time {
 		return from
 	}
 }
+
+// NextDueDateAfter returns the due date following an occurrence that was
+// due on due and closed at closedAt, honouring the anchor. With
+// AnchorCompletionDate the calendar day of closedAt in loc, the user's
+// timezone, replaces the due date, keeping due's time of day.
+func (r Recurrence) NextDueDateAfter(due, closedAt time.Time, loc *time.Location) time.Time {
+	if r.Anchor != AnchorCompletionDate {
+		return r.NextDueDate(due)
+	}
+	c := closedAt.In(loc)
+	from := time.Date(c.Year(), c.Month(), c.Day(), due.Hour(), due.Minute(), due.Second(), due.Nanosecond(), due.Location())
+	return r.NextDueDate(from)
+}
@@ -67,3 +67,100 @@ func TestRecurrence_NextDueDate(t *testing.T) {
 		})
 	}
 }
+
+func TestRecurrence_WithAnchor(t *testing.T) {
+	base, _ := NewRecurrence(FrequencyWeekly, 2)
+	if base.Anchor != AnchorDueDate {
+		t.Errorf("default Anchor = %q, want %q", base.Anchor, AnchorDueDate)
+	}
+
+	tests := []struct {
+		name    string
+		anchor  Anchor
+		want    Anchor
+		wantErr bool
+	}{
+		{"empty defaults to due date", "", AnchorDueDate, false},
+		{"due date", AnchorDueDate, AnchorDueDate, false},
+		{"completion date", AnchorCompletionDate, AnchorCompletionDate, false},
+		{"invalid", Anchor("whenever"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := base.WithAnchor(tt.anchor)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.Anchor != tt.want {
+				t.Errorf("Anchor = %q, want %q", r.Anchor, tt.want)
+			}
+			if r.Frequency != base.Frequency || r.Interval != base.Interval {
+				t.Error("WithAnchor should keep frequency and interval")
+			}
+		})
+	}
+}
+
+func TestRecurrence_NextDueDateAfter(t *testing.T) {
+	due := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tenDaysLate := time.Date(2025, 3, 11, 17, 45, 0, 0, time.UTC)
+	early := time.Date(2025, 2, 27, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		freq     Frequency
+		interval int
+		anchor   Anchor
+		closedAt time.Time
+		want     time.Time
+	}{
+		{"due date anchor ignores lateness", FrequencyDaily, 14, AnchorDueDate, tenDaysLate, time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{"zero-value anchor behaves like due date", FrequencyDaily, 14, "", tenDaysLate, time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{"completion anchor counts from late completion", FrequencyDaily, 14, AnchorCompletionDate, tenDaysLate, time.Date(2025, 3, 25, 12, 0, 0, 0, time.UTC)},
+		{"completion anchor counts from early completion", FrequencyWeekly, 1, AnchorCompletionDate, early, time.Date(2025, 3, 6, 12, 0, 0, 0, time.UTC)},
+		{"completion anchor monthly", FrequencyMonthly, 1, AnchorCompletionDate, tenDaysLate, time.Date(2025, 4, 11, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Recurrence{Frequency: tt.freq, Interval: tt.interval, Anchor: tt.anchor}
+			got := r.NextDueDateAfter(due, tt.closedAt, time.UTC)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextDueDateAfter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrence_NextDueDateAfter_UsesUserLocation(t *testing.T) {
+	r := Recurrence{Frequency: FrequencyDaily, Interval: 1, Anchor: AnchorCompletionDate}
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	la := time.FixedZone("PST", -8*60*60)
+	sydney := time.FixedZone("AEDT", 11*60*60)
+
+	tests := []struct {
+		name     string
+		closedAt time.Time
+		loc      *time.Location
+		want     time.Time
+	}{
+		// 9pm on Mar 4 in Los Angeles is already Mar 5 in UTC.
+		{"Los Angeles evening", time.Date(2025, 3, 5, 5, 0, 0, 0, time.UTC), la, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)},
+		// 8am on Mar 5 in Sydney is still Mar 4 in UTC.
+		{"Sydney morning", time.Date(2025, 3, 4, 21, 0, 0, 0, time.UTC), sydney, time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"UTC", time.Date(2025, 3, 5, 5, 0, 0, 0, time.UTC), time.UTC, time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.NextDueDateAfter(due, tt.closedAt, tt.loc)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextDueDateAfter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
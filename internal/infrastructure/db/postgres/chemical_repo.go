@@ -22,7 +22,7 @@ func NewChemicalRepo(db *sql.DB) *ChemicalRepo {
 func (r *ChemicalRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Chemical, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, user_id, name, type,
-			stock_amount, stock_unit, alert_threshold,
+			stock_amount, stock_unit, alert_threshold, daily_usage,
 			last_purchased, created_at, updated_at
 		FROM chemicals
 		WHERE user_id = $1
@@ -46,7 +46,7 @@ func (r *ChemicalRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entitie
 func (r *ChemicalRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Chemical, error) {
 	row := r.db.QueryRowContext(ctx, `
 		SELECT id, user_id, name, type,
-			stock_amount, stock_unit, alert_threshold,
+			stock_amount, stock_unit, alert_threshold, daily_usage,
 			last_purchased, created_at, updated_at
 		FROM chemicals
 		WHERE id = $1 AND user_id = $2`, id, userID)
@@ -61,15 +61,30 @@ func (r *ChemicalRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.U
 }
 
 func (r *ChemicalRepo) Create(ctx context.Context, c *entities.Chemical) error {
-	_, err := r.db.ExecContext(ctx, `
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO chemicals (id, user_id, name, type,
-			stock_amount, stock_unit, alert_threshold,
+			stock_amount, stock_unit, alert_threshold, daily_usage,
 			last_purchased, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
-		c.ID, c.UserID, c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.LastPurchased, c.CreatedAt, c.UpdatedAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		c.ID, c.UserID, c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.DailyUsage, c.LastPurchased, c.CreatedAt, c.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("inserting chemical: %w", err)
 	}
+	if m := c.OpeningMovement(); m != nil {
+		if err := insertStockMovement(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing chemical: %w", err)
+	}
 	return nil
 }
 
@@ -79,16 +94,25 @@ func (r *ChemicalRepo) Update(ctx context.Context, c *entities.Chemical) error {
 		UPDATE chemicals
 		SET name = $1, type = $2,
 			stock_amount = $3, stock_unit = $4,
-			alert_threshold = $5, last_purchased = $6,
-			updated_at = $7
-		WHERE id = $8 AND user_id = $9`,
-		c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.LastPurchased, c.UpdatedAt, c.ID, c.UserID)
+			alert_threshold = $5, daily_usage = $6,
+			last_purchased = $7, updated_at = $8
+		WHERE id = $9 AND user_id = $10`,
+		c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.DailyUsage, c.LastPurchased, c.UpdatedAt, c.ID, c.UserID)
 	if err != nil {
 		return fmt.Errorf("updating chemical: %w", err)
 	}
 	return nil
 }
 
+func (r *ChemicalRepo) UpdateUsage(ctx context.Context, c *entities.Chemical) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE chemicals SET daily_usage = $1 WHERE id = $2 AND user_id = $3`,
+		c.DailyUsage, c.ID, c.UserID)
+	if err != nil {
+		return fmt.Errorf("updating chemical usage: %w", err)
+	}
+	return nil
+}
+
 func (r *ChemicalRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
 	_, err := r.db.ExecContext(ctx, `DELETE FROM chemicals WHERE id = $1 AND user_id = $2`, id, userID)
 	if err != nil {
@@ -100,7 +124,7 @@ func (r *ChemicalRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUI
 func scanChemicalFromRow(s scanner) (*entities.Chemical, error) {
 	var c entities.Chemical
 	var chemType, stockUnit string
-	if err := s.Scan(&c.ID, &c.UserID, &c.Name, &chemType, &c.Stock.Amount, &stockUnit, &c.AlertThreshold, &c.LastPurchased, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	if err := s.Scan(&c.ID, &c.UserID, &c.Name, &chemType, &c.Stock.Amount, &stockUnit, &c.AlertThreshold, &c.DailyUsage, &c.LastPurchased, &c.CreatedAt, &c.UpdatedAt); err != nil {
 		return nil, fmt.Errorf("scanning chemical: %w", err)
 	}
 	c.Type = entities.ChemicalType(chemType)
@@ -91,11 +91,26 @@ func (r *EquipmentRepo) Update(ctx context.Context, e *entities.Equipment) error
 	return nil
 }
 
+// Delete removes the equipment and unlinks any tasks that maintained it.
 func (r *EquipmentRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM equipment WHERE id = $1 AND user_id = $2`, id, userID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"tasks", "task_series"} {
+		if _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET equipment_id = NULL WHERE equipment_id = $1 AND user_id = $2`, id, userID); err != nil {
+			return fmt.Errorf("unlinking %s from equipment: %w", table, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM equipment WHERE id = $1 AND user_id = $2`, id, userID); err != nil {
 		return fmt.Errorf("deleting equipment: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing equipment delete: %w", err)
+	}
 	return nil
 }
 
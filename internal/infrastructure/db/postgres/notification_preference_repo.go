@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type NotificationPreferenceRepo struct {
+	db *sql.DB
+}
+
+func NewNotificationPreferenceRepo(db *sql.DB) *NotificationPreferenceRepo {
+	return &NotificationPreferenceRepo{db: db}
+}
+
+func (r *NotificationPreferenceRepo) FindByUserID(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	all, err := r.findWhere(ctx, "user_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return entities.NewNotificationPreferences(userID), nil
+	}
+	return all[0], nil
+}
+
+func (r *NotificationPreferenceRepo) FindByTimezone(ctx context.Context, timezone string) ([]*entities.NotificationPreferences, error) {
+	return r.findWhere(ctx, "user_id IN (SELECT id FROM users WHERE timezone = $1 AND NOT is_disabled)", timezone)
+}
+
+func (r *NotificationPreferenceRepo) findWhere(ctx context.Context, where string, args ...any) ([]*entities.NotificationPreferences, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id, category, channel
+		FROM notification_preferences
+		WHERE `+where+`
+		ORDER BY user_id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var all []*entities.NotificationPreferences
+	for rows.Next() {
+		var userID uuid.UUID
+		var category, channel string
+		if err := rows.Scan(&userID, &category, &channel); err != nil {
+			return nil, fmt.Errorf("scanning notification preference: %w", err)
+		}
+		if len(all) == 0 || all[len(all)-1].UserID != userID {
+			all = append(all, entities.NewNotificationPreferences(userID))
+		}
+		all[len(all)-1].Set(entities.NotificationCategory(category), channel, true)
+	}
+	return all, rows.Err()
+}
+
+func (r *NotificationPreferenceRepo) Save(ctx context.Context, prefs *entities.NotificationPreferences) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM notification_preferences WHERE user_id = $1`, prefs.UserID); err != nil {
+		return fmt.Errorf("clearing notification preferences: %w", err)
+	}
+	for _, category := range entities.NotificationCategories {
+		for _, channel := range entities.NotificationChannels {
+			if !prefs.IsEnabled(category, channel) {
+				continue
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO notification_preferences (user_id, category, channel)
+				VALUES ($1, $2, $3)`,
+				prefs.UserID, string(category), channel)
+			if err != nil {
+				return fmt.Errorf("inserting notification preference: %w", err)
+			}
+		}
+	}
+	return tx.Commit()
+}
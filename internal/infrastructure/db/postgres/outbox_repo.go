@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+type OutboxRepo struct {
+	db *sql.DB
+}
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+const outboxColumns = `id, notification_id, user_id, channel, kind, recipient, subject, body, html_body, headers,
+	push_priority, push_tags, push_click, status, attempts, last_error, next_attempt_at, created_at, updated_at, sent_at`
+
+func (r *OutboxRepo) Enqueue(ctx context.Context, notif *entities.TaskNotification, msg *entities.OutboxMessage) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var taskID *uuid.UUID
+	if notif.TaskID != uuid.Nil {
+		taskID = &notif.TaskID
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO task_notifications (id, task_id, user_id, type, kind, rule, task_ids, due_date, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, type, rule, due_date) DO NOTHING`,
+		notif.ID, taskID, notif.UserID,
+		notif.Type, notif.Kind, notif.Rule, joinUUIDs(notif.TaskIDs), notif.DueDate, notif.SentAt)
+	if err != nil {
+		return false, fmt.Errorf("claiming task notification: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO notification_outbox (`+outboxColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`,
+		msg.ID, msg.NotificationID, msg.UserID, msg.Channel, msg.Kind, msg.Recipient,
+		msg.Subject, msg.Body, msg.HTMLBody, joinHeaders(msg.Headers),
+		msg.Push.Priority, msg.Push.Tags, msg.Push.Click, msg.Status, msg.Attempts, msg.LastError,
+		msg.NextAttemptAt, msg.CreatedAt, msg.UpdatedAt, msg.SentAt)
+	if err != nil {
+		return false, fmt.Errorf("inserting outbox message: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return true, nil
+}
+
+func (r *OutboxRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE notification_outbox SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE status = $2 AND next_attempt_at <= $3
+			ORDER BY next_attempt_at ASC LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+outboxColumns,
+		leaseUntil, entities.OutboxPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming outbox messages: %w", err)
+	}
+	defer rows.Close()
+	return scanOutboxMessages(rows)
+}
+
+func (r *OutboxRepo) FindRecent(ctx context.Context, userID uuid.UUID, limit int) ([]entities.OutboxMessage, error) {
+	return r.findWhere(ctx, "user_id = $1 ORDER BY created_at DESC LIMIT $2", userID, limit)
+}
+
+func (r *OutboxRepo) FindRecentErrors(ctx context.Context, limit int) ([]entities.OutboxMessage, error) {
+	return r.findWhere(ctx, "last_error <> '' ORDER BY updated_at DESC LIMIT $1", limit)
+}
+
+func (r *OutboxRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+outboxColumns+` FROM notification_outbox WHERE `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying outbox messages: %w", err)
+	}
+	defer rows.Close()
+	return scanOutboxMessages(rows)
+}
+
+func (r *OutboxRepo) Stats(ctx context.Context, since time.Time) ([]repositories.OutboxStats, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT channel,
+			COUNT(*) FILTER (WHERE status = 'sent'),
+			COUNT(*) FILTER (WHERE status = 'pending' AND attempts = 0),
+			COUNT(*) FILTER (WHERE status = 'pending' AND attempts > 0),
+			COUNT(*) FILTER (WHERE status = 'dead')
+		FROM notification_outbox
+		WHERE created_at >= $1
+		GROUP BY channel
+		ORDER BY channel`,
+		since)
+	if err != nil {
+		return nil, fmt.Errorf("counting outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []repositories.OutboxStats
+	for rows.Next() {
+		var st repositories.OutboxStats
+		if err := rows.Scan(&st.Channel, &st.Sent, &st.Pending, &st.Retrying, &st.Dead); err != nil {
+			return nil, fmt.Errorf("scanning outbox stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
+
+func scanOutboxMessages(rows *sql.Rows) ([]entities.OutboxMessage, error) {
+	var msgs []entities.OutboxMessage
+	for rows.Next() {
+		m, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, *m)
+	}
+	return msgs, rows.Err()
+}
+
+func scanOutboxMessage(s scanner) (*entities.OutboxMessage, error) {
+	var m entities.OutboxMessage
+	var headers string
+	if err := s.Scan(&m.ID, &m.NotificationID, &m.UserID, &m.Channel, &m.Kind, &m.Recipient, &m.Subject, &m.Body, &m.HTMLBody, &headers,
+		&m.Push.Priority, &m.Push.Tags, &m.Push.Click, &m.Status, &m.Attempts, &m.LastError, &m.NextAttemptAt, &m.CreatedAt, &m.UpdatedAt, &m.SentAt); err != nil {
+		return nil, fmt.Errorf("scanning outbox message: %w", err)
+	}
+	m.Headers = splitHeaders(headers)
+	return &m, nil
+}
+
+func (r *OutboxRepo) Update(ctx context.Context, msg *entities.OutboxMessage) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE notification_outbox
+		SET recipient = $1, status = $2, attempts = $3, last_error = $4, next_attempt_at = $5, updated_at = $6, sent_at = $7
+		WHERE id = $8`,
+		msg.Recipient, msg.Status, msg.Attempts, msg.LastError, msg.NextAttemptAt, msg.UpdatedAt, msg.SentAt, msg.ID)
+	if err != nil {
+		return fmt.Errorf("updating outbox message: %w", err)
+	}
+	return nil
+}
+
+// joinHeaders formats email headers one "Name: value" per line, sorted by
+// name.
+func joinHeaders(headers map[string]string) string {
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		fmt.Fprintf(&b, "%s: %s\n", k, headers[k])
+	}
+	return b.String()
+}
+
+func splitHeaders(s string) map[string]string {
+	var headers map[string]string
+	for _, line := range strings.Split(s, "\n") {
+		k, v, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[k] = v
+	}
+	return headers
+}
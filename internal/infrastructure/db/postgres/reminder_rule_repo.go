@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type ReminderRuleRepo struct {
+	db *sql.DB
+}
+
+func NewReminderRuleRepo(db *sql.DB) *ReminderRuleRepo {
+	return &ReminderRuleRepo{db: db}
+}
+
+func (r *ReminderRuleRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.ReminderRule, error) {
+	return r.findWhere(ctx, "user_id = $1", userID)
+}
+
+func (r *ReminderRuleRepo) FindByTimezone(ctx context.Context, timezone string) ([]entities.ReminderRule, error) {
+	return r.findWhere(ctx, "user_id IN (SELECT id FROM users WHERE timezone = $1 AND NOT is_disabled)", timezone)
+}
+
+func (r *ReminderRuleRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.ReminderRule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, offset_days, send_hour, created_at
+		FROM reminder_rules
+		WHERE `+where+`
+		ORDER BY offset_days ASC, send_hour ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying reminder rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []entities.ReminderRule
+	for rows.Next() {
+		var rule entities.ReminderRule
+		if err := rows.Scan(&rule.ID, &rule.UserID, &rule.OffsetDays, &rule.SendHour, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning reminder rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *ReminderRuleRepo) Create(ctx context.Context, rule *entities.ReminderRule) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO reminder_rules (id, user_id, offset_days, send_hour, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		rule.ID, rule.UserID, rule.OffsetDays, rule.SendHour, rule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting reminder rule: %w", err)
+	}
+	return nil
+}
+
+func (r *ReminderRuleRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM reminder_rules WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("deleting reminder rule: %w", err)
+	}
+	return nil
+}
+
+func (r *ReminderRuleRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM reminder_rules WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("deleting reminder rules: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type StockMovementRepo struct {
+	db *sql.DB
+}
+
+func NewStockMovementRepo(db *sql.DB) *StockMovementRepo {
+	return &StockMovementRepo{db: db}
+}
+
+func (r *StockMovementRepo) FindByChemicalID(ctx context.Context, userID uuid.UUID, chemicalID uuid.UUID) ([]entities.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, chemical_id, kind, amount, unit, reason,
+			cost, chemistry_log_id, created_at
+		FROM stock_movements
+		WHERE chemical_id = $1 AND user_id = $2
+		ORDER BY created_at ASC, id ASC`, chemicalID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []entities.StockMovement
+	for rows.Next() {
+		var m entities.StockMovement
+		var kind, unit string
+		if err := rows.Scan(&m.ID, &m.UserID, &m.ChemicalID, &kind, &m.Amount, &unit, &m.Reason, &m.Cost, &m.ChemistryLogID, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning stock movement: %w", err)
+		}
+		m.Kind = entities.StockMovementKind(kind)
+		m.Unit = valueobjects.Unit(unit)
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
+
+func (r *StockMovementRepo) Record(ctx context.Context, c *entities.Chemical, movements ...entities.StockMovement) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range movements {
+		if err := insertStockMovement(ctx, tx, &movements[i]); err != nil {
+			return err
+		}
+	}
+
+	var balance float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(amount), 0) FROM stock_movements
+		WHERE chemical_id = $1 AND user_id = $2`, c.ID, c.UserID).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("summing stock movements: %w", err)
+	}
+	c.Reconcile(balance)
+	c.UpdatedAt = time.Now()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE chemicals
+		SET name = $1, type = $2,
+			stock_amount = $3, stock_unit = $4,
+			alert_threshold = $5, daily_usage = $6,
+			last_purchased = $7, updated_at = $8
+		WHERE id = $9 AND user_id = $10`,
+		c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.DailyUsage, c.LastPurchased, c.UpdatedAt, c.ID, c.UserID)
+	if err != nil {
+		return fmt.Errorf("updating chemical: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing stock movements: %w", err)
+	}
+	return nil
+}
+
+func insertStockMovement(ctx context.Context, tx *sql.Tx, m *entities.StockMovement) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO stock_movements (id, user_id, chemical_id, kind, amount, unit, reason,
+			cost, chemistry_log_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		m.ID, m.UserID, m.ChemicalID, string(m.Kind), m.Amount, string(m.Unit), m.Reason,
+		m.Cost, m.ChemistryLogID, m.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting stock movement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type TaskCompletionRepo struct {
+	db *sql.DB
+}
+
+func NewTaskCompletionRepo(db *sql.DB) *TaskCompletionRepo {
+	return &TaskCompletionRepo{db: db}
+}
+
+func (r *TaskCompletionRepo) FindBySeriesID(ctx context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.TaskCompletion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, task_id, series_id, notes, duration_minutes,
+			chemistry_log_id, service_record_id,
+			completed_at, created_at
+		FROM task_completions
+		WHERE series_id = $1 AND user_id = $2
+		ORDER BY completed_at DESC`, seriesID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying task completions: %w", err)
+	}
+	defer rows.Close()
+
+	var completions []entities.TaskCompletion
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var c entities.TaskCompletion
+		if err := rows.Scan(&c.ID, &c.UserID, &c.TaskID, &c.SeriesID, &c.Notes, &c.DurationMinutes, &c.ChemistryLogID, &c.ServiceRecordID, &c.CompletedAt, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning task completion: %w", err)
+		}
+		index[c.ID] = len(completions)
+		completions = append(completions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(completions) == 0 {
+		return completions, nil
+	}
+
+	photoRows, err := r.db.QueryContext(ctx, `
+		SELECT p.id, p.completion_id, p.user_id, p.filename, p.content_type, p.created_at
+		FROM task_completion_photos p
+		JOIN task_completions c ON c.id = p.completion_id
+		WHERE c.series_id = $1 AND c.user_id = $2
+		ORDER BY p.created_at ASC`, seriesID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying task completion photos: %w", err)
+	}
+	defer photoRows.Close()
+
+	for photoRows.Next() {
+		var p entities.TaskPhoto
+		if err := photoRows.Scan(&p.ID, &p.CompletionID, &p.UserID, &p.Filename, &p.ContentType, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning task completion photo: %w", err)
+		}
+		if i, ok := index[p.CompletionID]; ok {
+			completions[i].Photos = append(completions[i].Photos, p)
+		}
+	}
+	return completions, photoRows.Err()
+}
+
+func (r *TaskCompletionRepo) FindPhoto(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskPhoto, error) {
+	var p entities.TaskPhoto
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, completion_id, user_id, filename, content_type, data, created_at
+		FROM task_completion_photos
+		WHERE id = $1 AND user_id = $2`, id, userID).
+		Scan(&p.ID, &p.CompletionID, &p.UserID, &p.Filename, &p.ContentType, &p.Data, &p.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying task completion photo: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *TaskCompletionRepo) Create(ctx context.Context, c *entities.TaskCompletion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO task_completions (id, user_id, task_id, series_id, notes, duration_minutes,
+			chemistry_log_id, service_record_id,
+			completed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		c.ID, c.UserID, c.TaskID, c.SeriesID, c.Notes, c.DurationMinutes,
+		c.ChemistryLogID, c.ServiceRecordID,
+		c.CompletedAt, c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting task completion: %w", err)
+	}
+
+	for _, p := range c.Photos {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO task_completion_photos (id, completion_id, user_id, filename, content_type, data, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			p.ID, c.ID, c.UserID, p.Filename, p.ContentType, p.Data, p.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("inserting task completion photo: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task completion: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskCompletionRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_completion_photos WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("deleting task completion photos: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_completions WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("deleting task completions: %w", err)
+	}
+	return nil
+}
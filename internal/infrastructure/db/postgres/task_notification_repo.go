@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/domain/entities"
@@ -23,11 +24,11 @@ func (r *TaskNotificationRepo) Claim(ctx context.Context, notif *entities.TaskNo
 		taskID = &notif.TaskID
 	}
 	res, err := r.db.ExecContext(ctx, `
-		INSERT INTO task_notifications (id, task_id, user_id, type, due_date, sent_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (user_id, type, due_date) DO NOTHING`,
+		INSERT INTO task_notifications (id, task_id, user_id, type, kind, rule, task_ids, due_date, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, type, rule, due_date) DO NOTHING`,
 		notif.ID, taskID, notif.UserID,
-		notif.Type, notif.DueDate, notif.SentAt)
+		notif.Type, notif.Kind, notif.Rule, joinUUIDs(notif.TaskIDs), notif.DueDate, notif.SentAt)
 	if err != nil {
 		return false, fmt.Errorf("claiming task notification: %w", err)
 	}
@@ -38,10 +39,45 @@ func (r *TaskNotificationRepo) Claim(ctx context.Context, notif *entities.TaskNo
 	return rows > 0, nil
 }
 
-func (r *TaskNotificationRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM task_notifications WHERE id = $1`, id)
+func (r *TaskNotificationRepo) FindLastSentReminder(ctx context.Context, userID uuid.UUID, channel string) (*entities.TaskNotification, error) {
+	var n entities.TaskNotification
+	var taskID *uuid.UUID
+	var taskIDs string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT n.id, n.task_id, n.user_id, n.type, n.kind, n.rule, n.task_ids, n.due_date, n.sent_at
+		FROM task_notifications n
+		JOIN notification_outbox o ON o.notification_id = n.id
+		WHERE n.user_id = $1 AND n.type = $2 AND n.kind IN ($3, $4) AND o.status = $5
+		ORDER BY o.sent_at DESC LIMIT 1`,
+		userID, channel, entities.NotificationKindDue, entities.NotificationKindOverdue, entities.OutboxSent,
+	).Scan(&n.ID, &taskID, &n.UserID, &n.Type, &n.Kind, &n.Rule, &taskIDs, &n.DueDate, &n.SentAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("deleting task notification: %w", err)
+		return nil, fmt.Errorf("querying last sent reminder: %w", err)
+	}
+	if taskID != nil {
+		n.TaskID = *taskID
+	}
+	n.TaskIDs = splitUUIDs(taskIDs)
+	return &n, nil
+}
+
+func joinUUIDs(ids []uuid.UUID) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return strings.Join(s, ",")
+}
+
+func splitUUIDs(s string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(s, ",") {
+		if id, err := uuid.Parse(part); err == nil {
+			ids = append(ids, id)
+		}
 	}
-	return nil
+	return ids
 }
@@ -21,13 +21,13 @@ func NewTaskRepo(db *sql.DB) *TaskRepo {
 
 func (r *TaskRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Task, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at
 		FROM tasks
 		WHERE user_id = $1
-		ORDER BY due_date ASC`, userID)
+		ORDER BY COALESCE(snoozed_until, due_date) ASC`, userID)
 	if err != nil {
 		return nil, fmt.Errorf("querying tasks: %w", err)
 	}
@@ -39,17 +39,22 @@ func (r *TaskRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Ta
 		if err != nil {
 			return nil, err
 		}
-		t.CheckOverdue()
 		tasks = append(tasks, *t)
 	}
-	return tasks, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachTaskChecklists(ctx, r.db, tasks, `user_id = $1`, userID); err != nil {
+		return nil, err
+	}
+	return tasks, nil
 }
 
 func (r *TaskRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Task, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at
 		FROM tasks
 		WHERE id = $1 AND user_id = $2`, id, userID)
@@ -60,21 +65,90 @@ func (r *TaskRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID)
 	if err != nil {
 		return nil, fmt.Errorf("querying task: %w", err)
 	}
-	t.CheckOverdue()
-	return t, nil
+	tasks := []entities.Task{*t}
+	if err := attachTaskChecklists(ctx, r.db, tasks, `task_id = $1 AND user_id = $2`, id, userID); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+func (r *TaskRepo) FindBySeriesID(ctx context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
+			created_at, updated_at
+		FROM tasks
+		WHERE series_id = $1 AND user_id = $2
+		ORDER BY due_date DESC`, seriesID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying task series: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []entities.Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachTaskChecklists(ctx, r.db, tasks, `task_id IN (SELECT id FROM tasks WHERE series_id = $1 AND user_id = $2)`, seriesID, userID); err != nil {
+		return nil, err
+	}
+	return tasks, nil
 }
 
 func (r *TaskRepo) Create(ctx context.Context, t *entities.Task) error {
-	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO tasks (id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO tasks (id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
-		t.ID, t.UserID, t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, t.DueDate, string(t.Status), t.CompletedAt, t.CreatedAt, t.UpdatedAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
+		t.ID, t.UserID, t.SeriesID, t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, string(t.Recurrence.Anchor), t.EquipmentID, t.DueDate, string(t.Status), t.CompletedAt, t.SkippedAt, t.SnoozedUntil, t.OverdueAt, t.CreatedAt, t.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("inserting task: %w", err)
 	}
+	if err := insertTaskChecklist(ctx, tx, t); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task: %w", err)
+	}
+	return nil
+}
+
+// SaveChecklist replaces the task's checklist items, including their
+// checked state.
+func (r *TaskRepo) SaveChecklist(ctx context.Context, t *entities.Task) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_checklist_items WHERE task_id = $1 AND user_id = $2`, t.ID, t.UserID); err != nil {
+		return fmt.Errorf("deleting task checklist items: %w", err)
+	}
+	if err := insertTaskChecklist(ctx, tx, t); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task checklist: %w", err)
+	}
 	return nil
 }
 
@@ -83,28 +157,30 @@ func (r *TaskRepo) Update(ctx context.Context, t *entities.Task) error {
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE tasks
 		SET name = $1, description = $2,
-			recurrence_frequency = $3, recurrence_interval = $4,
-			due_date = $5, status = $6, completed_at = $7,
-			updated_at = $8
-		WHERE id = $9 AND user_id = $10`,
-		t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, t.DueDate, string(t.Status), t.CompletedAt, t.UpdatedAt, t.ID, t.UserID)
+			recurrence_frequency = $3, recurrence_interval = $4, recurrence_anchor = $5,
+			equipment_id = $6, due_date = $7, status = $8, completed_at = $9,
+			skipped_at = $10, snoozed_until = $11, overdue_at = $12,
+			updated_at = $13
+		WHERE id = $14 AND user_id = $15`,
+		t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, string(t.Recurrence.Anchor), t.EquipmentID, t.DueDate, string(t.Status), t.CompletedAt, t.SkippedAt, t.SnoozedUntil, t.OverdueAt, t.UpdatedAt, t.ID, t.UserID)
 	if err != nil {
 		return fmt.Errorf("updating task: %w", err)
 	}
 	return nil
 }
 
-func (r *TaskRepo) FindDueOnDate(ctx context.Context, date time.Time) ([]entities.Task, error) {
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+func (r *TaskRepo) FindDueOnDate(ctx context.Context, timezone string, date time.Time) ([]entities.Task, error) {
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 	endOfDay := startOfDay.AddDate(0, 0, 1)
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at
 		FROM tasks
-		WHERE due_date >= $1 AND due_date < $2 AND status = 'pending'
-		ORDER BY due_date ASC`, startOfDay, endOfDay)
+		WHERE COALESCE(snoozed_until, due_date) >= $1 AND COALESCE(snoozed_until, due_date) < $2 AND status IN ('pending', 'overdue')
+			AND user_id IN (SELECT id FROM users WHERE timezone = $3)
+		ORDER BY COALESCE(snoozed_until, due_date) ASC`, startOfDay, endOfDay, timezone)
 	if err != nil {
 		return nil, fmt.Errorf("querying tasks due on date: %w", err)
 	}
@@ -121,7 +197,27 @@ func (r *TaskRepo) FindDueOnDate(ctx context.Context, date time.Time) ([]entitie
 	return tasks, rows.Err()
 }
 
+func (r *TaskRepo) MarkOverdue(ctx context.Context, timezone string, dueBefore, now time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE tasks
+		SET status = 'overdue', overdue_at = $1, updated_at = $1
+		WHERE status = 'pending' AND COALESCE(snoozed_until, due_date) < $2
+			AND user_id IN (SELECT id FROM users WHERE timezone = $3)`,
+		now, dueBefore, timezone)
+	if err != nil {
+		return 0, fmt.Errorf("marking tasks overdue: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
 func (r *TaskRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_checklist_items WHERE task_id = $1 AND user_id = $2`, id, userID); err != nil {
+		return fmt.Errorf("deleting task checklist items: %w", err)
+	}
 	_, err := r.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = $1 AND user_id = $2`, id, userID)
 	if err != nil {
 		return fmt.Errorf("deleting task: %w", err)
@@ -131,11 +227,12 @@ func (r *TaskRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) e
 
 func scanTaskFromRow(s scanner) (*entities.Task, error) {
 	var t entities.Task
-	var freq, status string
-	if err := s.Scan(&t.ID, &t.UserID, &t.Name, &t.Description, &freq, &t.Recurrence.Interval, &t.DueDate, &status, &t.CompletedAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
+	var freq, anchor, status string
+	if err := s.Scan(&t.ID, &t.UserID, &t.SeriesID, &t.Name, &t.Description, &freq, &t.Recurrence.Interval, &anchor, &t.EquipmentID, &t.DueDate, &status, &t.CompletedAt, &t.SkippedAt, &t.SnoozedUntil, &t.OverdueAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
 		return nil, err
 	}
 	t.Recurrence.Frequency = valueobjects.Frequency(freq)
+	t.Recurrence.Anchor = valueobjects.Anchor(anchor)
 	t.Status = entities.TaskStatus(status)
 	return &t, nil
 }
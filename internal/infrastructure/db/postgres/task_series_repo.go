@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type TaskSeriesRepo struct {
+	db *sql.DB
+}
+
+func NewTaskSeriesRepo(db *sql.DB) *TaskSeriesRepo {
+	return &TaskSeriesRepo{db: db}
+}
+
+func (r *TaskSeriesRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskSeries, error) {
+	var s entities.TaskSeries
+	var freq, anchor string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, user_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			ended_at, created_at, updated_at
+		FROM task_series
+		WHERE id = $1 AND user_id = $2`, id, userID).
+		Scan(&s.ID, &s.UserID, &s.Name, &s.Description, &freq, &s.Recurrence.Interval, &anchor, &s.EquipmentID, &s.EndedAt, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying task series: %w", err)
+	}
+	s.Recurrence.Frequency = valueobjects.Frequency(freq)
+	s.Recurrence.Anchor = valueobjects.Anchor(anchor)
+	if s.Checklist, err = findSeriesChecklist(ctx, r.db, s.ID); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *TaskSeriesRepo) Create(ctx context.Context, s *entities.TaskSeries) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO task_series (id, user_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			ended_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		s.ID, s.UserID, s.Name, s.Description, string(s.Recurrence.Frequency), s.Recurrence.Interval, string(s.Recurrence.Anchor), s.EquipmentID, s.EndedAt, s.CreatedAt, s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting task series: %w", err)
+	}
+	if err := replaceSeriesChecklist(ctx, tx, s); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task series: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskSeriesRepo) Update(ctx context.Context, s *entities.TaskSeries) error {
+	s.UpdatedAt = time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE task_series
+		SET name = $1, description = $2,
+			recurrence_frequency = $3, recurrence_interval = $4, recurrence_anchor = $5,
+			equipment_id = $6, ended_at = $7, updated_at = $8
+		WHERE id = $9 AND user_id = $10`,
+		s.Name, s.Description, string(s.Recurrence.Frequency), s.Recurrence.Interval, string(s.Recurrence.Anchor), s.EquipmentID, s.EndedAt, s.UpdatedAt, s.ID, s.UserID)
+	if err != nil {
+		return fmt.Errorf("updating task series: %w", err)
+	}
+	if err := replaceSeriesChecklist(ctx, tx, s); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task series: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskSeriesRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_series_checklist_items WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("deleting series checklist items: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_series WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("deleting task series: %w", err)
+	}
+	return nil
+}
@@ -18,12 +18,17 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 	return &UserRepo{db: db}
 }
 
+// userColumns lists the columns read by scanUserFromRow, in order.
+const userColumns = `id, email, password_hash, is_admin, is_disabled,
+			is_demo, demo_expires_at,
+			phone, phone_verified_at, phone_code_hash, phone_code_sent_at, phone_code_expires_at, phone_code_attempts,
+			sms_opted_out, push_service, push_url, push_token, pool_gallons, timezone, calendar_token,
+			no_test_alert_days, digest_weekday, digest_hour,
+			quiet_hours_enabled, quiet_hours_start, quiet_hours_end, created_at, updated_at`
+
 func (r *UserRepo) FindAll(ctx context.Context) ([]entities.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		ORDER BY created_at DESC`)
 	if err != nil {
@@ -44,10 +49,7 @@ func (r *UserRepo) FindAll(ctx context.Context) ([]entities.User, error) {
 
 func (r *UserRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE id = $1`, id)
 	u, err := scanUserRow(row)
@@ -62,10 +64,7 @@ func (r *UserRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.User,
 
 func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE email = $1`, email)
 	u, err := scanUserRow(row)
@@ -78,17 +77,42 @@ func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*entities.Use
 	return u, nil
 }
 
+// FindByCalendarToken returns the user whose calendar feed uses token, or
+// nil if there is none.
+func (r *UserRepo) FindByCalendarToken(ctx context.Context, token string) (*entities.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE calendar_token = $1`, token)
+	u, err := scanUserRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying user by calendar token: %w", err)
+	}
+	return u, nil
+}
+
 func (r *UserRepo) Create(ctx context.Context, u *entities.User) error {
+	code, codeSentAt, codeExpiresAt := phoneCodeColumns(u)
 	_, err := r.db.ExecContext(ctx, `
 		INSERT INTO users (id, email, password_hash, is_admin,
 			is_disabled, is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			phone, phone_verified_at, phone_code_hash, phone_code_sent_at, phone_code_expires_at, phone_code_attempts,
+			sms_opted_out, push_service, push_url, push_token, pool_gallons, timezone, calendar_token,
+			no_test_alert_days, digest_weekday, digest_hour,
+			quiet_hours_enabled, quiet_hours_start, quiet_hours_end, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)`,
 		u.ID, u.Email, u.PasswordHash, u.IsAdmin, u.IsDisabled,
 		u.IsDemo, u.DemoExpiresAt,
-		u.Phone, u.NotifyEmail, u.NotifySMS, u.PoolGallons,
-		u.CreatedAt, u.UpdatedAt)
+		u.Phone, u.PhoneVerifiedAt, code.Hash, codeSentAt, codeExpiresAt, code.Attempts,
+		u.SMSOptedOut, u.Push.Service, u.Push.URL, u.Push.Token, u.PoolGallons, u.Timezone, u.CalendarToken,
+		u.NoTestAlertDays, int(u.Digest.Weekday), u.Digest.Hour,
+		u.QuietHours.Enabled, u.QuietHours.Start, u.QuietHours.End, u.CreatedAt, u.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("inserting user: %w", err)
 	}
@@ -97,18 +121,26 @@ func (r *UserRepo) Create(ctx context.Context, u *entities.User) error {
 
 func (r *UserRepo) Update(ctx context.Context, u *entities.User) error {
 	u.UpdatedAt = time.Now()
+	code, codeSentAt, codeExpiresAt := phoneCodeColumns(u)
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE users
 		SET email = $1, password_hash = $2,
 			is_admin = $3, is_disabled = $4,
 			is_demo = $5, demo_expires_at = $6,
-			phone = $7, notify_email = $8, notify_sms = $9,
-			pool_gallons = $10, updated_at = $11
-		WHERE id = $12`,
+			phone = $7, phone_verified_at = $8, phone_code_hash = $9, phone_code_sent_at = $10,
+			phone_code_expires_at = $11, phone_code_attempts = $12,
+			sms_opted_out = $13, push_service = $14, push_url = $15, push_token = $16,
+			pool_gallons = $17, timezone = $18, calendar_token = $19,
+			no_test_alert_days = $20, digest_weekday = $21, digest_hour = $22,
+			quiet_hours_enabled = $23, quiet_hours_start = $24, quiet_hours_end = $25,
+			updated_at = $26
+		WHERE id = $27`,
 		u.Email, u.PasswordHash, u.IsAdmin, u.IsDisabled,
 		u.IsDemo, u.DemoExpiresAt,
-		u.Phone, u.NotifyEmail, u.NotifySMS,
-		u.PoolGallons, u.UpdatedAt, u.ID)
+		u.Phone, u.PhoneVerifiedAt, code.Hash, codeSentAt, codeExpiresAt, code.Attempts,
+		u.SMSOptedOut, u.Push.Service, u.Push.URL, u.Push.Token, u.PoolGallons, u.Timezone, u.CalendarToken,
+		u.NoTestAlertDays, int(u.Digest.Weekday), u.Digest.Hour,
+		u.QuietHours.Enabled, u.QuietHours.Start, u.QuietHours.End, u.UpdatedAt, u.ID)
 	if err != nil {
 		return fmt.Errorf("updating user: %w", err)
 	}
@@ -132,6 +164,70 @@ func (r *UserRepo) CountDemo(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+func (r *UserRepo) FindTimezones(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT timezone FROM users WHERE NOT is_disabled`)
+	if err != nil {
+		return nil, fmt.Errorf("querying user timezones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []string
+	for rows.Next() {
+		var zone string
+		if err := rows.Scan(&zone); err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+func (r *UserRepo) FindByTimezone(ctx context.Context, timezone string) ([]entities.User, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE timezone = $1 AND NOT is_disabled`, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("querying users by timezone: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entities.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, rows.Err()
+}
+
+// FindByPhone returns the enabled users with the phone number phone.
+func (r *UserRepo) FindByPhone(ctx context.Context, phone string) ([]entities.User, error) {
+	if phone == "" {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE phone = $1 AND NOT is_disabled`, phone)
+	if err != nil {
+		return nil, fmt.Errorf("querying users by phone: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entities.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, rows.Err()
+}
+
 func (r *UserRepo) CountAdmins(ctx context.Context) (int, error) {
 	var count int
 	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE is_admin = TRUE AND is_disabled = FALSE`).Scan(&count)
@@ -147,10 +243,7 @@ type scanner interface {
 
 func (r *UserRepo) FindExpiredDemo(ctx context.Context, now time.Time) ([]entities.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE is_demo = TRUE AND demo_expires_at < $1`, now)
 	if err != nil {
@@ -171,15 +264,32 @@ func (r *UserRepo) FindExpiredDemo(ctx context.Context, now time.Time) ([]entiti
 
 func scanUserFromRow(s scanner) (*entities.User, error) {
 	var u entities.User
+	var code entities.PhoneCode
+	var codeSentAt, codeExpiresAt *time.Time
 	if err := s.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.IsAdmin, &u.IsDisabled,
 		&u.IsDemo, &u.DemoExpiresAt,
-		&u.Phone, &u.NotifyEmail, &u.NotifySMS, &u.PoolGallons,
-		&u.CreatedAt, &u.UpdatedAt); err != nil {
+		&u.Phone, &u.PhoneVerifiedAt, &code.Hash, &codeSentAt, &codeExpiresAt, &code.Attempts,
+		&u.SMSOptedOut, &u.Push.Service, &u.Push.URL, &u.Push.Token, &u.PoolGallons, &u.Timezone, &u.CalendarToken,
+		&u.NoTestAlertDays, &u.Digest.Weekday, &u.Digest.Hour,
+		&u.QuietHours.Enabled, &u.QuietHours.Start, &u.QuietHours.End, &u.CreatedAt, &u.UpdatedAt); err != nil {
 		return nil, err
 	}
+	if code.Hash != "" && codeSentAt != nil && codeExpiresAt != nil {
+		code.SentAt, code.ExpiresAt = *codeSentAt, *codeExpiresAt
+		u.PhoneCode = &code
+	}
 	return &u, nil
 }
 
+// phoneCodeColumns splits the user's pending verification code into its
+// columns, all zero if there is none.
+func phoneCodeColumns(u *entities.User) (code entities.PhoneCode, sentAt, expiresAt *time.Time) {
+	if u.PhoneCode == nil {
+		return entities.PhoneCode{}, nil, nil
+	}
+	return *u.PhoneCode, &u.PhoneCode.SentAt, &u.PhoneCode.ExpiresAt
+}
+
 func scanUser(rows *sql.Rows) (*entities.User, error) {
 	return scanUserFromRow(rows)
 }
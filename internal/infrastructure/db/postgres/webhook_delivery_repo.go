@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type WebhookDeliveryRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliveryRepo(db *sql.DB) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{db: db}
+}
+
+const deliveryColumns = `id, webhook_id, user_id, event, payload, status, attempts, response_code, last_error,
+	next_attempt_at, created_at, updated_at`
+
+func (r *WebhookDeliveryRepo) FindRecent(ctx context.Context, userID uuid.UUID, limit int) ([]entities.WebhookDelivery, error) {
+	return r.findWhere(ctx, "user_id = $1 ORDER BY created_at DESC LIMIT $2", userID, limit)
+}
+
+func (r *WebhookDeliveryRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	deliveries, err := r.findWhere(ctx, "id = $1 AND user_id = $2", id, userID)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+func (r *WebhookDeliveryRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = $2 AND next_attempt_at <= $3
+			ORDER BY next_attempt_at ASC LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns,
+		leaseUntil, entities.DeliveryPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+	return scanDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+	return scanDeliveries(rows)
+}
+
+func scanDeliveries(rows *sql.Rows) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+	for rows.Next() {
+		var d entities.WebhookDelivery
+		var event, payload string
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.UserID, &event, &payload, &d.Status, &d.Attempts, &d.ResponseCode, &d.LastError,
+			&d.NextAttemptAt, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning webhook delivery: %w", err)
+		}
+		d.Event = entities.WebhookEvent(event)
+		d.Payload = []byte(payload)
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookDeliveryRepo) Create(ctx context.Context, d *entities.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, webhook_id, user_id, event, payload, status, attempts, response_code, last_error,
+			next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		d.ID, d.WebhookID, d.UserID, string(d.Event), string(d.Payload), d.Status, d.Attempts, d.ResponseCode, d.LastError,
+		d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepo) Update(ctx context.Context, d *entities.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, response_code = $3, last_error = $4, next_attempt_at = $5, updated_at = $6
+		WHERE id = $7`,
+		d.Status, d.Attempts, d.ResponseCode, d.LastError, d.NextAttemptAt, d.UpdatedAt, d.ID)
+	if err != nil {
+		return fmt.Errorf("updating webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// attachTaskChecklists loads the checklist items matching where and adds
+// them to the tasks they belong to.
+func attachTaskChecklists(ctx context.Context, db *sql.DB, tasks []entities.Task, where string, args ...any) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	index := make(map[uuid.UUID]int, len(tasks))
+	for i, t := range tasks {
+		index[t.ID] = i
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, task_id, position, name, required, checked_at
+		FROM task_checklist_items
+		WHERE `+where+`
+		ORDER BY position ASC`, args...)
+	if err != nil {
+		return fmt.Errorf("querying task checklist items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.ChecklistItem
+		var idStr, taskIDStr string
+		var required int
+		var checkedAt *string
+		if err := rows.Scan(&idStr, &taskIDStr, &item.Position, &item.Name, &required, &checkedAt); err != nil {
+			return fmt.Errorf("scanning task checklist item: %w", err)
+		}
+		item.ID = uuid.MustParse(idStr)
+		item.Required = required == 1
+		item.CheckedAt = parseTimePtr(checkedAt)
+		if i, ok := index[uuid.MustParse(taskIDStr)]; ok {
+			tasks[i].Checklist = append(tasks[i].Checklist, item)
+		}
+	}
+	return rows.Err()
+}
+
+func insertTaskChecklist(ctx context.Context, db execer, t *entities.Task) error {
+	for _, item := range t.Checklist {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO task_checklist_items (id, task_id, user_id, position, name, required, checked_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			item.ID.String(), t.ID.String(), t.UserID.String(), item.Position, item.Name, boolToInt(item.Required), fmtTimePtr(item.CheckedAt))
+		if err != nil {
+			return fmt.Errorf("inserting task checklist item: %w", err)
+		}
+	}
+	return nil
+}
+
+func findSeriesChecklist(ctx context.Context, db *sql.DB, seriesID uuid.UUID) ([]entities.ChecklistItem, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, position, name, required
+		FROM task_series_checklist_items
+		WHERE series_id = ?
+		ORDER BY position ASC`, seriesID.String())
+	if err != nil {
+		return nil, fmt.Errorf("querying series checklist items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entities.ChecklistItem
+	for rows.Next() {
+		var item entities.ChecklistItem
+		var idStr string
+		var required int
+		if err := rows.Scan(&idStr, &item.Position, &item.Name, &required); err != nil {
+			return nil, fmt.Errorf("scanning series checklist item: %w", err)
+		}
+		item.ID = uuid.MustParse(idStr)
+		item.Required = required == 1
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func replaceSeriesChecklist(ctx context.Context, db execer, s *entities.TaskSeries) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM task_series_checklist_items WHERE series_id = ?`, s.ID.String()); err != nil {
+		return fmt.Errorf("deleting series checklist items: %w", err)
+	}
+	for _, item := range s.Checklist {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO task_series_checklist_items (id, series_id, user_id, position, name, required)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			item.ID.String(), s.ID.String(), s.UserID.String(), item.Position, item.Name, boolToInt(item.Required))
+		if err != nil {
+			return fmt.Errorf("inserting series checklist item: %w", err)
+		}
+	}
+	return nil
+}
@@ -22,7 +22,7 @@ func NewChemicalRepo(db *sql.DB) *ChemicalRepo {
 func (r *ChemicalRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Chemical, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, user_id, name, type,
-			stock_amount, stock_unit, alert_threshold,
+			stock_amount, stock_unit, alert_threshold, daily_usage,
 			last_purchased, created_at, updated_at
 		FROM chemicals
 		WHERE user_id = ?
@@ -46,7 +46,7 @@ func (r *ChemicalRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entitie
 func (r *ChemicalRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Chemical, error) {
 	row := r.db.QueryRowContext(ctx, `
 		SELECT id, user_id, name, type,
-			stock_amount, stock_unit, alert_threshold,
+			stock_amount, stock_unit, alert_threshold, daily_usage,
 			last_purchased, created_at, updated_at
 		FROM chemicals
 		WHERE id = ? AND user_id = ?`, id.String(), userID.String())
@@ -61,15 +61,30 @@ func (r *ChemicalRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.U
 }
 
 func (r *ChemicalRepo) Create(ctx context.Context, c *entities.Chemical) error {
-	_, err := r.db.ExecContext(ctx, `
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO chemicals (id, user_id, name, type,
-			stock_amount, stock_unit, alert_threshold,
+			stock_amount, stock_unit, alert_threshold, daily_usage,
 			last_purchased, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		c.ID.String(), c.UserID.String(), c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, fmtTimePtr(c.LastPurchased), c.CreatedAt.Format(time.RFC3339), c.UpdatedAt.Format(time.RFC3339))
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID.String(), c.UserID.String(), c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.DailyUsage, fmtTimePtr(c.LastPurchased), c.CreatedAt.Format(time.RFC3339), c.UpdatedAt.Format(time.RFC3339))
 	if err != nil {
 		return fmt.Errorf("inserting chemical: %w", err)
 	}
+	if m := c.OpeningMovement(); m != nil {
+		if err := insertStockMovement(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing chemical: %w", err)
+	}
 	return nil
 }
 
@@ -79,16 +94,25 @@ func (r *ChemicalRepo) Update(ctx context.Context, c *entities.Chemical) error {
 		UPDATE chemicals
 		SET name = ?, type = ?,
 			stock_amount = ?, stock_unit = ?,
-			alert_threshold = ?, last_purchased = ?,
-			updated_at = ?
+			alert_threshold = ?, daily_usage = ?,
+			last_purchased = ?, updated_at = ?
 		WHERE id = ? AND user_id = ?`,
-		c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, fmtTimePtr(c.LastPurchased), c.UpdatedAt.Format(time.RFC3339), c.ID.String(), c.UserID.String())
+		c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.DailyUsage, fmtTimePtr(c.LastPurchased), c.UpdatedAt.Format(time.RFC3339), c.ID.String(), c.UserID.String())
 	if err != nil {
 		return fmt.Errorf("updating chemical: %w", err)
 	}
 	return nil
 }
 
+func (r *ChemicalRepo) UpdateUsage(ctx context.Context, c *entities.Chemical) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE chemicals SET daily_usage = ? WHERE id = ? AND user_id = ?`,
+		c.DailyUsage, c.ID.String(), c.UserID.String())
+	if err != nil {
+		return fmt.Errorf("updating chemical usage: %w", err)
+	}
+	return nil
+}
+
 func (r *ChemicalRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
 	_, err := r.db.ExecContext(ctx, `DELETE FROM chemicals WHERE id = ? AND user_id = ?`, id.String(), userID.String())
 	if err != nil {
@@ -101,7 +125,7 @@ func scanChemicalFromRow(s scanner) (*entities.Chemical, error) {
 	var c entities.Chemical
 	var idStr, userIDStr, chemType, stockUnit, createdAt, updatedAt string
 	var lastPurchased *string
-	if err := s.Scan(&idStr, &userIDStr, &c.Name, &chemType, &c.Stock.Amount, &stockUnit, &c.AlertThreshold, &lastPurchased, &createdAt, &updatedAt); err != nil {
+	if err := s.Scan(&idStr, &userIDStr, &c.Name, &chemType, &c.Stock.Amount, &stockUnit, &c.AlertThreshold, &c.DailyUsage, &lastPurchased, &createdAt, &updatedAt); err != nil {
 		return nil, fmt.Errorf("scanning chemical: %w", err)
 	}
 	c.ID = uuid.MustParse(idStr)
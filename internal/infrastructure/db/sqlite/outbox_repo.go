@@ -0,0 +1,200 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+type OutboxRepo struct {
+	db *sql.DB
+}
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+const outboxColumns = `id, notification_id, user_id, channel, kind, recipient, subject, body, html_body, headers,
+	push_priority, push_tags, push_click, status, attempts, last_error, next_attempt_at, created_at, updated_at, sent_at`
+
+func (r *OutboxRepo) Enqueue(ctx context.Context, notif *entities.TaskNotification, msg *entities.OutboxMessage) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var taskID string
+	if notif.TaskID != uuid.Nil {
+		taskID = notif.TaskID.String()
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO task_notifications (id, task_id, user_id, type, kind, rule, task_ids, due_date, sent_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		notif.ID.String(), taskID, notif.UserID.String(),
+		notif.Type, notif.Kind, notif.Rule, joinUUIDs(notif.TaskIDs), notif.DueDate.Format("2006-01-02"), notif.SentAt.Format(time.RFC3339))
+	if err != nil {
+		return false, fmt.Errorf("claiming task notification: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO notification_outbox (`+outboxColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		msg.ID.String(), msg.NotificationID.String(), msg.UserID.String(), msg.Channel, msg.Kind, msg.Recipient,
+		msg.Subject, msg.Body, msg.HTMLBody, joinHeaders(msg.Headers),
+		msg.Push.Priority, msg.Push.Tags, msg.Push.Click, msg.Status, msg.Attempts, msg.LastError,
+		fmtUTCPtr(msg.NextAttemptAt), msg.CreatedAt.UTC().Format(time.RFC3339), msg.UpdatedAt.UTC().Format(time.RFC3339), fmtUTCPtr(msg.SentAt))
+	if err != nil {
+		return false, fmt.Errorf("inserting outbox message: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return true, nil
+}
+
+func (r *OutboxRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE notification_outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC LIMIT ?
+		)
+		RETURNING `+outboxColumns,
+		leaseUntil.UTC().Format(time.RFC3339), entities.OutboxPending, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming outbox messages: %w", err)
+	}
+	defer rows.Close()
+	return scanOutboxMessages(rows)
+}
+
+func (r *OutboxRepo) FindRecent(ctx context.Context, userID uuid.UUID, limit int) ([]entities.OutboxMessage, error) {
+	return r.findWhere(ctx, "user_id = ? ORDER BY created_at DESC LIMIT ?", userID.String(), limit)
+}
+
+func (r *OutboxRepo) FindRecentErrors(ctx context.Context, limit int) ([]entities.OutboxMessage, error) {
+	return r.findWhere(ctx, "last_error != '' ORDER BY updated_at DESC LIMIT ?", limit)
+}
+
+func (r *OutboxRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+outboxColumns+` FROM notification_outbox WHERE `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying outbox messages: %w", err)
+	}
+	defer rows.Close()
+	return scanOutboxMessages(rows)
+}
+
+func (r *OutboxRepo) Stats(ctx context.Context, since time.Time) ([]repositories.OutboxStats, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT channel,
+			SUM(CASE WHEN status = 'sent' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN status = 'pending' AND attempts = 0 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN status = 'pending' AND attempts > 0 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN status = 'dead' THEN 1 ELSE 0 END)
+		FROM notification_outbox
+		WHERE created_at >= ?
+		GROUP BY channel
+		ORDER BY channel`,
+		since.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("counting outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []repositories.OutboxStats
+	for rows.Next() {
+		var st repositories.OutboxStats
+		if err := rows.Scan(&st.Channel, &st.Sent, &st.Pending, &st.Retrying, &st.Dead); err != nil {
+			return nil, fmt.Errorf("scanning outbox stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
+
+func scanOutboxMessages(rows *sql.Rows) ([]entities.OutboxMessage, error) {
+	var msgs []entities.OutboxMessage
+	for rows.Next() {
+		m, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, *m)
+	}
+	return msgs, rows.Err()
+}
+
+func scanOutboxMessage(s scanner) (*entities.OutboxMessage, error) {
+	var m entities.OutboxMessage
+	var idStr, notifIDStr, userIDStr, headers, createdAt, updatedAt string
+	var nextAttempt, sentAt *string
+	if err := s.Scan(&idStr, &notifIDStr, &userIDStr, &m.Channel, &m.Kind, &m.Recipient, &m.Subject, &m.Body, &m.HTMLBody, &headers,
+		&m.Push.Priority, &m.Push.Tags, &m.Push.Click, &m.Status, &m.Attempts, &m.LastError, &nextAttempt, &createdAt, &updatedAt, &sentAt); err != nil {
+		return nil, fmt.Errorf("scanning outbox message: %w", err)
+	}
+	m.ID = uuid.MustParse(idStr)
+	m.NotificationID = uuid.MustParse(notifIDStr)
+	m.UserID = uuid.MustParse(userIDStr)
+	m.Headers = splitHeaders(headers)
+	m.NextAttemptAt = parseTimePtr(nextAttempt)
+	m.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	m.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	m.SentAt = parseTimePtr(sentAt)
+	return &m, nil
+}
+
+func (r *OutboxRepo) Update(ctx context.Context, msg *entities.OutboxMessage) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE notification_outbox
+		SET recipient = ?, status = ?, attempts = ?, last_error = ?, next_attempt_at = ?, updated_at = ?, sent_at = ?
+		WHERE id = ?`,
+		msg.Recipient, msg.Status, msg.Attempts, msg.LastError, fmtUTCPtr(msg.NextAttemptAt), msg.UpdatedAt.UTC().Format(time.RFC3339),
+		fmtUTCPtr(msg.SentAt), msg.ID.String())
+	if err != nil {
+		return fmt.Errorf("updating outbox message: %w", err)
+	}
+	return nil
+}
+
+// joinHeaders formats email headers one "Name: value" per line, sorted by
+// name.
+func joinHeaders(headers map[string]string) string {
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		fmt.Fprintf(&b, "%s: %s\n", k, headers[k])
+	}
+	return b.String()
+}
+
+func splitHeaders(s string) map[string]string {
+	var headers map[string]string
+	for _, line := range strings.Split(s, "\n") {
+		k, v, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[k] = v
+	}
+	return headers
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type ReminderRuleRepo struct {
+	db *sql.DB
+}
+
+func NewReminderRuleRepo(db *sql.DB) *ReminderRuleRepo {
+	return &ReminderRuleRepo{db: db}
+}
+
+func (r *ReminderRuleRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.ReminderRule, error) {
+	return r.findWhere(ctx, "user_id = ?", userID.String())
+}
+
+func (r *ReminderRuleRepo) FindByTimezone(ctx context.Context, timezone string) ([]entities.ReminderRule, error) {
+	return r.findWhere(ctx, "user_id IN (SELECT id FROM users WHERE timezone = ? AND is_disabled = 0)", timezone)
+}
+
+func (r *ReminderRuleRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.ReminderRule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, offset_days, send_hour, created_at
+		FROM reminder_rules
+		WHERE `+where+`
+		ORDER BY offset_days ASC, send_hour ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying reminder rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []entities.ReminderRule
+	for rows.Next() {
+		var rule entities.ReminderRule
+		var idStr, userIDStr, createdAt string
+		if err := rows.Scan(&idStr, &userIDStr, &rule.OffsetDays, &rule.SendHour, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning reminder rule: %w", err)
+		}
+		rule.ID = uuid.MustParse(idStr)
+		rule.UserID = uuid.MustParse(userIDStr)
+		rule.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *ReminderRuleRepo) Create(ctx context.Context, rule *entities.ReminderRule) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO reminder_rules (id, user_id, offset_days, send_hour, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		rule.ID.String(), rule.UserID.String(), rule.OffsetDays, rule.SendHour, rule.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting reminder rule: %w", err)
+	}
+	return nil
+}
+
+func (r *ReminderRuleRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM reminder_rules WHERE id = ? AND user_id = ?`, id.String(), userID.String())
+	if err != nil {
+		return fmt.Errorf("deleting reminder rule: %w", err)
+	}
+	return nil
+}
+
+func (r *ReminderRuleRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM reminder_rules WHERE user_id = ?`, userID.String())
+	if err != nil {
+		return fmt.Errorf("deleting reminder rules: %w", err)
+	}
+	return nil
+}
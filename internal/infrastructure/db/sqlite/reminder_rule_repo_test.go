@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestReminderRuleRepoImplementsInterface(t *testing.T) {
+	var _ repositories.ReminderRuleRepository = (*ReminderRuleRepo)(nil)
+}
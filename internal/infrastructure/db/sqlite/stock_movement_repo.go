@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type StockMovementRepo struct {
+	db *sql.DB
+}
+
+func NewStockMovementRepo(db *sql.DB) *StockMovementRepo {
+	return &StockMovementRepo{db: db}
+}
+
+func (r *StockMovementRepo) FindByChemicalID(ctx context.Context, userID uuid.UUID, chemicalID uuid.UUID) ([]entities.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, chemical_id, kind, amount, unit, reason,
+			cost, chemistry_log_id, created_at
+		FROM stock_movements
+		WHERE chemical_id = ? AND user_id = ?
+		ORDER BY created_at ASC, id ASC`, chemicalID.String(), userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("querying stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []entities.StockMovement
+	for rows.Next() {
+		var m entities.StockMovement
+		var idStr, userIDStr, chemicalIDStr, kind, unit, createdAt string
+		var chemLogID *string
+		if err := rows.Scan(&idStr, &userIDStr, &chemicalIDStr, &kind, &m.Amount, &unit, &m.Reason, &m.Cost, &chemLogID, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning stock movement: %w", err)
+		}
+		m.ID = uuid.MustParse(idStr)
+		m.UserID = uuid.MustParse(userIDStr)
+		m.ChemicalID = uuid.MustParse(chemicalIDStr)
+		m.Kind = entities.StockMovementKind(kind)
+		m.Unit = valueobjects.Unit(unit)
+		m.ChemistryLogID = parseUUIDPtr(chemLogID)
+		m.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
+
+func (r *StockMovementRepo) Record(ctx context.Context, c *entities.Chemical, movements ...entities.StockMovement) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range movements {
+		if err := insertStockMovement(ctx, tx, &movements[i]); err != nil {
+			return err
+		}
+	}
+
+	var balance float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(amount), 0) FROM stock_movements
+		WHERE chemical_id = ? AND user_id = ?`, c.ID.String(), c.UserID.String()).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("summing stock movements: %w", err)
+	}
+	c.Reconcile(balance)
+	c.UpdatedAt = time.Now()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE chemicals
+		SET name = ?, type = ?,
+			stock_amount = ?, stock_unit = ?,
+			alert_threshold = ?, daily_usage = ?,
+			last_purchased = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?`,
+		c.Name, string(c.Type), c.Stock.Amount, string(c.Stock.Unit), c.AlertThreshold, c.DailyUsage, fmtTimePtr(c.LastPurchased), c.UpdatedAt.Format(time.RFC3339), c.ID.String(), c.UserID.String())
+	if err != nil {
+		return fmt.Errorf("updating chemical: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing stock movements: %w", err)
+	}
+	return nil
+}
+
+func insertStockMovement(ctx context.Context, tx *sql.Tx, m *entities.StockMovement) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO stock_movements (id, user_id, chemical_id, kind, amount, unit, reason,
+			cost, chemistry_log_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.ID.String(), m.UserID.String(), m.ChemicalID.String(), string(m.Kind), m.Amount, string(m.Unit), m.Reason,
+		m.Cost, formatUUIDPtr(m.ChemistryLogID), m.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting stock movement: %w", err)
+	}
+	return nil
+}
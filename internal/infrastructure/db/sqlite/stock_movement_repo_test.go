@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestStockMovementRepoImplementsInterface(t *testing.T) {
+	var _ repositories.StockMovementRepository = (*StockMovementRepo)(nil)
+}
@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type TaskCompletionRepo struct {
+	db *sql.DB
+}
+
+func NewTaskCompletionRepo(db *sql.DB) *TaskCompletionRepo {
+	return &TaskCompletionRepo{db: db}
+}
+
+func (r *TaskCompletionRepo) FindBySeriesID(ctx context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.TaskCompletion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, task_id, series_id, notes, duration_minutes,
+			chemistry_log_id, service_record_id,
+			completed_at, created_at
+		FROM task_completions
+		WHERE series_id = ? AND user_id = ?
+		ORDER BY completed_at DESC`, seriesID.String(), userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("querying task completions: %w", err)
+	}
+	defer rows.Close()
+
+	var completions []entities.TaskCompletion
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var c entities.TaskCompletion
+		var idStr, userIDStr, taskIDStr, seriesIDStr, completedAt, createdAt string
+		var chemLogID, srID *string
+		if err := rows.Scan(&idStr, &userIDStr, &taskIDStr, &seriesIDStr, &c.Notes, &c.DurationMinutes, &chemLogID, &srID, &completedAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning task completion: %w", err)
+		}
+		c.ID = uuid.MustParse(idStr)
+		c.UserID = uuid.MustParse(userIDStr)
+		c.TaskID = uuid.MustParse(taskIDStr)
+		c.SeriesID = uuid.MustParse(seriesIDStr)
+		c.ChemistryLogID = parseUUIDPtr(chemLogID)
+		c.ServiceRecordID = parseUUIDPtr(srID)
+		c.CompletedAt, _ = time.Parse(time.RFC3339, completedAt)
+		c.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		index[c.ID] = len(completions)
+		completions = append(completions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(completions) == 0 {
+		return completions, nil
+	}
+
+	photoRows, err := r.db.QueryContext(ctx, `
+		SELECT p.id, p.completion_id, p.user_id, p.filename, p.content_type, p.created_at
+		FROM task_completion_photos p
+		JOIN task_completions c ON c.id = p.completion_id
+		WHERE c.series_id = ? AND c.user_id = ?
+		ORDER BY p.created_at ASC`, seriesID.String(), userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("querying task completion photos: %w", err)
+	}
+	defer photoRows.Close()
+
+	for photoRows.Next() {
+		var p entities.TaskPhoto
+		var idStr, completionIDStr, userIDStr, createdAt string
+		if err := photoRows.Scan(&idStr, &completionIDStr, &userIDStr, &p.Filename, &p.ContentType, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning task completion photo: %w", err)
+		}
+		p.ID = uuid.MustParse(idStr)
+		p.CompletionID = uuid.MustParse(completionIDStr)
+		p.UserID = uuid.MustParse(userIDStr)
+		p.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		if i, ok := index[p.CompletionID]; ok {
+			completions[i].Photos = append(completions[i].Photos, p)
+		}
+	}
+	return completions, photoRows.Err()
+}
+
+func (r *TaskCompletionRepo) FindPhoto(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskPhoto, error) {
+	var p entities.TaskPhoto
+	var idStr, completionIDStr, userIDStr, createdAt string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, completion_id, user_id, filename, content_type, data, created_at
+		FROM task_completion_photos
+		WHERE id = ? AND user_id = ?`, id.String(), userID.String()).
+		Scan(&idStr, &completionIDStr, &userIDStr, &p.Filename, &p.ContentType, &p.Data, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying task completion photo: %w", err)
+	}
+	p.ID = uuid.MustParse(idStr)
+	p.CompletionID = uuid.MustParse(completionIDStr)
+	p.UserID = uuid.MustParse(userIDStr)
+	p.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return &p, nil
+}
+
+func (r *TaskCompletionRepo) Create(ctx context.Context, c *entities.TaskCompletion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO task_completions (id, user_id, task_id, series_id, notes, duration_minutes,
+			chemistry_log_id, service_record_id,
+			completed_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID.String(), c.UserID.String(), c.TaskID.String(), c.SeriesID.String(), c.Notes, c.DurationMinutes,
+		formatUUIDPtr(c.ChemistryLogID), formatUUIDPtr(c.ServiceRecordID),
+		c.CompletedAt.Format(time.RFC3339), c.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting task completion: %w", err)
+	}
+
+	for _, p := range c.Photos {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO task_completion_photos (id, completion_id, user_id, filename, content_type, data, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			p.ID.String(), c.ID.String(), c.UserID.String(), p.Filename, p.ContentType, p.Data, p.CreatedAt.Format(time.RFC3339))
+		if err != nil {
+			return fmt.Errorf("inserting task completion photo: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task completion: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskCompletionRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_completion_photos WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("deleting task completion photos: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_completions WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("deleting task completions: %w", err)
+	}
+	return nil
+}
+
+func parseUUIDPtr(s *string) *uuid.UUID {
+	if s == nil || *s == "" {
+		return nil
+	}
+	id, err := uuid.Parse(*s)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+func formatUUIDPtr(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestTaskCompletionRepoImplementsInterface(t *testing.T) {
+	var _ repositories.TaskCompletionRepository = (*TaskCompletionRepo)(nil)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,10 +25,10 @@ func (r *TaskNotificationRepo) Claim(ctx context.Context, notif *entities.TaskNo
 		taskID = notif.TaskID.String()
 	}
 	res, err := r.db.ExecContext(ctx, `
-		INSERT OR IGNORE INTO task_notifications (id, task_id, user_id, type, due_date, sent_at)
-		VALUES (?, ?, ?, ?, ?, ?)`,
+		INSERT OR IGNORE INTO task_notifications (id, task_id, user_id, type, kind, rule, task_ids, due_date, sent_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		notif.ID.String(), taskID, notif.UserID.String(),
-		notif.Type, notif.DueDate.Format("2006-01-02"), notif.SentAt.Format(time.RFC3339))
+		notif.Type, notif.Kind, notif.Rule, joinUUIDs(notif.TaskIDs), notif.DueDate.Format("2006-01-02"), notif.SentAt.Format(time.RFC3339))
 	if err != nil {
 		return false, fmt.Errorf("claiming task notification: %w", err)
 	}
@@ -38,10 +39,48 @@ func (r *TaskNotificationRepo) Claim(ctx context.Context, notif *entities.TaskNo
 	return rows > 0, nil
 }
 
-func (r *TaskNotificationRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM task_notifications WHERE id = ?`, id.String())
+func (r *TaskNotificationRepo) FindLastSentReminder(ctx context.Context, userID uuid.UUID, channel string) (*entities.TaskNotification, error) {
+	var n entities.TaskNotification
+	var id, taskID, uid, taskIDs, dueDate, sentAt string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT n.id, n.task_id, n.user_id, n.type, n.kind, n.rule, n.task_ids, n.due_date, n.sent_at
+		FROM task_notifications n
+		JOIN notification_outbox o ON o.notification_id = n.id
+		WHERE n.user_id = ? AND n.type = ? AND n.kind IN (?, ?) AND o.status = ?
+		ORDER BY o.sent_at DESC LIMIT 1`,
+		userID.String(), channel, entities.NotificationKindDue, entities.NotificationKindOverdue, entities.OutboxSent,
+	).Scan(&id, &taskID, &uid, &n.Type, &n.Kind, &n.Rule, &taskIDs, &dueDate, &sentAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("deleting task notification: %w", err)
+		return nil, fmt.Errorf("querying last sent reminder: %w", err)
+	}
+	n.ID = uuid.MustParse(id)
+	if taskID != "" {
+		n.TaskID = uuid.MustParse(taskID)
+	}
+	n.UserID = uuid.MustParse(uid)
+	n.TaskIDs = splitUUIDs(taskIDs)
+	n.DueDate, _ = time.Parse("2006-01-02", dueDate)
+	n.SentAt, _ = time.Parse(time.RFC3339, sentAt)
+	return &n, nil
+}
+
+func joinUUIDs(ids []uuid.UUID) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return strings.Join(s, ",")
+}
+
+func splitUUIDs(s string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(s, ",") {
+		if id, err := uuid.Parse(part); err == nil {
+			ids = append(ids, id)
+		}
 	}
-	return nil
+	return ids
 }
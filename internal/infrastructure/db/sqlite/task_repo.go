@@ -21,13 +21,13 @@ func NewTaskRepo(db *sql.DB) *TaskRepo {
 
 func (r *TaskRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Task, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at
 		FROM tasks
 		WHERE user_id = ?
-		ORDER BY due_date ASC`, userID.String())
+		ORDER BY COALESCE(snoozed_until, due_date) ASC`, userID.String())
 	if err != nil {
 		return nil, fmt.Errorf("querying tasks: %w", err)
 	}
@@ -39,17 +39,22 @@ func (r *TaskRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Ta
 		if err != nil {
 			return nil, err
 		}
-		t.CheckOverdue()
 		tasks = append(tasks, *t)
 	}
-	return tasks, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachTaskChecklists(ctx, r.db, tasks, `user_id = ?`, userID.String()); err != nil {
+		return nil, err
+	}
+	return tasks, nil
 }
 
 func (r *TaskRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Task, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at
 		FROM tasks
 		WHERE id = ? AND user_id = ?`, id.String(), userID.String())
@@ -60,61 +65,122 @@ func (r *TaskRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID)
 	if err != nil {
 		return nil, fmt.Errorf("querying task: %w", err)
 	}
-	t.CheckOverdue()
-	return t, nil
+	tasks := []entities.Task{*t}
+	if err := attachTaskChecklists(ctx, r.db, tasks, `task_id = ? AND user_id = ?`, id.String(), userID.String()); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+func (r *TaskRepo) FindBySeriesID(ctx context.Context, userID uuid.UUID, seriesID uuid.UUID) ([]entities.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
+			created_at, updated_at
+		FROM tasks
+		WHERE series_id = ? AND user_id = ?
+		ORDER BY due_date DESC`, seriesID.String(), userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("querying task series: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []entities.Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachTaskChecklists(ctx, r.db, tasks, `task_id IN (SELECT id FROM tasks WHERE series_id = ? AND user_id = ?)`, seriesID.String(), userID.String()); err != nil {
+		return nil, err
+	}
+	return tasks, nil
 }
 
 func (r *TaskRepo) Create(ctx context.Context, t *entities.Task) error {
-	var completedAt *string
-	if t.CompletedAt != nil {
-		s := t.CompletedAt.Format(time.RFC3339)
-		completedAt = &s
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
 	}
-	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO tasks (id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO tasks (id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		t.ID.String(), t.UserID.String(), t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, t.DueDate.Format(time.RFC3339), string(t.Status), completedAt, t.CreatedAt.Format(time.RFC3339), t.UpdatedAt.Format(time.RFC3339))
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.ID.String(), t.UserID.String(), t.SeriesID.String(), t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, string(t.Recurrence.Anchor), formatUUIDPtr(t.EquipmentID), t.DueDate.Format(time.RFC3339), string(t.Status), fmtTimePtr(t.CompletedAt), fmtTimePtr(t.SkippedAt), fmtTimePtr(t.SnoozedUntil), fmtTimePtr(t.OverdueAt), t.CreatedAt.Format(time.RFC3339), t.UpdatedAt.Format(time.RFC3339))
 	if err != nil {
 		return fmt.Errorf("inserting task: %w", err)
 	}
+	if err := insertTaskChecklist(ctx, tx, t); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task: %w", err)
+	}
+	return nil
+}
+
+// SaveChecklist replaces the task's checklist items, including their
+// checked state.
+func (r *TaskRepo) SaveChecklist(ctx context.Context, t *entities.Task) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_checklist_items WHERE task_id = ? AND user_id = ?`, t.ID.String(), t.UserID.String()); err != nil {
+		return fmt.Errorf("deleting task checklist items: %w", err)
+	}
+	if err := insertTaskChecklist(ctx, tx, t); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task checklist: %w", err)
+	}
 	return nil
 }
 
 func (r *TaskRepo) Update(ctx context.Context, t *entities.Task) error {
 	t.UpdatedAt = time.Now()
-	var completedAt *string
-	if t.CompletedAt != nil {
-		s := t.CompletedAt.Format(time.RFC3339)
-		completedAt = &s
-	}
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE tasks
 		SET name = ?, description = ?,
-			recurrence_frequency = ?, recurrence_interval = ?,
-			due_date = ?, status = ?, completed_at = ?,
+			recurrence_frequency = ?, recurrence_interval = ?, recurrence_anchor = ?,
+			equipment_id = ?, due_date = ?, status = ?, completed_at = ?,
+			skipped_at = ?, snoozed_until = ?, overdue_at = ?,
 			updated_at = ?
 		WHERE id = ? AND user_id = ?`,
-		t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, t.DueDate.Format(time.RFC3339), string(t.Status), completedAt, t.UpdatedAt.Format(time.RFC3339), t.ID.String(), t.UserID.String())
+		t.Name, t.Description, string(t.Recurrence.Frequency), t.Recurrence.Interval, string(t.Recurrence.Anchor), formatUUIDPtr(t.EquipmentID), t.DueDate.Format(time.RFC3339), string(t.Status), fmtTimePtr(t.CompletedAt), fmtTimePtr(t.SkippedAt), fmtTimePtr(t.SnoozedUntil), fmtTimePtr(t.OverdueAt), t.UpdatedAt.Format(time.RFC3339), t.ID.String(), t.UserID.String())
 	if err != nil {
 		return fmt.Errorf("updating task: %w", err)
 	}
 	return nil
 }
 
-func (r *TaskRepo) FindDueOnDate(ctx context.Context, date time.Time) ([]entities.Task, error) {
+func (r *TaskRepo) FindDueOnDate(ctx context.Context, timezone string, date time.Time) ([]entities.Task, error) {
 	startOfDay := date.Format("2006-01-02") + "T00:00:00Z"
 	endOfDay := date.AddDate(0, 0, 1).Format("2006-01-02") + "T00:00:00Z"
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, user_id, name, description,
-			recurrence_frequency, recurrence_interval,
-			due_date, status, completed_at,
+		SELECT id, user_id, series_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			due_date, status, completed_at, skipped_at, snoozed_until, overdue_at,
 			created_at, updated_at
 		FROM tasks
-		WHERE due_date >= ? AND due_date < ? AND status = 'pending'
-		ORDER BY due_date ASC`, startOfDay, endOfDay)
+		WHERE COALESCE(snoozed_until, due_date) >= ? AND COALESCE(snoozed_until, due_date) < ? AND status IN ('pending', 'overdue')
+			AND user_id IN (SELECT id FROM users WHERE timezone = ?)
+		ORDER BY COALESCE(snoozed_until, due_date) ASC`, startOfDay, endOfDay, timezone)
 	if err != nil {
 		return nil, fmt.Errorf("querying tasks due on date: %w", err)
 	}
@@ -131,7 +197,27 @@ func (r *TaskRepo) FindDueOnDate(ctx context.Context, date time.Time) ([]entitie
 	return tasks, rows.Err()
 }
 
+func (r *TaskRepo) MarkOverdue(ctx context.Context, timezone string, dueBefore, now time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE tasks
+		SET status = 'overdue', overdue_at = ?, updated_at = ?
+		WHERE status = 'pending' AND COALESCE(snoozed_until, due_date) < ?
+			AND user_id IN (SELECT id FROM users WHERE timezone = ?)`,
+		now.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339), dueBefore.Format(time.RFC3339), timezone)
+	if err != nil {
+		return 0, fmt.Errorf("marking tasks overdue: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
 func (r *TaskRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_checklist_items WHERE task_id = ? AND user_id = ?`, id.String(), userID.String()); err != nil {
+		return fmt.Errorf("deleting task checklist items: %w", err)
+	}
 	_, err := r.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = ? AND user_id = ?`, id.String(), userID.String())
 	if err != nil {
 		return fmt.Errorf("deleting task: %w", err)
@@ -145,23 +231,25 @@ type scanner interface {
 
 func scanTaskFromRow(s scanner) (*entities.Task, error) {
 	var t entities.Task
-	var idStr, userIDStr, freq, dueDate, status, createdAt, updatedAt string
+	var idStr, userIDStr, seriesIDStr, freq, anchor, dueDate, status, createdAt, updatedAt string
 	var interval int
-	var completedAt *string
-	if err := s.Scan(&idStr, &userIDStr, &t.Name, &t.Description, &freq, &interval, &dueDate, &status, &completedAt, &createdAt, &updatedAt); err != nil {
+	var equipmentID, completedAt, skippedAt, snoozedUntil, overdueAt *string
+	if err := s.Scan(&idStr, &userIDStr, &seriesIDStr, &t.Name, &t.Description, &freq, &interval, &anchor, &equipmentID, &dueDate, &status, &completedAt, &skippedAt, &snoozedUntil, &overdueAt, &createdAt, &updatedAt); err != nil {
 		return nil, err
 	}
 	t.ID = uuid.MustParse(idStr)
 	t.UserID = uuid.MustParse(userIDStr)
-	t.Recurrence = valueobjects.Recurrence{Frequency: valueobjects.Frequency(freq), Interval: interval}
+	t.SeriesID = uuid.MustParse(seriesIDStr)
+	t.Recurrence = valueobjects.Recurrence{Frequency: valueobjects.Frequency(freq), Interval: interval, Anchor: valueobjects.Anchor(anchor)}
+	t.EquipmentID = parseUUIDPtr(equipmentID)
 	t.DueDate, _ = time.Parse(time.RFC3339, dueDate)
 	t.Status = entities.TaskStatus(status)
 	t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	t.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-	if completedAt != nil {
-		ca, _ := time.Parse(time.RFC3339, *completedAt)
-		t.CompletedAt = &ca
-	}
+	t.CompletedAt = parseTimePtr(completedAt)
+	t.SkippedAt = parseTimePtr(skippedAt)
+	t.SnoozedUntil = parseTimePtr(snoozedUntil)
+	t.OverdueAt = parseTimePtr(overdueAt)
 	return &t, nil
 }
 
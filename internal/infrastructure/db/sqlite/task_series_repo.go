@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type TaskSeriesRepo struct {
+	db *sql.DB
+}
+
+func NewTaskSeriesRepo(db *sql.DB) *TaskSeriesRepo {
+	return &TaskSeriesRepo{db: db}
+}
+
+func (r *TaskSeriesRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.TaskSeries, error) {
+	var s entities.TaskSeries
+	var idStr, userIDStr, freq, anchor, createdAt, updatedAt string
+	var equipmentID, endedAt *string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, user_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			ended_at, created_at, updated_at
+		FROM task_series
+		WHERE id = ? AND user_id = ?`, id.String(), userID.String()).
+		Scan(&idStr, &userIDStr, &s.Name, &s.Description, &freq, &s.Recurrence.Interval, &anchor, &equipmentID, &endedAt, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying task series: %w", err)
+	}
+	s.ID = uuid.MustParse(idStr)
+	s.UserID = uuid.MustParse(userIDStr)
+	s.Recurrence.Frequency = valueobjects.Frequency(freq)
+	s.Recurrence.Anchor = valueobjects.Anchor(anchor)
+	s.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	s.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	s.EquipmentID = parseUUIDPtr(equipmentID)
+	s.EndedAt = parseTimePtr(endedAt)
+	if s.Checklist, err = findSeriesChecklist(ctx, r.db, s.ID); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *TaskSeriesRepo) Create(ctx context.Context, s *entities.TaskSeries) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO task_series (id, user_id, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, equipment_id,
+			ended_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		s.ID.String(), s.UserID.String(), s.Name, s.Description, string(s.Recurrence.Frequency), s.Recurrence.Interval, string(s.Recurrence.Anchor), formatUUIDPtr(s.EquipmentID), fmtTimePtr(s.EndedAt), s.CreatedAt.Format(time.RFC3339), s.UpdatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting task series: %w", err)
+	}
+	if err := replaceSeriesChecklist(ctx, tx, s); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task series: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskSeriesRepo) Update(ctx context.Context, s *entities.TaskSeries) error {
+	s.UpdatedAt = time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE task_series
+		SET name = ?, description = ?,
+			recurrence_frequency = ?, recurrence_interval = ?, recurrence_anchor = ?,
+			equipment_id = ?, ended_at = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?`,
+		s.Name, s.Description, string(s.Recurrence.Frequency), s.Recurrence.Interval, string(s.Recurrence.Anchor), formatUUIDPtr(s.EquipmentID), fmtTimePtr(s.EndedAt), s.UpdatedAt.Format(time.RFC3339), s.ID.String(), s.UserID.String())
+	if err != nil {
+		return fmt.Errorf("updating task series: %w", err)
+	}
+	if err := replaceSeriesChecklist(ctx, tx, s); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task series: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskSeriesRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_series_checklist_items WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("deleting series checklist items: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM task_series WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("deleting task series: %w", err)
+	}
+	return nil
+}
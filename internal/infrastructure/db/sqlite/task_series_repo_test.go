@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestTaskSeriesRepoImplementsInterface(t *testing.T) {
+	var _ repositories.TaskSeriesRepository = (*TaskSeriesRepo)(nil)
+}
@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+type TaskTemplateRepo struct {
+	db *sql.DB
+}
+
+func NewTaskTemplateRepo(db *sql.DB) *TaskTemplateRepo {
+	return &TaskTemplateRepo{db: db}
+}
+
+func (r *TaskTemplateRepo) FindAvailable(ctx context.Context, userID uuid.UUID) ([]entities.TaskTemplate, error) {
+	return r.findWhere(ctx, `user_id IS NULL OR user_id = ?`, userID.String())
+}
+
+func (r *TaskTemplateRepo) FindSiteWide(ctx context.Context) ([]entities.TaskTemplate, error) {
+	return r.findWhere(ctx, `user_id IS NULL`)
+}
+
+func (r *TaskTemplateRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.TaskTemplate, error) {
+	templates, err := r.findWhere(ctx, `id = ?`, id.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	return &templates[0], nil
+}
+
+// findWhere loads the templates matching where, site-wide first, along with
+// their items in order.
+func (r *TaskTemplateRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.TaskTemplate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, name, description, created_at, updated_at
+		FROM task_templates
+		WHERE `+where+`
+		ORDER BY user_id IS NOT NULL, name ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying task templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []entities.TaskTemplate
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var t entities.TaskTemplate
+		var idStr, createdAt, updatedAt string
+		var userID *string
+		if err := rows.Scan(&idStr, &userID, &t.Name, &t.Description, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scanning task template: %w", err)
+		}
+		t.ID = uuid.MustParse(idStr)
+		t.UserID = parseUUIDPtr(userID)
+		t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		t.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		index[t.ID] = len(templates)
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return templates, nil
+	}
+
+	itemRows, err := r.db.QueryContext(ctx, `
+		SELECT id, template_id, position, name, description,
+			recurrence_frequency, recurrence_interval, recurrence_anchor, due_in_days
+		FROM task_template_items
+		WHERE template_id IN (SELECT id FROM task_templates WHERE `+where+`)
+		ORDER BY position ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying task template items: %w", err)
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var item entities.TaskTemplateItem
+		var idStr, templateIDStr, freq, anchor string
+		if err := itemRows.Scan(&idStr, &templateIDStr, &item.Position, &item.Name, &item.Description, &freq, &item.Recurrence.Interval, &anchor, &item.DueInDays); err != nil {
+			return nil, fmt.Errorf("scanning task template item: %w", err)
+		}
+		item.ID = uuid.MustParse(idStr)
+		item.TemplateID = uuid.MustParse(templateIDStr)
+		item.Recurrence.Frequency = valueobjects.Frequency(freq)
+		item.Recurrence.Anchor = valueobjects.Anchor(anchor)
+		if i, ok := index[item.TemplateID]; ok {
+			templates[i].Items = append(templates[i].Items, item)
+		}
+	}
+	return templates, itemRows.Err()
+}
+
+func (r *TaskTemplateRepo) Create(ctx context.Context, t *entities.TaskTemplate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO task_templates (id, user_id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		t.ID.String(), formatUUIDPtr(t.UserID), t.Name, t.Description, t.CreatedAt.Format(time.RFC3339), t.UpdatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting task template: %w", err)
+	}
+	if err := insertTaskTemplateItems(ctx, tx, t); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task template: %w", err)
+	}
+	return nil
+}
+
+// Update saves the template details and replaces its items.
+func (r *TaskTemplateRepo) Update(ctx context.Context, t *entities.TaskTemplate) error {
+	t.UpdatedAt = time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE task_templates
+		SET name = ?, description = ?, updated_at = ?
+		WHERE id = ?`,
+		t.Name, t.Description, t.UpdatedAt.Format(time.RFC3339), t.ID.String())
+	if err != nil {
+		return fmt.Errorf("updating task template: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_template_items WHERE template_id = ?`, t.ID.String()); err != nil {
+		return fmt.Errorf("deleting task template items: %w", err)
+	}
+	if err := insertTaskTemplateItems(ctx, tx, t); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task template: %w", err)
+	}
+	return nil
+}
+
+func insertTaskTemplateItems(ctx context.Context, tx *sql.Tx, t *entities.TaskTemplate) error {
+	for _, item := range t.Items {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO task_template_items (id, template_id, position, name, description,
+				recurrence_frequency, recurrence_interval, recurrence_anchor, due_in_days)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			item.ID.String(), t.ID.String(), item.Position, item.Name, item.Description,
+			string(item.Recurrence.Frequency), item.Recurrence.Interval, string(item.Recurrence.Anchor), item.DueInDays)
+		if err != nil {
+			return fmt.Errorf("inserting task template item: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *TaskTemplateRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_template_items WHERE template_id = ?`, id.String()); err != nil {
+		return fmt.Errorf("deleting task template items: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_templates WHERE id = ?`, id.String()); err != nil {
+		return fmt.Errorf("deleting task template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task template delete: %w", err)
+	}
+	return nil
+}
+
+func (r *TaskTemplateRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM task_template_items
+		WHERE template_id IN (SELECT id FROM task_templates WHERE user_id = ?)`, userID.String()); err != nil {
+		return fmt.Errorf("deleting task template items: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_templates WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("deleting task templates: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing task templates delete: %w", err)
+	}
+	return nil
+}
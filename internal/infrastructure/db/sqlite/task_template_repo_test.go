@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestTaskTemplateRepoImplementsInterface(t *testing.T) {
+	var _ repositories.TaskTemplateRepository = (*TaskTemplateRepo)(nil)
+}
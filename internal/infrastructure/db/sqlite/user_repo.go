@@ -18,12 +18,17 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 	return &UserRepo{db: db}
 }
 
+// userColumns lists the columns read by scanUserFromRow, in order.
+const userColumns = `id, email, password_hash, is_admin, is_disabled,
+			is_demo, demo_expires_at,
+			phone, phone_verified_at, phone_code_hash, phone_code_sent_at, phone_code_expires_at, phone_code_attempts,
+			sms_opted_out, push_service, push_url, push_token, pool_gallons, timezone, calendar_token,
+			no_test_alert_days, digest_weekday, digest_hour,
+			quiet_hours_enabled, quiet_hours_start, quiet_hours_end, created_at, updated_at`
+
 func (r *UserRepo) FindAll(ctx context.Context) ([]entities.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		ORDER BY created_at DESC`)
 	if err != nil {
@@ -44,10 +49,7 @@ func (r *UserRepo) FindAll(ctx context.Context) ([]entities.User, error) {
 
 func (r *UserRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE id = ?`, id.String())
 	u, err := scanUserRow(row)
@@ -62,10 +64,7 @@ func (r *UserRepo) FindByID(ctx context.Context, id uuid.UUID) (*entities.User,
 
 func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE email = ?`, email)
 	u, err := scanUserRow(row)
@@ -78,21 +77,47 @@ func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*entities.Use
 	return u, nil
 }
 
+// FindByCalendarToken returns the user whose calendar feed uses token, or
+// nil if there is none.
+func (r *UserRepo) FindByCalendarToken(ctx context.Context, token string) (*entities.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE calendar_token = ?`, token)
+	u, err := scanUserRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying user by calendar token: %w", err)
+	}
+	return u, nil
+}
+
 func (r *UserRepo) Create(ctx context.Context, u *entities.User) error {
 	var demoExpiresAt *string
 	if u.DemoExpiresAt != nil {
 		s := u.DemoExpiresAt.Format(time.RFC3339)
 		demoExpiresAt = &s
 	}
+	code, codeSentAt, codeExpiresAt := phoneCodeColumns(u)
 	_, err := r.db.ExecContext(ctx, `
 		INSERT INTO users (id, email, password_hash, is_admin,
 			is_disabled, is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			phone, phone_verified_at, phone_code_hash, phone_code_sent_at, phone_code_expires_at, phone_code_attempts,
+			sms_opted_out, push_service, push_url, push_token, pool_gallons, timezone, calendar_token,
+			no_test_alert_days, digest_weekday, digest_hour,
+			quiet_hours_enabled, quiet_hours_start, quiet_hours_end, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		u.ID.String(), u.Email, u.PasswordHash, boolToInt(u.IsAdmin), boolToInt(u.IsDisabled),
 		boolToInt(u.IsDemo), demoExpiresAt,
-		u.Phone, boolToInt(u.NotifyEmail), boolToInt(u.NotifySMS), u.PoolGallons,
+		u.Phone, fmtUTCPtr(u.PhoneVerifiedAt), code.Hash, fmtUTCPtr(codeSentAt), fmtUTCPtr(codeExpiresAt), code.Attempts,
+		boolToInt(u.SMSOptedOut), u.Push.Service, u.Push.URL, u.Push.Token, u.PoolGallons, u.Timezone, u.CalendarToken,
+		u.NoTestAlertDays, int(u.Digest.Weekday), u.Digest.Hour,
+		boolToInt(u.QuietHours.Enabled), u.QuietHours.Start, u.QuietHours.End,
 		u.CreatedAt.Format(time.RFC3339), u.UpdatedAt.Format(time.RFC3339))
 	if err != nil {
 		return fmt.Errorf("inserting user: %w", err)
@@ -107,18 +132,25 @@ func (r *UserRepo) Update(ctx context.Context, u *entities.User) error {
 		s := u.DemoExpiresAt.Format(time.RFC3339)
 		demoExpiresAt = &s
 	}
+	code, codeSentAt, codeExpiresAt := phoneCodeColumns(u)
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE users
 		SET email = ?, password_hash = ?,
 			is_admin = ?, is_disabled = ?,
 			is_demo = ?, demo_expires_at = ?,
-			phone = ?, notify_email = ?, notify_sms = ?,
-			pool_gallons = ?, updated_at = ?
+			phone = ?, phone_verified_at = ?, phone_code_hash = ?, phone_code_sent_at = ?, phone_code_expires_at = ?, phone_code_attempts = ?,
+			sms_opted_out = ?, push_service = ?, push_url = ?, push_token = ?, pool_gallons = ?, timezone = ?, calendar_token = ?,
+			no_test_alert_days = ?, digest_weekday = ?, digest_hour = ?,
+			quiet_hours_enabled = ?, quiet_hours_start = ?, quiet_hours_end = ?,
+			updated_at = ?
 		WHERE id = ?`,
 		u.Email, u.PasswordHash, boolToInt(u.IsAdmin), boolToInt(u.IsDisabled),
 		boolToInt(u.IsDemo), demoExpiresAt,
-		u.Phone, boolToInt(u.NotifyEmail), boolToInt(u.NotifySMS),
-		u.PoolGallons, u.UpdatedAt.Format(time.RFC3339), u.ID.String())
+		u.Phone, fmtUTCPtr(u.PhoneVerifiedAt), code.Hash, fmtUTCPtr(codeSentAt), fmtUTCPtr(codeExpiresAt), code.Attempts,
+		boolToInt(u.SMSOptedOut), u.Push.Service, u.Push.URL, u.Push.Token, u.PoolGallons, u.Timezone, u.CalendarToken,
+		u.NoTestAlertDays, int(u.Digest.Weekday), u.Digest.Hour,
+		boolToInt(u.QuietHours.Enabled), u.QuietHours.Start, u.QuietHours.End,
+		u.UpdatedAt.Format(time.RFC3339), u.ID.String())
 	if err != nil {
 		return fmt.Errorf("updating user: %w", err)
 	}
@@ -142,6 +174,70 @@ func (r *UserRepo) CountDemo(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+func (r *UserRepo) FindTimezones(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT timezone FROM users WHERE is_disabled = 0`)
+	if err != nil {
+		return nil, fmt.Errorf("querying user timezones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []string
+	for rows.Next() {
+		var zone string
+		if err := rows.Scan(&zone); err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+func (r *UserRepo) FindByTimezone(ctx context.Context, timezone string) ([]entities.User, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE timezone = ? AND is_disabled = 0`, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("querying users by timezone: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entities.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, rows.Err()
+}
+
+// FindByPhone returns the enabled users with the phone number phone.
+func (r *UserRepo) FindByPhone(ctx context.Context, phone string) ([]entities.User, error) {
+	if phone == "" {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE phone = ? AND is_disabled = 0`, phone)
+	if err != nil {
+		return nil, fmt.Errorf("querying users by phone: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entities.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, rows.Err()
+}
+
 func (r *UserRepo) CountAdmins(ctx context.Context) (int, error) {
 	var count int
 	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE is_admin = 1 AND is_disabled = 0`).Scan(&count)
@@ -160,10 +256,7 @@ func boolToInt(b bool) int {
 
 func (r *UserRepo) FindExpiredDemo(ctx context.Context, now time.Time) ([]entities.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, email, password_hash, is_admin, is_disabled,
-			is_demo, demo_expires_at,
-			phone, notify_email, notify_sms, pool_gallons,
-			created_at, updated_at
+		SELECT `+userColumns+`
 		FROM users
 		WHERE is_demo = 1 AND demo_expires_at < ?`, now.Format(time.RFC3339))
 	if err != nil {
@@ -185,12 +278,15 @@ func (r *UserRepo) FindExpiredDemo(ctx context.Context, now time.Time) ([]entiti
 func scanUserFromRow(s scanner) (*entities.User, error) {
 	var u entities.User
 	var idStr, createdAt, updatedAt string
-	var isAdmin, isDisabled, isDemo, notifyEmail, notifySMS int
-	var demoExpiresAt *string
+	var isAdmin, isDisabled, isDemo, smsOptedOut, quietEnabled int
+	var demoExpiresAt, phoneVerifiedAt, codeSentAt, codeExpiresAt *string
+	var code entities.PhoneCode
 	if err := s.Scan(&idStr, &u.Email, &u.PasswordHash, &isAdmin, &isDisabled,
 		&isDemo, &demoExpiresAt,
-		&u.Phone, &notifyEmail, &notifySMS, &u.PoolGallons,
-		&createdAt, &updatedAt); err != nil {
+		&u.Phone, &phoneVerifiedAt, &code.Hash, &codeSentAt, &codeExpiresAt, &code.Attempts,
+		&smsOptedOut, &u.Push.Service, &u.Push.URL, &u.Push.Token, &u.PoolGallons, &u.Timezone, &u.CalendarToken,
+		&u.NoTestAlertDays, &u.Digest.Weekday, &u.Digest.Hour,
+		&quietEnabled, &u.QuietHours.Start, &u.QuietHours.End, &createdAt, &updatedAt); err != nil {
 		return nil, err
 	}
 	u.ID = uuid.MustParse(idStr)
@@ -201,13 +297,28 @@ func scanUserFromRow(s scanner) (*entities.User, error) {
 		t, _ := time.Parse(time.RFC3339, *demoExpiresAt)
 		u.DemoExpiresAt = &t
 	}
-	u.NotifyEmail = notifyEmail == 1
-	u.NotifySMS = notifySMS == 1
+	u.PhoneVerifiedAt = parseTimePtr(phoneVerifiedAt)
+	if code.Hash != "" && codeSentAt != nil && codeExpiresAt != nil {
+		code.SentAt = *parseTimePtr(codeSentAt)
+		code.ExpiresAt = *parseTimePtr(codeExpiresAt)
+		u.PhoneCode = &code
+	}
+	u.SMSOptedOut = smsOptedOut == 1
+	u.QuietHours.Enabled = quietEnabled == 1
 	u.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	u.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 	return &u, nil
 }
 
+// phoneCodeColumns splits the user's pending verification code into its
+// columns, all zero if there is none.
+func phoneCodeColumns(u *entities.User) (code entities.PhoneCode, sentAt, expiresAt *time.Time) {
+	if u.PhoneCode == nil {
+		return entities.PhoneCode{}, nil, nil
+	}
+	return *u.PhoneCode, &u.PhoneCode.SentAt, &u.PhoneCode.ExpiresAt
+}
+
 func scanUser(rows *sql.Rows) (*entities.User, error) {
 	return scanUserFromRow(rows)
 }
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type WebhookDeliveryRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliveryRepo(db *sql.DB) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{db: db}
+}
+
+const deliveryColumns = `id, webhook_id, user_id, event, payload, status, attempts, response_code, last_error,
+	next_attempt_at, created_at, updated_at`
+
+func (r *WebhookDeliveryRepo) FindRecent(ctx context.Context, userID uuid.UUID, limit int) ([]entities.WebhookDelivery, error) {
+	return r.findWhere(ctx, "user_id = ? ORDER BY created_at DESC LIMIT ?", userID.String(), limit)
+}
+
+func (r *WebhookDeliveryRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	deliveries, err := r.findWhere(ctx, "id = ? AND user_id = ?", id.String(), userID.String())
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+func (r *WebhookDeliveryRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at ASC LIMIT ?
+		)
+		RETURNING `+deliveryColumns,
+		leaseUntil.UTC().Format(time.RFC3339), entities.DeliveryPending, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+	return scanDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+	return scanDeliveries(rows)
+}
+
+func scanDeliveries(rows *sql.Rows) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+	for rows.Next() {
+		var d entities.WebhookDelivery
+		var idStr, hookIDStr, userIDStr, event, payload, createdAt, updatedAt string
+		var nextAttempt *string
+		if err := rows.Scan(&idStr, &hookIDStr, &userIDStr, &event, &payload, &d.Status, &d.Attempts, &d.ResponseCode, &d.LastError,
+			&nextAttempt, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scanning webhook delivery: %w", err)
+		}
+		d.ID = uuid.MustParse(idStr)
+		d.WebhookID = uuid.MustParse(hookIDStr)
+		d.UserID = uuid.MustParse(userIDStr)
+		d.Event = entities.WebhookEvent(event)
+		d.Payload = []byte(payload)
+		d.NextAttemptAt = parseTimePtr(nextAttempt)
+		d.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		d.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookDeliveryRepo) Create(ctx context.Context, d *entities.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, webhook_id, user_id, event, payload, status, attempts, response_code, last_error,
+			next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		d.ID.String(), d.WebhookID.String(), d.UserID.String(), string(d.Event), string(d.Payload), d.Status, d.Attempts, d.ResponseCode, d.LastError,
+		fmtUTCPtr(d.NextAttemptAt), d.CreatedAt.Format(time.RFC3339), d.UpdatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepo) Update(ctx context.Context, d *entities.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, response_code = ?, last_error = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ?`,
+		d.Status, d.Attempts, d.ResponseCode, d.LastError, fmtUTCPtr(d.NextAttemptAt), d.UpdatedAt.Format(time.RFC3339), d.ID.String())
+	if err != nil {
+		return fmt.Errorf("updating webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// fmtUTCPtr formats t in UTC, so stored times compare correctly as text.
+func fmtUTCPtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return fmtTimePtr(&u)
+}
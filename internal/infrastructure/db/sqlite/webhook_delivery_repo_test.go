@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestWebhookDeliveryRepoImplementsInterface(t *testing.T) {
+	var _ repositories.WebhookDeliveryRepository = (*WebhookDeliveryRepo)(nil)
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+type WebhookRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookRepo(db *sql.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+func (r *WebhookRepo) FindAll(ctx context.Context, userID uuid.UUID) ([]entities.Webhook, error) {
+	return r.findWhere(ctx, "user_id = ?", userID.String())
+}
+
+func (r *WebhookRepo) FindByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*entities.Webhook, error) {
+	hooks, err := r.findWhere(ctx, "id = ? AND user_id = ?", id.String(), userID.String())
+	if err != nil || len(hooks) == 0 {
+		return nil, err
+	}
+	return &hooks[0], nil
+}
+
+func (r *WebhookRepo) FindByTimezone(ctx context.Context, timezone string) ([]entities.Webhook, error) {
+	return r.findWhere(ctx, "user_id IN (SELECT id FROM users WHERE timezone = ? AND is_disabled = 0)", timezone)
+}
+
+func (r *WebhookRepo) findWhere(ctx context.Context, where string, args ...any) ([]entities.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, url, secret, events, created_at
+		FROM webhooks
+		WHERE `+where+`
+		ORDER BY created_at ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []entities.Webhook
+	for rows.Next() {
+		var h entities.Webhook
+		var idStr, userIDStr, events, createdAt string
+		if err := rows.Scan(&idStr, &userIDStr, &h.URL, &h.Secret, &events, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning webhook: %w", err)
+		}
+		h.ID = uuid.MustParse(idStr)
+		h.UserID = uuid.MustParse(userIDStr)
+		h.Events = splitEvents(events)
+		h.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		hooks = append(hooks, h)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, hook *entities.Webhook) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhooks (id, user_id, url, secret, events, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		hook.ID.String(), hook.UserID.String(), hook.URL, hook.Secret, joinEvents(hook.Events), hook.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ? AND user_id = ?`, id.String(), userID.String())
+	if err != nil {
+		return fmt.Errorf("deleting webhook: %w", err)
+	}
+	return nil
+}
+
+func joinEvents(events []entities.WebhookEvent) string {
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = string(e)
+	}
+	return strings.Join(s, ",")
+}
+
+func splitEvents(s string) []entities.WebhookEvent {
+	var events []entities.WebhookEvent
+	for _, e := range strings.Split(s, ",") {
+		if e != "" {
+			events = append(events, entities.WebhookEvent(e))
+		}
+	}
+	return events
+}
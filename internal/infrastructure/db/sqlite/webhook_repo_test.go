@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/repositories"
+)
+
+func TestWebhookRepoImplementsInterface(t *testing.T) {
+	var _ repositories.WebhookRepository = (*WebhookRepo)(nil)
+}
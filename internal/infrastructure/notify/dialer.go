@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when a user-supplied URL resolves to an
+// address on the server's own network.
+var ErrPrivateAddress = errors.New("refusing to connect to a private address")
+
+// sharedAddressSpace is carrier-grade NAT space (RFC 6598), which, like
+// private space, usually means the server's own network.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// newUserURLClient returns a client for posting to URLs that users enter,
+// such as webhooks and push servers. Unless allowPrivate is set, it refuses
+// to connect to loopback, private, link-local (including cloud metadata
+// endpoints) and other non-public addresses. The check runs on the address actually
+// dialled, after DNS resolution, so a hostname can't be rebound to one
+// after the URL was saved.
+func newUserURLClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = refusePrivate
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Connect directly, so the check sees the destination, not a proxy.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+// refusePrivate is a net.Dialer Control function that fails unless address
+// is a public IP. The dial error already names the address.
+func refusePrivate(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !publicAddr(addrPort.Addr().Unmap()) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+func publicAddr(ip netip.Addr) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// PushNotifier posts push notifications to the ntfy topic or Gotify
+// server a user has set up. The recipient is an entities.PushTarget
+// address, and the message's Push options set its priority, tags and
+// link.
+type PushNotifier struct {
+	client *http.Client
+}
+
+// NewPushNotifier returns a notifier that refuses to post to private
+// addresses unless allowPrivate is set, as for webhooks.
+func NewPushNotifier(allowPrivate bool) *PushNotifier {
+	return &PushNotifier{client: newUserURLClient(allowPrivate)}
+}
+
+func (n *PushNotifier) Send(ctx context.Context, to string, msg valueobjects.Message) error {
+	service, target, ok := entities.ParsePushAddress(to)
+	if !ok {
+		return fmt.Errorf("invalid push address %q", to)
+	}
+	priority := msg.Push.Priority
+	if priority < 1 || priority > 5 {
+		priority = valueobjects.PushPriorityDefault
+	}
+	switch service {
+	case entities.PushGotify:
+		return n.sendGotify(ctx, target, msg, priority)
+	default:
+		return n.sendNtfy(ctx, target, msg, priority)
+	}
+}
+
+// ntfyMessage is ntfy's JSON publishing format.
+type ntfyMessage struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title,omitempty"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags,omitempty"`
+	Click    string   `json:"click,omitempty"`
+}
+
+// sendNtfy publishes to the topic at topicURL. JSON is posted to the
+// server's root, which takes UTF-8 titles that headers can't carry.
+func (n *PushNotifier) sendNtfy(ctx context.Context, topicURL string, msg valueobjects.Message, priority int) error {
+	i := strings.LastIndex(topicURL, "/")
+	server, topic := topicURL[:i+1], topicURL[i+1:]
+	body := ntfyMessage{
+		Topic:    topic,
+		Title:    msg.Subject,
+		Message:  msg.Text,
+		Priority: priority,
+		Click:    msg.Push.Click,
+	}
+	if msg.Push.Tags != "" {
+		body.Tags = strings.Split(msg.Push.Tags, ",")
+	}
+	header := http.Header{}
+	if msg.PushToken != "" {
+		header.Set("Authorization", "Bearer "+msg.PushToken)
+	}
+	return n.post(ctx, "ntfy", server, header, body)
+}
+
+// gotifyMessage is the body of Gotify's create message API.
+type gotifyMessage struct {
+	Title    string         `json:"title,omitempty"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// gotifyPriorities maps push priorities onto Gotify's 0-10 scale, where
+// apps only make a sound from 4 and pop up from 8.
+var gotifyPriorities = [...]int{1: 1, 2: 3, 3: 5, 4: 8, 5: 10}
+
+// sendGotify creates a message on the Gotify server at serverURL. Gotify
+// has no tags, so they are left out.
+func (n *PushNotifier) sendGotify(ctx context.Context, serverURL string, msg valueobjects.Message, priority int) error {
+	body := gotifyMessage{
+		Title:    msg.Subject,
+		Message:  msg.Text,
+		Priority: gotifyPriorities[priority],
+	}
+	if msg.Push.Click != "" {
+		body.Extras = map[string]any{
+			"client::notification": map[string]any{"click": map[string]string{"url": msg.Push.Click}},
+		}
+	}
+	header := http.Header{}
+	header.Set("X-Gotify-Key", msg.PushToken)
+	return n.post(ctx, "Gotify", serverURL+"/message", header, body)
+}
+
+func (n *PushNotifier) post(ctx context.Context, service, url string, header http.Header, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encoding %s message: %w", service, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building %s request: %w", service, err)
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PoolVibes/1.0")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending push notification via %s: %w", service, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", service, resp.Status)
+	}
+	return nil
+}
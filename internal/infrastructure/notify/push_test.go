@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// pushServer is a stand-in for an ntfy or Gotify server that records the
+// last request it was sent.
+type pushServer struct {
+	*httptest.Server
+	path   string
+	header http.Header
+	body   map[string]any
+}
+
+func newPushServer(t *testing.T, status int) *pushServer {
+	t.Helper()
+	s := &pushServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.path, s.header = r.URL.Path, r.Header
+		if err := json.NewDecoder(r.Body).Decode(&s.body); err != nil {
+			t.Errorf("decoding push request: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func testPushMessage() valueobjects.Message {
+	return valueobjects.Message{
+		Subject: "PoolVibes: unsafe water chemistry — pH 8.4",
+		Text:    "Your latest test is out of range.",
+		Push: valueobjects.PushOptions{
+			Priority: 4,
+			Tags:     "warning,test_tube",
+			Click:    "https://pool.example.com/",
+		},
+		PushToken: "tk_secret",
+	}
+}
+
+func TestPushNotifier_Ntfy(t *testing.T) {
+	srv := newPushServer(t, http.StatusOK)
+	if err := NewPushNotifier(true).Send(context.Background(), "ntfy:"+srv.URL+"/ntfy/my-pool", testPushMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if srv.path != "/ntfy/" {
+		t.Errorf("posted to %q, want the server root /ntfy/", srv.path)
+	}
+	if got := srv.header.Get("Authorization"); got != "Bearer tk_secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+	want := map[string]any{
+		"topic":    "my-pool",
+		"title":    "PoolVibes: unsafe water chemistry — pH 8.4",
+		"message":  "Your latest test is out of range.",
+		"priority": float64(4),
+		"tags":     []any{"warning", "test_tube"},
+		"click":    "https://pool.example.com/",
+	}
+	for k, v := range want {
+		if got, _ := json.Marshal(srv.body[k]); string(got) != mustJSON(v) {
+			t.Errorf("%s = %s, want %s", k, got, mustJSON(v))
+		}
+	}
+}
+
+func TestPushNotifier_Gotify(t *testing.T) {
+	srv := newPushServer(t, http.StatusOK)
+	if err := NewPushNotifier(true).Send(context.Background(), "gotify:"+srv.URL, testPushMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if srv.path != "/message" {
+		t.Errorf("posted to %q, want /message", srv.path)
+	}
+	if got := srv.header.Get("X-Gotify-Key"); got != "tk_secret" {
+		t.Errorf("X-Gotify-Key = %q", got)
+	}
+	if srv.body["priority"] != float64(8) || srv.body["title"] != "PoolVibes: unsafe water chemistry — pH 8.4" {
+		t.Errorf("body = %v", srv.body)
+	}
+	click := `{"client::notification":{"click":{"url":"https://pool.example.com/"}}}`
+	if got, _ := json.Marshal(srv.body["extras"]); string(got) != click {
+		t.Errorf("extras = %s, want %s", got, click)
+	}
+}
+
+func TestPushNotifier_Defaults(t *testing.T) {
+	srv := newPushServer(t, http.StatusOK)
+	msg := valueobjects.Message{Subject: "PoolVibes test notification", Text: "Working."}
+	if err := NewPushNotifier(true).Send(context.Background(), "ntfy:"+srv.URL+"/my-pool", msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if srv.body["priority"] != float64(valueobjects.PushPriorityDefault) {
+		t.Errorf("priority = %v, want the default", srv.body["priority"])
+	}
+	if srv.header.Get("Authorization") != "" || srv.body["click"] != nil || srv.body["tags"] != nil {
+		t.Errorf("unset options were sent: %v %v", srv.header, srv.body)
+	}
+}
+
+func TestPushNotifier_Errors(t *testing.T) {
+	srv := newPushServer(t, http.StatusForbidden)
+	if err := NewPushNotifier(true).Send(context.Background(), "ntfy:"+srv.URL+"/my-pool", testPushMessage()); err == nil {
+		t.Error("Send() succeeded on a 403, want error")
+	}
+	if err := NewPushNotifier(true).Send(context.Background(), srv.URL+"/my-pool", testPushMessage()); err == nil {
+		t.Error("Send() accepted an address without a service")
+	}
+}
+
+func mustJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestPushNotifier_RefusesPrivateAddresses(t *testing.T) {
+	srv := newPushServer(t, http.StatusOK)
+	err := NewPushNotifier(false).Send(context.Background(), "ntfy:"+srv.URL+"/my-pool", testPushMessage())
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Send() error = %v, want %v", err, ErrPrivateAddress)
+	}
+	if srv.body != nil {
+		t.Error("request reached a loopback server")
+	}
+}
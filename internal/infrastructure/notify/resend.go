@@ -3,7 +3,10 @@ package notify
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
 	"github.com/resend/resend-go/v2"
 )
 
@@ -19,12 +22,19 @@ func NewResendNotifier(apiKey, from string) *ResendNotifier {
 	}
 }
 
-func (n *ResendNotifier) Send(ctx context.Context, to string, subject string, body string) error {
+// Send sends an email with text and HTML bodies, or just the text if
+// there is no HTML. The message's metadata is sent as Resend tags.
+func (n *ResendNotifier) Send(ctx context.Context, to string, msg valueobjects.Message) error {
 	params := &resend.SendEmailRequest{
 		From:    n.from,
 		To:      []string{to},
-		Subject: subject,
-		Text:    body,
+		Subject: msg.Subject,
+		Text:    msg.Text,
+		Html:    msg.HTML,
+		Headers: msg.Headers,
+	}
+	for _, name := range slices.Sorted(maps.Keys(msg.Metadata)) {
+		params.Tags = append(params.Tags, resend.Tag{Name: name, Value: msg.Metadata[name]})
 	}
 	_, err := n.client.Emails.SendWithContext(ctx, params)
 	if err != nil {
@@ -0,0 +1,266 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// SMTP connection security modes.
+const (
+	// SMTPSecurityStartTLS connects in plain text and upgrades with
+	// STARTTLS, refusing to send if the server doesn't offer it.
+	SMTPSecurityStartTLS = "starttls"
+	// SMTPSecurityTLS connects over TLS from the start (implicit TLS,
+	// usually port 465).
+	SMTPSecurityTLS = "tls"
+	// SMTPSecurityNone sends in plain text. Only for local relays.
+	SMTPSecurityNone = "none"
+)
+
+// smtpTimeout bounds a send when the context has no deadline of its own.
+const smtpTimeout = 30 * time.Second
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // empty to send without authenticating
+	Password string
+	From     string
+	ReplyTo  string // optional
+	Security string // SMTPSecurityStartTLS (default), SMTPSecurityTLS or SMTPSecurityNone
+	// TLSConfig overrides the TLS settings, e.g. to trust a private CA.
+	TLSConfig *tls.Config
+}
+
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return nil, fmt.Errorf("invalid SMTP from address: %w", err)
+	}
+	if cfg.ReplyTo != "" {
+		if _, err := mail.ParseAddress(cfg.ReplyTo); err != nil {
+			return nil, fmt.Errorf("invalid SMTP reply-to address: %w", err)
+		}
+	}
+	switch cfg.Security {
+	case "":
+		cfg.Security = SMTPSecurityStartTLS
+	case SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP security mode %q (use starttls, tls or none)", cfg.Security)
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+		if cfg.Security == SMTPSecurityTLS {
+			cfg.Port = 465
+		}
+	}
+	return &SMTPNotifier{cfg: cfg}, nil
+}
+
+// Send sends a multipart/alternative email with text and HTML bodies, or
+// a plain text one if there is no HTML.
+func (n *SMTPNotifier) Send(ctx context.Context, to string, msg valueobjects.Message) error {
+	if err := n.send(ctx, to, msg); err != nil {
+		return fmt.Errorf("sending email via SMTP: %w", err)
+	}
+	return nil
+}
+
+func (n *SMTPNotifier) send(ctx context.Context, to string, m valueobjects.Message) error {
+	from, _ := mail.ParseAddress(n.cfg.From)
+	rcpt, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	msg, err := n.buildMessage(from, rcpt, m)
+	if err != nil {
+		return err
+	}
+
+	conn, err := n.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("greeting: %w", err)
+	}
+	defer c.Close()
+
+	if n.cfg.Security == SMTPSecurityStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server does not support STARTTLS")
+		}
+		if err := c.StartTLS(n.tlsConfig()); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if n.cfg.Username != "" {
+		auth := smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+		if err := c.Auth(auth); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	if err := c.Rcpt(rcpt.Address); err != nil {
+		return fmt.Errorf("rcpt to: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	return c.Quit()
+}
+
+func (n *SMTPNotifier) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	if n.cfg.Security == SMTPSecurityTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: n.tlsConfig()}
+		conn, err := tlsDialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to %s: %w", addr, err)
+		}
+		return conn, nil
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+func (n *SMTPNotifier) tlsConfig() *tls.Config {
+	if n.cfg.TLSConfig != nil {
+		cfg := n.cfg.TLSConfig.Clone()
+		if cfg.ServerName == "" {
+			cfg.ServerName = n.cfg.Host
+		}
+		return cfg
+	}
+	return &tls.Config{ServerName: n.cfg.Host, MinVersion: tls.VersionTLS12}
+}
+
+// buildMessage formats an email with quoted-printable bodies: plain text
+// only, or multipart/alternative with an HTML part when the message has
+// one.
+func (n *SMTPNotifier) buildMessage(from, to *mail.Address, m valueobjects.Message) ([]byte, error) {
+	text, html := m.Text, m.HTML
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+	header("From", from.String())
+	header("To", to.String())
+	if n.cfg.ReplyTo != "" {
+		replyTo, _ := mail.ParseAddress(n.cfg.ReplyTo)
+		header("Reply-To", replyTo.String())
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", messageID(from.Address))
+	for _, k := range slices.Sorted(maps.Keys(m.Headers)) {
+		v := m.Headers[k]
+		if strings.ContainsAny(k+v, "\r\n") || strings.ContainsAny(k, ": ") {
+			return nil, fmt.Errorf("invalid header %q", k)
+		}
+		header(textproto.CanonicalMIMEHeaderKey(k), v)
+	}
+	header("MIME-Version", "1.0")
+
+	if html == "" {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	header("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	buf.WriteString("\r\n")
+	// Clients show the last part they can render, so HTML goes last.
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable writes body quoted-printable encoded, with CRLF line
+// endings.
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID returns a unique Message-ID in the sender's domain.
+func messageID(from string) string {
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		domain = from[i+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
@@ -0,0 +1,383 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
+)
+
+// testSMTPServer is a minimal in-process SMTP server that records the
+// messages it accepts.
+type testSMTPServer struct {
+	ln        net.Listener
+	tlsConfig *tls.Config
+	implicit  bool // TLS from the start
+	startTLS  bool // offer STARTTLS
+	user      string
+	pass      string
+
+	mu       sync.Mutex
+	received []receivedMail
+}
+
+type receivedMail struct {
+	From, To string
+	Data     string
+	TLS      bool
+	Authed   bool
+}
+
+func newTestSMTPServer(t *testing.T, implicit, startTLS bool) (*testSMTPServer, *x509.CertPool) {
+	t.Helper()
+	cert, pool := testCertificate(t)
+	s := &testSMTPServer{
+		tlsConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+		implicit:  implicit,
+		startTLS:  startTLS,
+		user:      "mailer",
+		pass:      "s3cret",
+	}
+	var err error
+	if implicit {
+		s.ln, err = tls.Listen("tcp", "127.0.0.1:0", s.tlsConfig)
+	} else {
+		s.ln, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.ln.Close() })
+	go s.serve()
+	return s, pool
+}
+
+func (s *testSMTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *testSMTPServer) messages() []receivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedMail(nil), s.received...)
+}
+
+func (s *testSMTPServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testSMTPServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	secure := s.implicit
+	var authed bool
+	var msg receivedMail
+
+	reply("220 test ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250-test")
+			if s.startTLS && !secure {
+				reply("250-STARTTLS")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 ready")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, r, secure = tlsConn, bufio.NewReader(tlsConn), true
+		case "AUTH":
+			fields := strings.Fields(line)
+			decoded, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			if string(decoded) == "\x00"+s.user+"\x00"+s.pass {
+				authed = true
+				reply("235 ok")
+			} else {
+				reply("535 bad credentials")
+			}
+		case "MAIL":
+			msg = receivedMail{From: strings.Trim(line[len("MAIL FROM:"):], "<> "), TLS: secure, Authed: authed}
+			reply("250 ok")
+		case "RCPT":
+			msg.To = strings.Trim(line[len("RCPT TO:"):], "<> ")
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			msg.Data = data.String()
+			s.mu.Lock()
+			s.received = append(s.received, msg)
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+// testCertificate returns a self-signed certificate for 127.0.0.1 and a
+// pool that trusts it.
+func testCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		implicit bool
+		security string
+	}{
+		{"starttls", false, SMTPSecurityStartTLS},
+		{"implicit tls", true, SMTPSecurityTLS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, pool := newTestSMTPServer(t, tt.implicit, true)
+			n, err := NewSMTPNotifier(SMTPConfig{
+				Host:      "127.0.0.1",
+				Port:      srv.port(),
+				Username:  "mailer",
+				Password:  "s3cret",
+				From:      "PoolVibes <pool@example.com>",
+				ReplyTo:   "help@example.com",
+				Security:  tt.security,
+				TLSConfig: &tls.Config{RootCAs: pool},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Send(context.Background(), "owner@example.com", valueobjects.Message{Subject: "PoolVibes: 2 task(s) due today", Text: "Clean filter\nCheck pH"}); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			got := srv.messages()
+			if len(got) != 1 {
+				t.Fatalf("server received %d messages, want 1", len(got))
+			}
+			m := got[0]
+			if !m.TLS || !m.Authed {
+				t.Errorf("TLS = %v, authed = %v, want both", m.TLS, m.Authed)
+			}
+			if m.From != "pool@example.com" || m.To != "owner@example.com" {
+				t.Errorf("envelope = %s -> %s", m.From, m.To)
+			}
+			parsed, err := mail.ReadMessage(strings.NewReader(m.Data))
+			if err != nil {
+				t.Fatalf("parsing message: %v", err)
+			}
+			if got := parsed.Header.Get("Reply-To"); got != "<help@example.com>" {
+				t.Errorf("Reply-To = %q", got)
+			}
+			if got := parsed.Header.Get("Subject"); got != "PoolVibes: 2 task(s) due today" {
+				t.Errorf("Subject = %q", got)
+			}
+			body, _ := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+			if strings.TrimSpace(string(body)) != "Clean filter\r\nCheck pH" {
+				t.Errorf("body = %q", body)
+			}
+		})
+	}
+}
+
+func TestSMTPNotifier_SendHTMLWithHeaders(t *testing.T) {
+	srv, pool := newTestSMTPServer(t, false, true)
+	n, err := NewSMTPNotifier(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      srv.port(),
+		From:      "pool@example.com",
+		TLSConfig: &tls.Config{RootCAs: pool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := `<p>Clean filter <a href="https://pool.example.com/?complete=1">Mark done</a></p>`
+	unsubscribe := "<https://pool.example.com/unsubscribe?token=abc>"
+	msg := valueobjects.Message{
+		Subject: "Tasks due",
+		Text:    "Clean filter",
+		HTML:    html,
+		Headers: map[string]string{
+			"List-Unsubscribe":      unsubscribe,
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+	if err := n.Send(context.Background(), "owner@example.com", msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	got := srv.messages()
+	if len(got) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(got))
+	}
+	parsed, err := mail.ReadMessage(strings.NewReader(got[0].Data))
+	if err != nil {
+		t.Fatalf("parsing message: %v", err)
+	}
+	if got := parsed.Header.Get("List-Unsubscribe"); got != unsubscribe {
+		t.Errorf("List-Unsubscribe = %q, want %q", got, unsubscribe)
+	}
+	if got := parsed.Header.Get("List-Unsubscribe-Post"); got != "List-Unsubscribe=One-Click" {
+		t.Errorf("List-Unsubscribe-Post = %q", got)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, want multipart/alternative", parsed.Header.Get("Content-Type"))
+	}
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	var bodies []string
+	for {
+		p, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading part: %v", err)
+		}
+		body, _ := io.ReadAll(quotedprintable.NewReader(p))
+		parts = append(parts, p.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "text/plain") || !strings.HasPrefix(parts[1], "text/html") {
+		t.Fatalf("parts = %v, want text then HTML", parts)
+	}
+	if bodies[0] != "Clean filter" || bodies[1] != html {
+		t.Errorf("bodies = %q", bodies)
+	}
+}
+
+func TestSMTPNotifier_RequiresStartTLS(t *testing.T) {
+	srv, pool := newTestSMTPServer(t, false, false)
+	n, err := NewSMTPNotifier(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      srv.port(),
+		From:      "pool@example.com",
+		TLSConfig: &tls.Config{RootCAs: pool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Send(context.Background(), "owner@example.com", valueobjects.Message{Subject: "hi", Text: "body"}); err == nil {
+		t.Fatal("Send() succeeded without STARTTLS, want error")
+	}
+	if len(srv.messages()) != 0 {
+		t.Error("message was sent in plain text")
+	}
+}
+
+func TestSMTPNotifier_BadCredentials(t *testing.T) {
+	srv, pool := newTestSMTPServer(t, false, true)
+	n, err := NewSMTPNotifier(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      srv.port(),
+		Username:  "mailer",
+		Password:  "wrong",
+		From:      "pool@example.com",
+		TLSConfig: &tls.Config{RootCAs: pool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Send(context.Background(), "owner@example.com", valueobjects.Message{Subject: "hi", Text: "body"}); err == nil {
+		t.Fatal("Send() succeeded with bad credentials, want error")
+	}
+}
+
+func TestNewSMTPNotifier_Validates(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SMTPConfig
+	}{
+		{"no host", SMTPConfig{From: "pool@example.com"}},
+		{"bad from", SMTPConfig{Host: "smtp.example.com", From: "not an address"}},
+		{"bad reply-to", SMTPConfig{Host: "smtp.example.com", From: "pool@example.com", ReplyTo: "nope"}},
+		{"bad security", SMTPConfig{Host: "smtp.example.com", From: "pool@example.com", Security: "ssl3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSMTPNotifier(tt.cfg); err == nil {
+				t.Error("NewSMTPNotifier() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestNewSMTPNotifier_DefaultPort(t *testing.T) {
+	n, _ := NewSMTPNotifier(SMTPConfig{Host: "smtp.example.com", From: "pool@example.com"})
+	if n.cfg.Port != 587 || n.cfg.Security != SMTPSecurityStartTLS {
+		t.Errorf("defaults = %d/%s, want 587/starttls", n.cfg.Port, n.cfg.Security)
+	}
+	n, _ = NewSMTPNotifier(SMTPConfig{Host: "smtp.example.com", From: "pool@example.com", Security: SMTPSecurityTLS})
+	if n.cfg.Port != 465 {
+		t.Errorf("implicit TLS port = %d, want 465", n.cfg.Port)
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
 	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
 
 	twilio "github.com/twilio/twilio-go"
+	twilioClient "github.com/twilio/twilio-go/client"
 )
 
 type TwilioNotifier struct {
 	client     *twilio.RestClient
+	validator  twilioClient.RequestValidator
 	fromNumber string
 }
 
@@ -21,15 +24,18 @@ func NewTwilioNotifier(accountSID, authToken, fromNumber string) *TwilioNotifier
 	})
 	return &TwilioNotifier{
 		client:     client,
+		validator:  twilioClient.NewRequestValidator(authToken),
 		fromNumber: fromNumber,
 	}
 }
 
-func (n *TwilioNotifier) Send(ctx context.Context, to string, _ string, body string) error {
+// Send texts the message's plain text body. Its subject, HTML and headers
+// are ignored.
+func (n *TwilioNotifier) Send(ctx context.Context, to string, msg valueobjects.Message) error {
 	params := &twilioApi.CreateMessageParams{}
 	params.SetTo(to)
 	params.SetFrom(n.fromNumber)
-	params.SetBody(body)
+	params.SetBody(msg.Text)
 
 	_, err := n.client.Api.CreateMessage(params)
 	if err != nil {
@@ -37,3 +43,9 @@ func (n *TwilioNotifier) Send(ctx context.Context, to string, _ string, body str
 	}
 	return nil
 }
+
+// ValidateRequest reports whether a webhook request Twilio posted to url
+// carries a valid X-Twilio-Signature, made with the account's auth token.
+func (n *TwilioNotifier) ValidateRequest(url string, body []byte, signature string) bool {
+	return n.validator.ValidateBody(url, body, signature)
+}
@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+// Webhook request headers.
+const (
+	WebhookEventHeader     = "X-PoolVibes-Event"
+	WebhookDeliveryHeader  = "X-PoolVibes-Delivery"
+	WebhookSignatureHeader = "X-PoolVibes-Signature"
+)
+
+// WebhookSender POSTs signed JSON payloads to webhook endpoints.
+type WebhookSender struct {
+	client *http.Client
+}
+
+// NewWebhookSender returns a sender that refuses to post to private
+// addresses unless allowPrivate is set.
+func NewWebhookSender(allowPrivate bool) *WebhookSender {
+	return &WebhookSender{client: newUserURLClient(allowPrivate)}
+}
+
+// Send posts payload to url, signed with secret. It returns the response
+// status, and an error unless the status is 2xx.
+func (s *WebhookSender) Send(ctx context.Context, url, secret, event, deliveryID string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PoolVibes-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookDeliveryHeader, deliveryID)
+	req.Header.Set(WebhookSignatureHeader, entities.SignWebhook(secret, time.Now(), payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
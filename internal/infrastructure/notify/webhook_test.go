@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	var gotSig, gotEvent, gotDelivery string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSig = r.Header.Get(WebhookSignatureHeader)
+		gotEvent = r.Header.Get(WebhookEventHeader)
+		gotDelivery = r.Header.Get(WebhookDeliveryHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	payload := []byte(`{"event":"task.completed"}`)
+	code, err := NewWebhookSender(true).Send(context.Background(), srv.URL, "whsec_test", "task.completed", "d1", payload)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if code != http.StatusNoContent {
+		t.Errorf("code = %d, want 204", code)
+	}
+	if gotEvent != "task.completed" || gotDelivery != "d1" || string(gotBody) != string(payload) {
+		t.Errorf("got event %q, delivery %q, body %s", gotEvent, gotDelivery, gotBody)
+	}
+
+	// The receiver can recompute the signature from the timestamp it carries.
+	ts, _ := strconv.ParseInt(strings.TrimPrefix(strings.Split(gotSig, ",")[0], "t="), 10, 64)
+	if want := entities.SignWebhook("whsec_test", time.Unix(ts, 0), gotBody); gotSig != want {
+		t.Errorf("signature = %q, want %q", gotSig, want)
+	}
+}
+
+func TestWebhookSender_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	code, err := NewWebhookSender(true).Send(context.Background(), srv.URL, "s", "task.due", "d1", []byte(`{}`))
+	if err == nil {
+		t.Fatal("Send() succeeded on a 502, want error")
+	}
+	if code != http.StatusBadGateway {
+		t.Errorf("code = %d, want 502", code)
+	}
+}
+
+func TestWebhookSender_RefusesPrivateAddresses(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	// localhost resolves to a loopback address, which is refused once dialled.
+	url := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	for _, u := range []string{srv.URL, url} {
+		_, err := NewWebhookSender(false).Send(context.Background(), u, "s", "task.due", "d1", []byte(`{}`))
+		if !errors.Is(err, ErrPrivateAddress) {
+			t.Errorf("Send(%s) error = %v, want %v", u, err, ErrPrivateAddress)
+		}
+	}
+	if called {
+		t.Error("request reached a loopback server")
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.215.14":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.10":    false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"::ffff:10.0.0.1": false,
+	} {
+		if got := publicAddr(netip.MustParseAddr(addr).Unmap()); got != want {
+			t.Errorf("publicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
@@ -11,11 +11,13 @@ import (
 )
 
 type AdminHandler struct {
-	svc *services.UserService
+	svc         *services.UserService
+	templateSvc *services.TaskTemplateService
+	outboxSvc   *services.OutboxService
 }
 
-func NewAdminHandler(svc *services.UserService) *AdminHandler {
-	return &AdminHandler{svc: svc}
+func NewAdminHandler(svc *services.UserService, templateSvc *services.TaskTemplateService, outboxSvc *services.OutboxService) *AdminHandler {
+	return &AdminHandler{svc: svc, templateSvc: templateSvc, outboxSvc: outboxSvc}
 }
 
 type adminUserSignals struct {
@@ -89,3 +91,81 @@ func (h *AdminHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	sse.PatchElementTempl(templates.AdminUserList(users))
 	sse.PatchElementTempl(templates.EmptyModal())
 }
+
+func (h *AdminHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	h.patchTemplateList(w, r)
+}
+
+func (h *AdminHandler) EditTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	tmpl, err := h.templateSvc.Get(r.Context(), id)
+	if err != nil {
+		slog.Error("Error loading task template", "templateID", id, "error", err)
+		http.Error(w, "failed to load template", http.StatusBadRequest)
+		return
+	}
+	if tmpl == nil || tmpl.BuiltIn || !tmpl.IsSiteWide() {
+		http.Error(w, "template not found", http.StatusNotFound)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.AdminEditTemplate(tmpl))
+}
+
+func (h *AdminHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var signals taskTemplateSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.templateSvc.Update(r.Context(), command.UpdateTaskTemplate{
+		ID:          id,
+		Name:        signals.Name,
+		Description: signals.Description,
+	})
+	if err != nil {
+		slog.Error("Error updating task template", "templateID", id, "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to update template"))
+		return
+	}
+	h.patchTemplateList(w, r)
+}
+
+func (h *AdminHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.templateSvc.Delete(r.Context(), id); err != nil {
+		slog.Error("Error deleting task template", "templateID", id, "error", err)
+		http.Error(w, "failed to delete template", http.StatusInternalServerError)
+		return
+	}
+	h.patchTemplateList(w, r)
+}
+
+func (h *AdminHandler) patchTemplateList(w http.ResponseWriter, r *http.Request) {
+	list, err := h.templateSvc.ListSiteWide(r.Context())
+	if err != nil {
+		slog.Error("Error listing task templates", "error", err)
+		http.Error(w, "failed to load templates", http.StatusInternalServerError)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.AdminTemplateList(list))
+	sse.PatchElementTempl(templates.EmptyModal())
+}
+
+func (h *AdminHandler) Notifications(w http.ResponseWriter, r *http.Request) {
+	report, err := h.outboxSvc.DeliveryReport(r.Context())
+	if err != nil {
+		slog.Error("Error loading delivery report", "error", err)
+		http.Error(w, "failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.AdminNotifications(report))
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/application/services"
+	"github.com/joshthewhite/poolvibes/internal/interface/web/ical"
+)
+
+const calendarName = "PoolVibes Tasks"
+
+type CalendarHandler struct {
+	svc *services.CalendarService
+}
+
+func NewCalendarHandler(svc *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{svc: svc}
+}
+
+// Feed serves the subscribable feed for the token in the URL. It is
+// reached without a session, so an unknown token is a plain 404.
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(r.PathValue("file"), ".ics")
+	entries, err := h.svc.Feed(r.Context(), token, time.Now())
+	if err != nil {
+		slog.Error("Error building calendar feed", "error", err)
+		http.Error(w, "failed to build calendar", http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	cal := ical.Calendar{Name: calendarName, RefreshInterval: time.Hour}
+	for _, e := range entries {
+		cal.Events = append(cal.Events, calendarEvent(e))
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if err := cal.Write(w); err != nil {
+		slog.Error("Error writing calendar feed", "error", err)
+	}
+}
+
+// TaskICS downloads a single task as a one-event calendar file.
+func (h *CalendarHandler) TaskICS(w http.ResponseWriter, r *http.Request) {
+	entry, err := h.svc.TaskEntry(r.Context(), r.PathValue("id"))
+	if err != nil || entry == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	cal := ical.Calendar{Name: calendarName, Events: []ical.Event{calendarEvent(*entry)}}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "task-"+entry.Task.ID.String()+".ics"))
+	if err := cal.Write(w); err != nil {
+		slog.Error("Error writing task calendar", "error", err)
+	}
+}
+
+// calendarEvent keeps UIDs stable across feed refreshes: a real occurrence
+// is keyed by its task, a projected one by its series and date, so calendar
+// apps update events in place instead of duplicating them.
+func calendarEvent(e services.CalendarEntry) ical.Event {
+	uid := e.Task.ID.String() + "@poolvibes"
+	if e.Projected {
+		uid = e.Task.SeriesID.String() + "-" + e.Due.Format("20060102") + "@poolvibes"
+	}
+	return ical.Event{
+		UID:         uid,
+		Summary:     e.Task.Name,
+		Description: e.Task.Description,
+		Date:        e.Due,
+		Stamp:       e.Task.UpdatedAt,
+	}
+}
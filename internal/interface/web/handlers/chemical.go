@@ -3,6 +3,8 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/joshthewhite/poolvibes/internal/application/command"
 	"github.com/joshthewhite/poolvibes/internal/application/services"
@@ -11,11 +13,12 @@ import (
 )
 
 type ChemicalHandler struct {
-	svc *services.ChemicalService
+	svc     *services.ChemicalService
+	chemSvc *services.ChemistryService
 }
 
-func NewChemicalHandler(svc *services.ChemicalService) *ChemicalHandler {
-	return &ChemicalHandler{svc: svc}
+func NewChemicalHandler(svc *services.ChemicalService, chemSvc *services.ChemistryService) *ChemicalHandler {
+	return &ChemicalHandler{svc: svc, chemSvc: chemSvc}
 }
 
 type chemicalSignals struct {
@@ -30,6 +33,14 @@ type adjustSignals struct {
 	Delta float64 `json:"adjustDelta"`
 }
 
+type movementSignals struct {
+	Kind           string  `json:"movementKind"`
+	Amount         float64 `json:"movementAmount"`
+	Reason         string  `json:"movementReason"`
+	Cost           string  `json:"movementCost"`
+	ChemistryLogID string  `json:"movementChemLogId"`
+}
+
 func (h *ChemicalHandler) List(w http.ResponseWriter, r *http.Request) {
 	chemicals, err := h.svc.List(r.Context())
 	if err != nil {
@@ -138,6 +149,89 @@ func (h *ChemicalHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
 	sse.PatchElementTempl(templates.ChemicalList(chemicals))
 }
 
+func (h *ChemicalHandler) MovementForm(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	chem, err := h.svc.Get(r.Context(), id)
+	if err != nil || chem == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	logs, err := h.chemSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error loading chemistry logs", "error", err)
+	}
+	if len(logs) > recentLogsForLinking {
+		logs = logs[:recentLogsForLinking]
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.ChemicalMovementForm(chem, logs))
+}
+
+func (h *ChemicalHandler) RecordMovement(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	signals := &movementSignals{}
+	if err := datastar.ReadSignals(r, signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	cmd := command.RecordStockMovement{
+		ID:             id,
+		Kind:           signals.Kind,
+		Amount:         signals.Amount,
+		Reason:         signals.Reason,
+		ChemistryLogID: signals.ChemistryLogID,
+	}
+	if raw := strings.TrimSpace(signals.Cost); raw != "" {
+		cost, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			sse := datastar.NewSSE(w, r)
+			sse.PatchElementTempl(templates.ModalError("Cost must be a number"))
+			return
+		}
+		cmd.Cost = &cost
+	}
+	if _, err := h.svc.RecordMovement(r.Context(), cmd); err != nil {
+		slog.Error("Error recording stock movement", "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to record stock movement: " + err.Error()))
+		return
+	}
+
+	chemicals, _ := h.svc.List(r.Context())
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.ChemicalList(chemicals))
+	sse.PatchElementTempl(templates.EmptyModal())
+}
+
+func (h *ChemicalHandler) History(w http.ResponseWriter, r *http.Request) {
+	history, err := h.svc.History(r.Context(), r.PathValue("id"))
+	if err != nil {
+		slog.Error("Error loading stock history", "error", err)
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	labels := make(map[string]string)
+	for _, e := range history.Ledger.Entries {
+		if e.ChemistryLogID == nil {
+			continue
+		}
+		key := e.ChemistryLogID.String()
+		if _, ok := labels[key]; ok {
+			continue
+		}
+		if log, err := h.chemSvc.Get(r.Context(), key); err == nil && log != nil {
+			labels[key] = "Water test " + log.TestedAt.Format("Jan 2, 2006")
+		}
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.ChemicalHistory(history.Chemical, history.Ledger, labels))
+}
+
 func (h *ChemicalHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if err := h.svc.Delete(r.Context(), id); err != nil {
@@ -107,7 +107,7 @@ func (h *ChemistryHandler) List(w http.ResponseWriter, r *http.Request) {
 
 func (h *ChemistryHandler) NewForm(w http.ResponseWriter, r *http.Request) {
 	sse := datastar.NewSSE(w, r)
-	sse.PatchElementTempl(templates.ChemistryNewForm(time.Now()))
+	sse.PatchElementTempl(templates.ChemistryNewForm(services.UserNow(r.Context())))
 }
 
 func (h *ChemistryHandler) Create(w http.ResponseWriter, r *http.Request) {
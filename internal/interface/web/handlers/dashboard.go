@@ -20,10 +20,11 @@ type DashboardHandler struct {
 	taskSvc       *services.TaskService
 	chemicSvc     *services.ChemicalService
 	milestoneRepo repositories.MilestoneRepository
+	webhookSvc    *services.WebhookService
 }
 
-func NewDashboardHandler(chemSvc *services.ChemistryService, taskSvc *services.TaskService, chemicSvc *services.ChemicalService, milestoneRepo repositories.MilestoneRepository) *DashboardHandler {
-	return &DashboardHandler{chemSvc: chemSvc, taskSvc: taskSvc, chemicSvc: chemicSvc, milestoneRepo: milestoneRepo}
+func NewDashboardHandler(chemSvc *services.ChemistryService, taskSvc *services.TaskService, chemicSvc *services.ChemicalService, milestoneRepo repositories.MilestoneRepository, webhookSvc *services.WebhookService) *DashboardHandler {
+	return &DashboardHandler{chemSvc: chemSvc, taskSvc: taskSvc, chemicSvc: chemicSvc, milestoneRepo: milestoneRepo, webhookSvc: webhookSvc}
 }
 
 func (h *DashboardHandler) Page(w http.ResponseWriter, r *http.Request) {
@@ -31,15 +32,21 @@ func (h *DashboardHandler) Page(w http.ResponseWriter, r *http.Request) {
 	tasks, _ := h.taskSvc.List(r.Context())
 	chemicals, _ := h.chemicSvc.List(r.Context())
 
-	data := buildDashboardData(logs, tasks, chemicals)
+	// Dates are judged by the user's local day
+	user, err := services.UserFromContext(r.Context())
+	now := time.Now()
+	if err == nil {
+		now = now.In(user.Location())
+	}
+
+	data := buildDashboardData(logs, tasks, chemicals, now)
 
 	// Gamification: health score, streaks, milestones
-	now := time.Now()
 	score := services.ComputeHealthScore(logs, tasks, chemicals, now)
 	data.HealthScore = templates.HealthScoreSummary{
 		Score:  score,
 		Status: healthScoreStatus(score),
-		Label:  healthScoreLabel(score),
+		Label:  services.HealthScoreLabel(score),
 	}
 	data.Streaks = templates.StreaksSummary{
 		TestingStreak: services.ComputeTestingStreak(logs, now),
@@ -47,7 +54,6 @@ func (h *DashboardHandler) Page(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// User info for greeting
-	user, err := services.UserFromContext(r.Context())
 	if err == nil {
 		data.Email = user.Email
 	}
@@ -60,11 +66,13 @@ func (h *DashboardHandler) Page(w http.ResponseWriter, r *http.Request) {
 			earnedSet[m.Milestone] = true
 		}
 
-		newlyEarned := services.CheckMilestones(logs, tasks, chemicals, score, earnedSet)
+		newlyEarned := services.CheckMilestones(logs, tasks, chemicals, score, earnedSet, now)
 		for _, key := range newlyEarned {
 			m := entities.NewMilestone(user.ID, key)
 			if err := h.milestoneRepo.Create(r.Context(), m); err != nil {
 				slog.Error("Failed to persist milestone", "key", key, "error", err)
+			} else {
+				h.webhookSvc.MilestoneEarned(r.Context(), m)
 			}
 			earnedSet[key] = true
 		}
@@ -80,7 +88,9 @@ func (h *DashboardHandler) Page(w http.ResponseWriter, r *http.Request) {
 	sse.PatchElementTempl(templates.Dashboard(data))
 }
 
-func buildDashboardData(logs []entities.ChemistryLog, tasks []entities.Task, chemicals []entities.Chemical) templates.DashboardData {
+// buildDashboardData summarises the user's data as of now, in the user's
+// timezone.
+func buildDashboardData(logs []entities.ChemistryLog, tasks []entities.Task, chemicals []entities.Chemical, now time.Time) templates.DashboardData {
 	data := templates.DashboardData{
 		Chart: templates.ChartData{
 			PHMin: 7.2,
@@ -129,10 +139,7 @@ func buildDashboardData(logs []entities.ChemistryLog, tasks []entities.Task, che
 		}
 
 		// Last tested
-		now := time.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		tested := time.Date(latest.TestedAt.Year(), latest.TestedAt.Month(), latest.TestedAt.Day(), 0, 0, 0, 0, latest.TestedAt.Location())
-		days := int(today.Sub(tested).Hours() / 24)
+		days := int(entities.DateOf(now).Sub(entities.DateOf(latest.TestedAt)).Hours() / 24)
 
 		var testedText string
 		testedStatus := "good"
@@ -188,26 +195,23 @@ func buildDashboardData(logs []entities.ChemistryLog, tasks []entities.Task, che
 	overdueCount := 0
 	dueTodayCount := 0
 	var upcomingTasks []entities.Task
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today := entities.DateOf(now)
 
 	for i := range tasks {
-		tasks[i].CheckOverdue()
-		if tasks[i].Status == entities.TaskStatusCompleted {
+		if !tasks[i].IsOpen() {
 			continue
 		}
 		if tasks[i].Status == entities.TaskStatusOverdue {
 			overdueCount++
 		}
-		due := time.Date(tasks[i].DueDate.Year(), tasks[i].DueDate.Month(), tasks[i].DueDate.Day(), 0, 0, 0, 0, tasks[i].DueDate.Location())
-		if today.Equal(due) {
+		if today.Equal(entities.DateOf(tasks[i].EffectiveDueDate())) {
 			dueTodayCount++
 		}
 		upcomingTasks = append(upcomingTasks, tasks[i])
 	}
 
 	sort.Slice(upcomingTasks, func(i, j int) bool {
-		return upcomingTasks[i].DueDate.Before(upcomingTasks[j].DueDate)
+		return upcomingTasks[i].EffectiveDueDate().Before(upcomingTasks[j].EffectiveDueDate())
 	})
 	if len(upcomingTasks) > 7 {
 		upcomingTasks = upcomingTasks[:7]
@@ -266,43 +270,24 @@ func healthScoreStatus(score int) string {
 	}
 }
 
-func healthScoreLabel(score int) string {
-	switch {
-	case score >= 90:
-		return "Excellent"
-	case score >= 80:
-		return "Great"
-	case score >= 60:
-		return "Needs attention"
-	case score >= 40:
-		return "Falling behind"
-	default:
-		return "Critical"
-	}
-}
-
-var milestoneInfo = map[entities.MilestoneKey]struct {
-	Name string
-	Icon string
-}{
-	entities.MilestoneFirstDip:    {"First Dip", "fa-solid fa-droplet"},
-	entities.MilestoneBalanced:    {"Balanced", "fa-solid fa-scale-balanced"},
-	entities.MilestoneConsistent:  {"Consistent", "fa-solid fa-calendar-check"},
-	entities.MilestoneDevoted:     {"Devoted", "fa-solid fa-award"},
-	entities.MilestoneOnIt:        {"On It", "fa-solid fa-clipboard-check"},
-	entities.MilestoneStockedUp:   {"Stocked Up", "fa-solid fa-boxes-stacked"},
-	entities.MilestoneCleanRecord: {"Clean Record", "fa-solid fa-shield-halved"},
-	entities.MilestonePoolPro:     {"Pool Pro", "fa-solid fa-trophy"},
+var milestoneIcons = map[entities.MilestoneKey]string{
+	entities.MilestoneFirstDip:    "fa-solid fa-droplet",
+	entities.MilestoneBalanced:    "fa-solid fa-scale-balanced",
+	entities.MilestoneConsistent:  "fa-solid fa-calendar-check",
+	entities.MilestoneDevoted:     "fa-solid fa-award",
+	entities.MilestoneOnIt:        "fa-solid fa-clipboard-check",
+	entities.MilestoneStockedUp:   "fa-solid fa-boxes-stacked",
+	entities.MilestoneCleanRecord: "fa-solid fa-shield-halved",
+	entities.MilestonePoolPro:     "fa-solid fa-trophy",
 }
 
 func buildMilestoneBadges(earned, newlyEarned map[entities.MilestoneKey]bool) []templates.MilestoneBadge {
 	var badges []templates.MilestoneBadge
 	for _, key := range entities.AllMilestones() {
-		info := milestoneInfo[key]
 		badges = append(badges, templates.MilestoneBadge{
 			Key:    string(key),
-			Name:   info.Name,
-			Icon:   info.Icon,
+			Name:   key.Label(),
+			Icon:   milestoneIcons[key],
 			Earned: earned[key],
 			IsNew:  newlyEarned[key],
 		})
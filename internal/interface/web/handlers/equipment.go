@@ -145,7 +145,7 @@ func (h *EquipmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 func (h *EquipmentHandler) NewServiceRecordForm(w http.ResponseWriter, r *http.Request) {
 	eqID := r.PathValue("id")
-	today := time.Now().Format("2006-01-02")
+	today := services.UserNow(r.Context()).Format("2006-01-02")
 
 	sse := datastar.NewSSE(w, r)
 	sse.PatchElementTempl(templates.ServiceRecordNewForm(eqID, today))
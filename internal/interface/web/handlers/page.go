@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/joshthewhite/poolvibes/internal/application/services"
 	"github.com/joshthewhite/poolvibes/internal/interface/web/templates"
 )
@@ -19,6 +20,10 @@ func (h *PageHandler) Root(w http.ResponseWriter, r *http.Request) {
 		h.Index(w, r)
 		return
 	}
+	if r.URL.Query().Has("complete") {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	templates.LandingPage().Render(r.Context(), w)
 }
@@ -32,6 +37,12 @@ func (h *PageHandler) Index(w http.ResponseWriter, r *http.Request) {
 		isAdmin = user.IsAdmin
 	}
 
+	// Links in reminder emails open a task's completion form.
+	completeTaskID := ""
+	if id, err := uuid.Parse(r.URL.Query().Get("complete")); err == nil {
+		completeTaskID = id.String()
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	templates.Layout(email, isAdmin).Render(r.Context(), w)
+	templates.Layout(email, isAdmin, completeTaskID).Render(r.Context(), w)
 }
@@ -1,28 +1,71 @@
 package handlers
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joshthewhite/poolvibes/internal/application/command"
 	"github.com/joshthewhite/poolvibes/internal/application/services"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
 	"github.com/joshthewhite/poolvibes/internal/interface/web/templates"
 	"github.com/starfederation/datastar-go/datastar"
 )
 
 type SettingsHandler struct {
-	svc *services.UserService
+	svc         *services.UserService
+	reminderSvc *services.ReminderService
+	alertSvc    *services.AlertService
+	webhookSvc  *services.WebhookService
+	outboxSvc   *services.OutboxService
 }
 
-func NewSettingsHandler(svc *services.UserService) *SettingsHandler {
-	return &SettingsHandler{svc: svc}
+func NewSettingsHandler(svc *services.UserService, reminderSvc *services.ReminderService, alertSvc *services.AlertService, webhookSvc *services.WebhookService, outboxSvc *services.OutboxService) *SettingsHandler {
+	return &SettingsHandler{svc: svc, reminderSvc: reminderSvc, alertSvc: alertSvc, webhookSvc: webhookSvc, outboxSvc: outboxSvc}
 }
 
 type settingsSignals struct {
 	Phone       string `json:"settingsPhone"`
-	NotifyEmail bool   `json:"settingsNotifyEmail"`
-	NotifySMS   bool   `json:"settingsNotifySms"`
 	PoolGallons int    `json:"settingsPoolGallons"`
+	Timezone    string `json:"settingsTimezone"`
+}
+
+type phoneSignals struct {
+	Phone string `json:"settingsPhone"`
+	Code  string `json:"settingsPhoneCode"`
+}
+
+type pushSignals struct {
+	Service string `json:"pushService"`
+	URL     string `json:"pushUrl"`
+	Token   string `json:"pushToken"`
+}
+
+// alertSettingsSignals holds the notification matrix, keyed by category then
+// channel.
+type alertSettingsSignals struct {
+	Alerts        map[string]map[string]bool `json:"alerts"`
+	NoTestDays    int                        `json:"alertNoTestDays"`
+	DigestWeekday string                     `json:"alertDigestDay"`
+	DigestHour    string                     `json:"alertDigestHour"`
+	QuietHours    bool                       `json:"alertQuietHours"`
+	QuietStart    string                     `json:"alertQuietStart"`
+	QuietEnd      string                     `json:"alertQuietEnd"`
+}
+
+// webhookSignals holds the new webhook form. Events are keyed by the parts
+// of their names, e.g. webhookEvents.task.due.
+type webhookSignals struct {
+	URL    string                     `json:"webhookUrl"`
+	Events map[string]map[string]bool `json:"webhookEvents"`
+}
+
+type reminderSignals struct {
+	OffsetDays string `json:"reminderOffset"`
+	SendHour   string `json:"reminderHour"`
 }
 
 func (h *SettingsHandler) Page(w http.ResponseWriter, r *http.Request) {
@@ -32,8 +75,40 @@ func (h *SettingsHandler) Page(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rules, err := h.reminderSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error listing reminder rules", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	alerts, err := h.alertSvc.Preferences(r.Context())
+	if err != nil {
+		slog.Error("Error loading alert preferences", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	hooks, err := h.webhookSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error listing webhooks", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	deliveries, err := h.webhookSvc.Deliveries(r.Context())
+	if err != nil {
+		slog.Error("Error listing webhook deliveries", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	history, err := h.outboxSvc.History(r.Context())
+	if err != nil {
+		slog.Error("Error loading notification history", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
 	sse := datastar.NewSSE(w, r)
-	sse.PatchElementTempl(templates.SettingsPage(user.Phone, user.NotifyEmail, user.NotifySMS, user.PoolGallons))
+	sse.PatchElementTempl(templates.SettingsPage(user, h.svc.SMSEnabled(), rules, alerts, hooks, deliveries, history, calendarFeedURL(r, user.CalendarToken)))
 }
 
 func (h *SettingsHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -43,19 +118,272 @@ func (h *SettingsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := h.svc.UpdatePreferences(r.Context(), command.UpdateNotificationPreferences{
+	user, err := h.svc.UpdatePreferences(r.Context(), command.UpdateNotificationPreferences{
 		Phone:       signals.Phone,
-		NotifyEmail: signals.NotifyEmail,
-		NotifySMS:   signals.NotifySMS,
 		PoolGallons: signals.PoolGallons,
+		Timezone:    signals.Timezone,
 	})
 	if err != nil {
 		slog.Error("Error saving settings", "error", err)
 		sse := datastar.NewSSE(w, r)
-		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to save settings"))
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to save settings: "+err.Error()))
 		return
 	}
 
 	sse := datastar.NewSSE(w, r)
 	sse.PatchElementTempl(templates.SettingsMessage("is-success is-light", "Settings saved successfully."))
+	sse.PatchElementTempl(templates.SettingsPhone(user, h.svc.SMSEnabled(), "", ""))
+}
+
+// SendPhoneCode saves the phone number entered and texts a verification
+// code to it.
+func (h *SettingsHandler) SendPhoneCode(w http.ResponseWriter, r *http.Request) {
+	var signals phoneSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	user, err := h.svc.SendPhoneCode(r.Context(), signals.Phone)
+	if user == nil {
+		slog.Error("Error sending phone verification code", "error", err)
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to send a verification code"))
+		return
+	}
+	if err != nil {
+		sse.PatchElementTempl(templates.SettingsPhone(user, h.svc.SMSEnabled(), "is-danger", err.Error()))
+		return
+	}
+	msg := fmt.Sprintf("Code sent to %s. It expires in %d minutes.", user.Phone, int(entities.PhoneCodeTTL.Minutes()))
+	sse.PatchElementTempl(templates.SettingsPhone(user, h.svc.SMSEnabled(), "is-success", msg))
+}
+
+// VerifyPhone checks the code the user entered.
+func (h *SettingsHandler) VerifyPhone(w http.ResponseWriter, r *http.Request) {
+	var signals phoneSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	user, err := h.svc.VerifyPhone(r.Context(), signals.Code)
+	if user == nil {
+		slog.Error("Error verifying phone", "error", err)
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to verify phone number"))
+		return
+	}
+	if err != nil {
+		sse.PatchElementTempl(templates.SettingsPhone(user, h.svc.SMSEnabled(), "is-danger", err.Error()))
+		return
+	}
+	sse.PatchElementTempl(templates.SettingsPhone(user, h.svc.SMSEnabled(), "is-success", "Phone number verified."))
+}
+
+// UpdatePush saves where the user's push notifications are sent.
+func (h *SettingsHandler) UpdatePush(w http.ResponseWriter, r *http.Request) {
+	var signals pushSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	user, err := h.svc.UpdatePush(r.Context(), command.UpdatePush(signals))
+	if err != nil {
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to save push settings: "+err.Error()))
+		return
+	}
+	msg := "Push notifications turned off."
+	if user.Push.IsSet() {
+		msg = "Push settings saved."
+	}
+	sse.PatchElementTempl(templates.SettingsPush(user, "is-success", msg))
+}
+
+// TestPush saves the push settings entered and sends a test notification
+// with them.
+func (h *SettingsHandler) TestPush(w http.ResponseWriter, r *http.Request) {
+	var signals pushSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	user, err := h.svc.UpdatePush(r.Context(), command.UpdatePush(signals))
+	if err != nil {
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to save push settings: "+err.Error()))
+		return
+	}
+	if err := h.svc.SendTestPush(r.Context()); err != nil {
+		sse.PatchElementTempl(templates.SettingsPush(user, "is-danger", err.Error()))
+		return
+	}
+	sse.PatchElementTempl(templates.SettingsPush(user, "is-success", "Test notification sent. If it doesn't arrive, check the URL and token."))
+}
+
+func (h *SettingsHandler) RegenerateCalendarToken(w http.ResponseWriter, r *http.Request) {
+	user, err := h.svc.RegenerateCalendarToken(r.Context())
+	if err != nil {
+		slog.Error("Error regenerating calendar token", "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to create calendar link"))
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.SettingsCalendar(calendarFeedURL(r, user.CalendarToken)))
+}
+
+func (h *SettingsHandler) UpdateAlerts(w http.ResponseWriter, r *http.Request) {
+	var signals alertSettingsSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	weekday, err1 := strconv.Atoi(signals.DigestWeekday)
+	hour, err2 := strconv.Atoi(signals.DigestHour)
+	quietStart, err3 := strconv.Atoi(signals.QuietStart)
+	quietEnd, err4 := strconv.Atoi(signals.QuietEnd)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	cmd := command.UpdateAlertPreferences{
+		NoTestDays:    signals.NoTestDays,
+		DigestWeekday: weekday,
+		DigestHour:    hour,
+		QuietHours:    signals.QuietHours,
+		QuietStart:    quietStart,
+		QuietEnd:      quietEnd,
+	}
+	for category, channels := range signals.Alerts {
+		for channel, on := range channels {
+			if on {
+				cmd.OptIns = append(cmd.OptIns, command.NotificationOptIn{Category: category, Channel: channel})
+			}
+		}
+	}
+
+	sse := datastar.NewSSE(w, r)
+	prefs, err := h.alertSvc.UpdatePreferences(r.Context(), cmd)
+	if err != nil {
+		slog.Error("Error saving notification preferences", "error", err)
+		sse.PatchElementTempl(templates.SettingsMessage("is-danger is-light", "Failed to save notification preferences: "+err.Error()))
+		return
+	}
+	digest := entities.DigestSchedule{Weekday: time.Weekday(weekday), Hour: hour}
+	quiet := entities.QuietHours{Enabled: signals.QuietHours, Start: quietStart, End: quietEnd}
+	sse.PatchElementTempl(templates.SettingsAlerts(prefs, signals.NoTestDays, digest, quiet, "is-success is-light", "Notification preferences saved."))
+}
+
+func (h *SettingsHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	var signals reminderSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+	offset, err1 := strconv.Atoi(signals.OffsetDays)
+	hour, err2 := strconv.Atoi(signals.SendHour)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.reminderSvc.Create(r.Context(), command.CreateReminderRule{OffsetDays: offset, SendHour: hour})
+	h.patchReminders(w, r, err)
+}
+
+func (h *SettingsHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	err := h.reminderSvc.Delete(r.Context(), r.PathValue("key"))
+	h.patchReminders(w, r, err)
+}
+
+// patchReminders re-renders the reminder list, showing err if the change
+// failed.
+func (h *SettingsHandler) patchReminders(w http.ResponseWriter, r *http.Request, err error) {
+	var errMsg string
+	if err != nil {
+		slog.Error("Error updating reminder rules", "error", err)
+		errMsg = err.Error()
+	}
+	rules, err := h.reminderSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error listing reminder rules", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.SettingsReminders(rules, errMsg))
+}
+
+func (h *SettingsHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var signals webhookSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	cmd := command.CreateWebhook{URL: strings.TrimSpace(signals.URL)}
+	for _, event := range entities.WebhookEvents {
+		resource, action, _ := strings.Cut(string(event), ".")
+		if signals.Events[resource][action] {
+			cmd.Events = append(cmd.Events, string(event))
+		}
+	}
+	_, err := h.webhookSvc.Create(r.Context(), cmd)
+	h.patchWebhooks(w, r, err)
+}
+
+func (h *SettingsHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	err := h.webhookSvc.Delete(r.Context(), r.PathValue("id"))
+	h.patchWebhooks(w, r, err)
+}
+
+func (h *SettingsHandler) ReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	_, err := h.webhookSvc.Replay(r.Context(), r.PathValue("id"))
+	h.patchWebhooks(w, r, err)
+}
+
+// patchWebhooks re-renders the webhooks section, showing err if the change
+// failed.
+func (h *SettingsHandler) patchWebhooks(w http.ResponseWriter, r *http.Request, err error) {
+	var errMsg string
+	if err != nil {
+		slog.Error("Error updating webhooks", "error", err)
+		errMsg = err.Error()
+	}
+	hooks, err := h.webhookSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error listing webhooks", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	deliveries, err := h.webhookSvc.Deliveries(r.Context())
+	if err != nil {
+		slog.Error("Error listing webhook deliveries", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.SettingsWebhooks(hooks, deliveries, errMsg))
+}
+
+// calendarFeedURL returns the absolute feed URL for token, as seen by the
+// browser making this request, or "" if the feed is off.
+func calendarFeedURL(r *http.Request, token string) string {
+	if token == "" {
+		return ""
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/calendar/" + token + ".ics"
 }
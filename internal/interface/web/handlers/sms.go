@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/joshthewhite/poolvibes/internal/application/services"
+)
+
+// maxSMSWebhookBody caps the size of an inbound message webhook. Twilio's
+// are a few kilobytes.
+const maxSMSWebhookBody = 64 << 10
+
+type SMSHandler struct {
+	svc *services.SMSReplyService
+}
+
+func NewSMSHandler(svc *services.SMSReplyService) *SMSHandler {
+	return &SMSHandler{svc: svc}
+}
+
+// Inbound receives a text message from Twilio and answers it with TwiML.
+// It is reached without a session, so the request must carry Twilio's
+// signature.
+func (h *SMSHandler) Inbound(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSMSWebhookBody))
+	if err != nil {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !h.svc.Verify(body, r.Header.Get("X-Twilio-Signature")) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	reply, err := h.svc.Handle(r.Context(), form.Get("From"), form.Get("Body"))
+	if err != nil {
+		slog.Error("Error handling SMS reply", "error", err)
+		reply = "Sorry, something went wrong. Please try again later."
+	}
+	writeTwiML(w, reply)
+}
+
+// writeTwiML answers with reply as a text message, or with nothing if
+// reply is empty.
+func writeTwiML(w http.ResponseWriter, reply string) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	io.WriteString(w, xml.Header+"<Response>")
+	if reply != "" {
+		io.WriteString(w, "<Message>")
+		xml.EscapeText(w, []byte(reply))
+		io.WriteString(w, "</Message>")
+	}
+	io.WriteString(w, "</Response>")
+}
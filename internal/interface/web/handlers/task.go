@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"encoding/base64"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/joshthewhite/poolvibes/internal/application/command"
@@ -13,21 +15,47 @@ import (
 )
 
 type TaskHandler struct {
-	svc *services.TaskService
+	svc      *services.TaskService
+	chemSvc  *services.ChemistryService
+	equipSvc *services.EquipmentService
 }
 
-func NewTaskHandler(svc *services.TaskService) *TaskHandler {
-	return &TaskHandler{svc: svc}
+func NewTaskHandler(svc *services.TaskService, chemSvc *services.ChemistryService, equipSvc *services.EquipmentService) *TaskHandler {
+	return &TaskHandler{svc: svc, chemSvc: chemSvc, equipSvc: equipSvc}
 }
 
 type taskSignals struct {
 	Name                string `json:"taskName"`
 	Description         string `json:"taskDescription"`
+	Checklist           string `json:"taskChecklist"`
+	EquipmentID         string `json:"taskEquipmentId"`
 	RecurrenceFrequency string `json:"recurrenceFrequency"`
 	RecurrenceInterval  int    `json:"recurrenceInterval"`
+	RecurrenceAnchor    string `json:"recurrenceAnchor"`
 	DueDate             string `json:"dueDate"`
+	Scope               string `json:"taskScope"`
 }
 
+type snoozeSignals struct {
+	Days int `json:"snoozeDays"`
+}
+
+type completionSignals struct {
+	Notes           string   `json:"completionNotes"`
+	DurationMinutes int      `json:"completionDuration"`
+	ChemistryLogID  string   `json:"completionChemLogId"`
+	ServiceRecordID string   `json:"completionServiceRecordId"`
+	Photos          []string `json:"completionPhotos"`
+	PhotoNames      []string `json:"completionPhotosNames"`
+	LogService      bool     `json:"completionLogService"`
+	ServiceCost     float64  `json:"completionServiceCost"`
+	Technician      string   `json:"completionServiceTechnician"`
+}
+
+// recentLogsForLinking caps how many chemistry logs are offered when linking
+// a completion to a water test.
+const recentLogsForLinking = 10
+
 func (h *TaskHandler) List(w http.ResponseWriter, r *http.Request) {
 	tasks, err := h.svc.List(r.Context())
 	if err != nil {
@@ -43,9 +71,13 @@ func (h *TaskHandler) List(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TaskHandler) NewForm(w http.ResponseWriter, r *http.Request) {
-	dueDate := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	dueDate := services.UserNow(r.Context()).AddDate(0, 0, 7).Format("2006-01-02")
+	equipment, err := h.equipSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error loading equipment", "error", err)
+	}
 	sse := datastar.NewSSE(w, r)
-	sse.PatchElementTempl(templates.TaskNewForm(dueDate))
+	sse.PatchElementTempl(templates.TaskNewForm(dueDate, equipment))
 }
 
 func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +93,10 @@ func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Description:         signals.Description,
 		RecurrenceFrequency: signals.RecurrenceFrequency,
 		RecurrenceInterval:  signals.RecurrenceInterval,
+		RecurrenceAnchor:    signals.RecurrenceAnchor,
 		DueDate:             dueDate,
+		Checklist:           signals.Checklist,
+		EquipmentID:         signals.EquipmentID,
 	})
 	if err != nil {
 		slog.Error("Error creating task", "error", err)
@@ -85,8 +120,13 @@ func (h *TaskHandler) EditForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	equipment, err := h.equipSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error loading equipment", "error", err)
+	}
+
 	sse := datastar.NewSSE(w, r)
-	sse.PatchElementTempl(templates.TaskEditForm(task))
+	sse.PatchElementTempl(templates.TaskEditForm(task, equipment))
 }
 
 func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -100,11 +140,15 @@ func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 	dueDate, _ := time.Parse("2006-01-02", signals.DueDate)
 	_, err := h.svc.Update(r.Context(), command.UpdateTask{
 		ID:                  id,
+		Scope:               signals.Scope,
 		Name:                signals.Name,
 		Description:         signals.Description,
 		RecurrenceFrequency: signals.RecurrenceFrequency,
 		RecurrenceInterval:  signals.RecurrenceInterval,
+		RecurrenceAnchor:    signals.RecurrenceAnchor,
 		DueDate:             dueDate,
+		Checklist:           signals.Checklist,
+		EquipmentID:         signals.EquipmentID,
 	})
 	if err != nil {
 		slog.Error("Error updating task", "error", err)
@@ -120,12 +164,132 @@ func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 	sse.PatchElementTempl(templates.EmptyModal())
 }
 
-func (h *TaskHandler) Complete(w http.ResponseWriter, r *http.Request) {
+func (h *TaskHandler) CompleteForm(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	_, err := h.svc.Complete(r.Context(), id)
+	task, err := h.svc.Get(r.Context(), id)
+	if err != nil || task == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	logs, err := h.chemSvc.List(r.Context())
 	if err != nil {
+		slog.Error("Error loading chemistry logs", "error", err)
+	}
+	if len(logs) > recentLogsForLinking {
+		logs = logs[:recentLogsForLinking]
+	}
+	equipment, err := h.equipSvc.List(r.Context())
+	if err != nil {
+		slog.Error("Error loading equipment", "error", err)
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskCompleteForm(task, logs, equipment))
+}
+
+func (h *TaskHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	signals := &completionSignals{}
+	if err := datastar.ReadSignals(r, signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	cmd := command.CompleteTask{
+		ID:                id,
+		Notes:             signals.Notes,
+		DurationMinutes:   signals.DurationMinutes,
+		ChemistryLogID:    signals.ChemistryLogID,
+		ServiceRecordID:   signals.ServiceRecordID,
+		LogService:        signals.LogService,
+		ServiceCost:       signals.ServiceCost,
+		ServiceTechnician: signals.Technician,
+	}
+	for i, encoded := range signals.Photos {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			sse := datastar.NewSSE(w, r)
+			sse.PatchElementTempl(templates.ModalError("Could not read photo upload"))
+			return
+		}
+		name := "photo-" + strconv.Itoa(i+1)
+		if i < len(signals.PhotoNames) {
+			name = signals.PhotoNames[i]
+		}
+		cmd.Photos = append(cmd.Photos, command.TaskPhotoUpload{Filename: name, Data: data})
+	}
+
+	if _, err := h.svc.Complete(r.Context(), cmd); err != nil {
 		slog.Error("Error completing task", "error", err)
-		http.Error(w, "failed to complete task", http.StatusInternalServerError)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to complete task: " + err.Error()))
+		return
+	}
+
+	tasks, _ := h.svc.List(r.Context())
+	active, completed := splitTasks(tasks)
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskList(active, completed))
+	sse.PatchElementTempl(templates.EmptyModal())
+}
+
+func (h *TaskHandler) Checklist(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	task, err := h.svc.Get(r.Context(), id)
+	if err != nil || task == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskChecklist(task))
+}
+
+func (h *TaskHandler) CheckItem(w http.ResponseWriter, r *http.Request) {
+	task, err := h.svc.CheckItem(r.Context(), command.CheckTaskItem{
+		TaskID:  r.PathValue("id"),
+		ItemID:  r.PathValue("itemId"),
+		Checked: r.URL.Query().Get("checked") == "true",
+	})
+	if err != nil {
+		slog.Error("Error checking task item", "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to update checklist: " + err.Error()))
+		return
+	}
+
+	tasks, _ := h.svc.List(r.Context())
+	active, completed := splitTasks(tasks)
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskList(active, completed))
+	sse.PatchElementTempl(templates.TaskChecklist(task))
+}
+
+func (h *TaskHandler) SnoozeForm(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	task, err := h.svc.Get(r.Context(), id)
+	if err != nil || task == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskSnoozeForm(task))
+}
+
+func (h *TaskHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	signals := &snoozeSignals{}
+	if err := datastar.ReadSignals(r, signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.svc.Snooze(r.Context(), command.SnoozeTask{ID: id, Days: signals.Days}); err != nil {
+		slog.Error("Error snoozing task", "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to snooze task: " + err.Error()))
 		return
 	}
 
@@ -133,11 +297,103 @@ func (h *TaskHandler) Complete(w http.ResponseWriter, r *http.Request) {
 	active, completed := splitTasks(tasks)
 	sse := datastar.NewSSE(w, r)
 	sse.PatchElementTempl(templates.TaskList(active, completed))
+	sse.PatchElementTempl(templates.EmptyModal())
+}
+
+func (h *TaskHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := h.svc.Skip(r.Context(), id); err != nil {
+		slog.Error("Error skipping task", "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to skip task: " + err.Error()))
+		return
+	}
+
+	tasks, _ := h.svc.List(r.Context())
+	active, completed := splitTasks(tasks)
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskList(active, completed))
+	sse.PatchElementTempl(templates.EmptyModal())
+}
+
+func (h *TaskHandler) History(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	task, err := h.svc.Get(r.Context(), id)
+	if err != nil || task == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	history, err := h.svc.History(r.Context(), id)
+	if err != nil {
+		slog.Error("Error loading task history", "error", err)
+		http.Error(w, "failed to load task history", http.StatusInternalServerError)
+		return
+	}
+
+	byTask := make(map[string]*entities.TaskCompletion, len(history.Completions))
+	for i := range history.Completions {
+		byTask[history.Completions[i].TaskID.String()] = &history.Completions[i]
+	}
+
+	var entries []templates.TaskHistoryEntry
+	for _, t := range history.Occurrences {
+		entry := templates.TaskHistoryEntry{Task: t, Completion: byTask[t.ID.String()]}
+		if c := entry.Completion; c != nil {
+			if c.ChemistryLogID != nil {
+				if log, err := h.chemSvc.Get(r.Context(), c.ChemistryLogID.String()); err == nil && log != nil {
+					entry.ChemistryLogLabel = "Water test " + log.TestedAt.Format("Jan 2, 2006")
+				}
+			}
+			if c.ServiceRecordID != nil {
+				if sr, err := h.equipSvc.GetServiceRecord(r.Context(), c.ServiceRecordID.String()); err == nil && sr != nil {
+					entry.ServiceRecordLabel = sr.Description + " (" + sr.ServiceDate.Format("Jan 2, 2006") + ")"
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskHistory(task, templates.TaskSeriesSummary{
+		Completed:          history.Stats.Completed,
+		Skipped:            history.Stats.Skipped,
+		OnTimeRate:         history.Stats.OnTimeRate,
+		CurrentStreak:      history.Stats.CurrentStreak,
+		AvgDurationMinutes: history.Stats.AvgDurationMinutes,
+		Ended:              !history.Series.IsActive(),
+	}, entries))
+}
+
+func (h *TaskHandler) Photo(w http.ResponseWriter, r *http.Request) {
+	photo, err := h.svc.GetPhoto(r.Context(), r.PathValue("photoId"))
+	if err != nil || photo == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", photo.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(photo.Data)
+}
+
+func (h *TaskHandler) DeleteForm(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	task, err := h.svc.Get(r.Context(), id)
+	if err != nil || task == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskDeleteForm(task))
 }
 
 func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	if err := h.svc.Delete(r.Context(), id); err != nil {
+	err := h.svc.Delete(r.Context(), command.DeleteTask{
+		ID:    id,
+		Scope: r.URL.Query().Get("scope"),
+	})
+	if err != nil {
 		slog.Error("Error deleting task", "error", err)
 		http.Error(w, "failed to delete task", http.StatusInternalServerError)
 		return
@@ -147,11 +403,12 @@ func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	active, completed := splitTasks(tasks)
 	sse := datastar.NewSSE(w, r)
 	sse.PatchElementTempl(templates.TaskList(active, completed))
+	sse.PatchElementTempl(templates.EmptyModal())
 }
 
 func splitTasks(tasks []entities.Task) (active, completed []entities.Task) {
 	for _, t := range tasks {
-		if t.Status == entities.TaskStatusCompleted {
+		if !t.IsOpen() {
 			completed = append(completed, t)
 		} else {
 			active = append(active, t)
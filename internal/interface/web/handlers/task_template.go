@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/joshthewhite/poolvibes/internal/application/command"
+	"github.com/joshthewhite/poolvibes/internal/application/services"
+	"github.com/joshthewhite/poolvibes/internal/interface/web/templates"
+	"github.com/starfederation/datastar-go/datastar"
+)
+
+type TaskTemplateHandler struct {
+	svc     *services.TaskTemplateService
+	taskSvc *services.TaskService
+}
+
+func NewTaskTemplateHandler(svc *services.TaskTemplateService, taskSvc *services.TaskService) *TaskTemplateHandler {
+	return &TaskTemplateHandler{svc: svc, taskSvc: taskSvc}
+}
+
+type taskTemplateSignals struct {
+	Name        string `json:"templateName"`
+	Description string `json:"templateDescription"`
+	SiteWide    bool   `json:"templateSiteWide"`
+}
+
+func (h *TaskTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.patchLibrary(w, r)
+}
+
+func (h *TaskTemplateHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := h.svc.Apply(r.Context(), command.ApplyTaskTemplate{ID: id}); err != nil {
+		slog.Error("Error applying task template", "templateID", id, "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to apply template"))
+		return
+	}
+
+	tasks, _ := h.taskSvc.List(r.Context())
+	active, completed := splitTasks(tasks)
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskList(active, completed))
+	sse.PatchElementTempl(templates.EmptyModal())
+}
+
+func (h *TaskTemplateHandler) SaveForm(w http.ResponseWriter, r *http.Request) {
+	user, err := services.UserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	fromAdmin := r.URL.Query().Get("from") == "admin" && user.IsAdmin
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskTemplateSaveForm(user.IsAdmin, fromAdmin))
+}
+
+func (h *TaskTemplateHandler) Save(w http.ResponseWriter, r *http.Request) {
+	var signals taskTemplateSignals
+	if err := datastar.ReadSignals(r, &signals); err != nil {
+		http.Error(w, "invalid request data", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.svc.SaveFromTasks(r.Context(), command.SaveTaskTemplate{
+		Name:        signals.Name,
+		Description: signals.Description,
+		SiteWide:    signals.SiteWide,
+	})
+	if err != nil {
+		slog.Error("Error saving task template", "error", err)
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.ModalError("Failed to save template: " + err.Error()))
+		return
+	}
+
+	if r.URL.Query().Get("from") == "admin" {
+		siteWide, _ := h.svc.ListSiteWide(r.Context())
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElementTempl(templates.AdminTemplateList(siteWide))
+		sse.PatchElementTempl(templates.EmptyModal())
+		return
+	}
+	h.patchLibrary(w, r)
+}
+
+func (h *TaskTemplateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		slog.Error("Error deleting task template", "templateID", id, "error", err)
+		http.Error(w, "failed to delete template", http.StatusInternalServerError)
+		return
+	}
+	h.patchLibrary(w, r)
+}
+
+func (h *TaskTemplateHandler) patchLibrary(w http.ResponseWriter, r *http.Request) {
+	userID, err := services.UserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	list, err := h.svc.List(r.Context())
+	if err != nil {
+		slog.Error("Error listing task templates", "error", err)
+		http.Error(w, "failed to load templates", http.StatusInternalServerError)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElementTempl(templates.TaskTemplateLibrary(list, userID))
+}
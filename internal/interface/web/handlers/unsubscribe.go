@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/joshthewhite/poolvibes/internal/application/services"
+	"github.com/joshthewhite/poolvibes/internal/interface/web/templates"
+)
+
+type UnsubscribeHandler struct {
+	svc *services.UnsubscribeService
+}
+
+func NewUnsubscribeHandler(svc *services.UnsubscribeService) *UnsubscribeHandler {
+	return &UnsubscribeHandler{svc: svc}
+}
+
+// Page asks the user to confirm unsubscribing with the token in the URL.
+// It changes nothing itself, since mail scanners follow links in emails.
+func (h *UnsubscribeHandler) Page(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, category, err := h.svc.Check(token, time.Now())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		templates.UnsubscribePage(token, "", false, unsubscribeError(err)).Render(r.Context(), w)
+		return
+	}
+	templates.UnsubscribePage(token, category.Label(), false, "").Render(r.Context(), w)
+}
+
+// Unsubscribe turns off the emails the token in the URL names. It is
+// posted by the confirmation page, and by mail clients offering a
+// one-click unsubscribe (RFC 8058), without a session.
+func (h *UnsubscribeHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	category, err := h.svc.Unsubscribe(r.Context(), token)
+	if errors.Is(err, services.ErrUnsubscribeInvalid) || errors.Is(err, services.ErrUnsubscribeExpired) {
+		w.WriteHeader(http.StatusBadRequest)
+		templates.UnsubscribePage(token, "", false, unsubscribeError(err)).Render(r.Context(), w)
+		return
+	}
+	if err != nil {
+		slog.Error("Error unsubscribing", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		templates.UnsubscribePage(token, "", false, "Something went wrong. Please try again later.").Render(r.Context(), w)
+		return
+	}
+	templates.UnsubscribePage(token, category.Label(), true, "").Render(r.Context(), w)
+}
+
+func unsubscribeError(err error) string {
+	if errors.Is(err, services.ErrUnsubscribeExpired) {
+		return "This unsubscribe link has expired."
+	}
+	return "This unsubscribe link isn't valid."
+}
@@ -0,0 +1,126 @@
+// Package ical writes iCalendar (RFC 5545) feeds of all-day events.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line allowed before folding.
+const maxLineOctets = 75
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+)
+
+// Calendar is a published calendar of events.
+type Calendar struct {
+	// Name is shown by calendar apps that support X-WR-CALNAME.
+	Name string
+	// RefreshInterval hints how often subscribers should poll. Zero omits it.
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Event is an all-day event.
+type Event struct {
+	// UID must stay the same for the same event across feed refreshes.
+	UID         string
+	Summary     string
+	Description string
+	// Date is the day of the event, taken in its own location.
+	Date time.Time
+	// Stamp is when the event was last changed.
+	Stamp time.Time
+}
+
+// Write encodes the calendar to w.
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		writeFolded(bw, s)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//PoolVibes//Tasks//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	if d := formatDuration(c.RefreshInterval); d != "" {
+		line("REFRESH-INTERVAL;VALUE=DURATION:" + d)
+		line("X-PUBLISHED-TTL:" + d)
+	}
+	for _, e := range c.Events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeText(e.UID))
+		line("DTSTAMP:" + e.Stamp.UTC().Format(dateTimeFormat))
+		line("DTSTART;VALUE=DATE:" + e.Date.Format(dateFormat))
+		line("DTEND;VALUE=DATE:" + e.Date.AddDate(0, 0, 1).Format(dateFormat))
+		line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// formatDuration writes d as a DURATION value in whole minutes, or "" if d
+// is shorter than a minute.
+func formatDuration(d time.Duration) string {
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	if hours == 0 && minutes == 0 {
+		return ""
+	}
+	out := "PT"
+	if hours > 0 {
+		out += strconv.Itoa(hours) + "H"
+	}
+	if minutes > 0 {
+		out += strconv.Itoa(minutes) + "M"
+	}
+	return out
+}
+
+// escapeText escapes a TEXT property value.
+func escapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeFolded writes a content line terminated by CRLF, folding it onto
+// continuation lines so no line exceeds maxLineOctets. Folds never split a
+// UTF-8 sequence.
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space of a continuation line counts toward its length.
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
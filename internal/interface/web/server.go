@@ -20,20 +20,36 @@ type Server struct {
 	userSvc       *services.UserService
 	chemSvc       *services.ChemistryService
 	taskSvc       *services.TaskService
+	templateSvc   *services.TaskTemplateService
 	equipSvc      *services.EquipmentService
 	chemicSvc     *services.ChemicalService
+	calendarSvc   *services.CalendarService
+	reminderSvc   *services.ReminderService
+	alertSvc      *services.AlertService
+	webhookSvc    *services.WebhookService
+	outboxSvc     *services.OutboxService
+	smsReplySvc   *services.SMSReplyService
+	unsubSvc      *services.UnsubscribeService
 	milestoneRepo repositories.MilestoneRepository
 }
 
-func NewServer(authSvc *services.AuthService, userSvc *services.UserService, chemSvc *services.ChemistryService, taskSvc *services.TaskService, equipSvc *services.EquipmentService, chemicSvc *services.ChemicalService, milestoneRepo repositories.MilestoneRepository) *Server {
+func NewServer(authSvc *services.AuthService, userSvc *services.UserService, chemSvc *services.ChemistryService, taskSvc *services.TaskService, templateSvc *services.TaskTemplateService, equipSvc *services.EquipmentService, chemicSvc *services.ChemicalService, calendarSvc *services.CalendarService, reminderSvc *services.ReminderService, alertSvc *services.AlertService, webhookSvc *services.WebhookService, outboxSvc *services.OutboxService, smsReplySvc *services.SMSReplyService, unsubSvc *services.UnsubscribeService, milestoneRepo repositories.MilestoneRepository) *Server {
 	s := &Server{
 		mux:           http.NewServeMux(),
 		authSvc:       authSvc,
 		userSvc:       userSvc,
 		chemSvc:       chemSvc,
 		taskSvc:       taskSvc,
+		templateSvc:   templateSvc,
 		equipSvc:      equipSvc,
 		chemicSvc:     chemicSvc,
+		calendarSvc:   calendarSvc,
+		reminderSvc:   reminderSvc,
+		alertSvc:      alertSvc,
+		webhookSvc:    webhookSvc,
+		outboxSvc:     outboxSvc,
+		smsReplySvc:   smsReplySvc,
+		unsubSvc:      unsubSvc,
 		milestoneRepo: milestoneRepo,
 	}
 	s.setupRoutes()
@@ -44,11 +60,13 @@ func (s *Server) setupRoutes() {
 	pageHandler := handlers.NewPageHandler()
 	authHandler := handlers.NewAuthHandler(s.authSvc)
 	chemHandler := handlers.NewChemistryHandler(s.chemSvc, s.userSvc)
-	taskHandler := handlers.NewTaskHandler(s.taskSvc)
+	taskHandler := handlers.NewTaskHandler(s.taskSvc, s.chemSvc, s.equipSvc)
 	equipHandler := handlers.NewEquipmentHandler(s.equipSvc)
-	chemicHandler := handlers.NewChemicalHandler(s.chemicSvc)
-	adminHandler := handlers.NewAdminHandler(s.userSvc)
-	settingsHandler := handlers.NewSettingsHandler(s.userSvc)
+	chemicHandler := handlers.NewChemicalHandler(s.chemicSvc, s.chemSvc)
+	templateHandler := handlers.NewTaskTemplateHandler(s.templateSvc, s.taskSvc)
+	adminHandler := handlers.NewAdminHandler(s.userSvc, s.templateSvc, s.outboxSvc)
+	settingsHandler := handlers.NewSettingsHandler(s.userSvc, s.reminderSvc, s.alertSvc, s.webhookSvc, s.outboxSvc)
+	calendarHandler := handlers.NewCalendarHandler(s.calendarSvc)
 
 	auth := func(h http.HandlerFunc) http.HandlerFunc { return requireAuth(s.authSvc, h) }
 	admin := func(h http.HandlerFunc) http.HandlerFunc { return requireAdmin(s.authSvc, h) }
@@ -57,6 +75,7 @@ func (s *Server) setupRoutes() {
 	// Rate limiters for auth endpoints
 	loginLimiter := newIPLimiter(rate.Every(12*time.Second), 5)  // 5 per minute
 	signupLimiter := newIPLimiter(rate.Every(20*time.Second), 3) // 3 per minute
+	feedLimiter := newIPLimiter(rate.Every(10*time.Second), 6)   // 6 per minute
 
 	// Auth routes (no auth required)
 	s.mux.HandleFunc("GET /login", authHandler.LoginPage)
@@ -65,11 +84,24 @@ func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("POST /signup", rateLimit(signupLimiter, authHandler.Signup))
 	s.mux.HandleFunc("POST /logout", authHandler.Logout)
 
+	// Calendar feed (secret token in the URL, no session)
+	s.mux.HandleFunc("GET /calendar/{file}", rateLimit(feedLimiter, calendarHandler.Feed))
+
+	// Replies to text messages (Twilio signature, no session)
+	if s.smsReplySvc != nil {
+		s.mux.HandleFunc("POST /sms/inbound", handlers.NewSMSHandler(s.smsReplySvc).Inbound)
+	}
+
+	// Unsubscribe links in emails (signed token in the URL, no session)
+	unsubHandler := handlers.NewUnsubscribeHandler(s.unsubSvc)
+	s.mux.HandleFunc("GET /unsubscribe", unsubHandler.Page)
+	s.mux.HandleFunc("POST /unsubscribe", unsubHandler.Unsubscribe)
+
 	// Page (landing or dashboard depending on auth)
 	s.mux.HandleFunc("GET /{$}", maybeAuth(pageHandler.Root))
 
 	// Dashboard (auth required)
-	dashHandler := handlers.NewDashboardHandler(s.chemSvc, s.taskSvc, s.chemicSvc, s.milestoneRepo)
+	dashHandler := handlers.NewDashboardHandler(s.chemSvc, s.taskSvc, s.chemicSvc, s.milestoneRepo, s.webhookSvc)
 	s.mux.HandleFunc("GET /dashboard", auth(dashHandler.Page))
 
 	// Chemistry (auth required)
@@ -88,9 +120,26 @@ func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("POST /tasks", auth(taskHandler.Create))
 	s.mux.HandleFunc("GET /tasks/{id}/edit", auth(taskHandler.EditForm))
 	s.mux.HandleFunc("PUT /tasks/{id}", auth(taskHandler.Update))
+	s.mux.HandleFunc("GET /tasks/{id}/complete", auth(taskHandler.CompleteForm))
 	s.mux.HandleFunc("POST /tasks/{id}/complete", auth(taskHandler.Complete))
+	s.mux.HandleFunc("GET /tasks/{id}/checklist", auth(taskHandler.Checklist))
+	s.mux.HandleFunc("POST /tasks/{id}/checklist/{itemId}", auth(taskHandler.CheckItem))
+	s.mux.HandleFunc("GET /tasks/{id}/snooze", auth(taskHandler.SnoozeForm))
+	s.mux.HandleFunc("POST /tasks/{id}/snooze", auth(taskHandler.Snooze))
+	s.mux.HandleFunc("POST /tasks/{id}/skip", auth(taskHandler.Skip))
+	s.mux.HandleFunc("GET /tasks/{id}/history", auth(taskHandler.History))
+	s.mux.HandleFunc("GET /tasks/{id}/calendar", auth(calendarHandler.TaskICS))
+	s.mux.HandleFunc("GET /tasks/{id}/photos/{photoId}", auth(taskHandler.Photo))
+	s.mux.HandleFunc("GET /tasks/{id}/delete", auth(taskHandler.DeleteForm))
 	s.mux.HandleFunc("DELETE /tasks/{id}", auth(taskHandler.Delete))
 
+	// Task templates (auth required)
+	s.mux.HandleFunc("GET /task-templates", auth(templateHandler.List))
+	s.mux.HandleFunc("POST /task-templates/{id}/apply", auth(templateHandler.Apply))
+	s.mux.HandleFunc("GET /task-templates/new", auth(templateHandler.SaveForm))
+	s.mux.HandleFunc("POST /task-templates", auth(templateHandler.Save))
+	s.mux.HandleFunc("DELETE /task-templates/{id}", auth(templateHandler.Delete))
+
 	// Equipment (auth required)
 	s.mux.HandleFunc("GET /equipment", auth(equipHandler.List))
 	s.mux.HandleFunc("GET /equipment/new", auth(equipHandler.NewForm))
@@ -109,16 +158,35 @@ func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("GET /chemicals/{id}/edit", auth(chemicHandler.EditForm))
 	s.mux.HandleFunc("PUT /chemicals/{id}", auth(chemicHandler.Update))
 	s.mux.HandleFunc("POST /chemicals/{id}/adjust", auth(chemicHandler.AdjustStock))
+	s.mux.HandleFunc("GET /chemicals/{id}/movements/new", auth(chemicHandler.MovementForm))
+	s.mux.HandleFunc("POST /chemicals/{id}/movements", auth(chemicHandler.RecordMovement))
+	s.mux.HandleFunc("GET /chemicals/{id}/history", auth(chemicHandler.History))
 	s.mux.HandleFunc("DELETE /chemicals/{id}", auth(chemicHandler.Delete))
 
 	// Settings (auth required)
 	s.mux.HandleFunc("GET /settings", auth(settingsHandler.Page))
 	s.mux.HandleFunc("PUT /settings", auth(settingsHandler.Update))
+	s.mux.HandleFunc("POST /settings/calendar-token", auth(settingsHandler.RegenerateCalendarToken))
+	s.mux.HandleFunc("POST /settings/phone/code", auth(settingsHandler.SendPhoneCode))
+	s.mux.HandleFunc("POST /settings/phone/verify", auth(settingsHandler.VerifyPhone))
+	s.mux.HandleFunc("PUT /settings/push", auth(settingsHandler.UpdatePush))
+	s.mux.HandleFunc("POST /settings/push/test", auth(settingsHandler.TestPush))
+	s.mux.HandleFunc("POST /settings/reminders", auth(settingsHandler.CreateReminder))
+	s.mux.HandleFunc("DELETE /settings/reminders/{key}", auth(settingsHandler.DeleteReminder))
+	s.mux.HandleFunc("PUT /settings/alerts", auth(settingsHandler.UpdateAlerts))
+	s.mux.HandleFunc("POST /settings/webhooks", auth(settingsHandler.CreateWebhook))
+	s.mux.HandleFunc("DELETE /settings/webhooks/{id}", auth(settingsHandler.DeleteWebhook))
+	s.mux.HandleFunc("POST /settings/webhooks/deliveries/{id}/replay", auth(settingsHandler.ReplayDelivery))
 
 	// Admin (admin required)
 	s.mux.HandleFunc("GET /admin/users", admin(adminHandler.ListUsers))
 	s.mux.HandleFunc("GET /admin/users/{id}/edit", admin(adminHandler.EditUser))
 	s.mux.HandleFunc("PUT /admin/users/{id}", admin(adminHandler.UpdateUser))
+	s.mux.HandleFunc("GET /admin/templates", admin(adminHandler.ListTemplates))
+	s.mux.HandleFunc("GET /admin/templates/{id}/edit", admin(adminHandler.EditTemplate))
+	s.mux.HandleFunc("PUT /admin/templates/{id}", admin(adminHandler.UpdateTemplate))
+	s.mux.HandleFunc("DELETE /admin/templates/{id}", admin(adminHandler.DeleteTemplate))
+	s.mux.HandleFunc("GET /admin/notifications", admin(adminHandler.Notifications))
 }
 
 func (s *Server) Start(ctx context.Context, addr string) error {
@@ -1,9 +1,17 @@
 package templates
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/application/services"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
+	"github.com/joshthewhite/poolvibes/internal/domain/valueobjects"
 )
 
 func escapeJS(s string) string {
@@ -27,6 +35,36 @@ func fmtDatePtr(t *time.Time) string {
 	return t.Format("2006-01-02")
 }
 
+func fmtUUIDPtr(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// findEquipment returns the item with the given ID, or nil if id is nil or
+// not in the list.
+func findEquipment(items []entities.Equipment, id *uuid.UUID) *entities.Equipment {
+	if id == nil {
+		return nil
+	}
+	for i := range items {
+		if items[i].ID == *id {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+// webcalURL swaps the http(s) scheme for webcal so calendar apps offer to
+// subscribe instead of downloading the file once.
+func webcalURL(url string) string {
+	if i := strings.Index(url, "://"); i >= 0 {
+		return "webcal" + url[i:]
+	}
+	return url
+}
+
 func boolStr(b bool) string {
 	if b {
 		return "true"
@@ -42,6 +80,16 @@ func fmtFloatG(f float64) string {
 	return fmt.Sprintf("%g", f)
 }
 
+func fmtDuration(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
 func fmtGallons(n int) string {
 	s := fmt.Sprintf("%d", n)
 	if n < 1000 {
@@ -58,12 +106,218 @@ func fmtGallons(n int) string {
 	return string(result)
 }
 
-func dueInText(dueDate time.Time) string {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, dueDate.Location())
+// commonTimezones are suggested in the settings time zone field; any IANA
+// zone name is accepted.
+var commonTimezones = []string{
+	"UTC",
+	"America/New_York", "America/Chicago", "America/Denver", "America/Phoenix",
+	"America/Los_Angeles", "America/Anchorage", "Pacific/Honolulu",
+	"America/Toronto", "America/Vancouver", "America/Mexico_City", "America/Sao_Paulo",
+	"Europe/London", "Europe/Paris", "Europe/Berlin", "Europe/Madrid", "Europe/Athens",
+	"Africa/Johannesburg", "Asia/Dubai", "Asia/Kolkata", "Asia/Singapore", "Asia/Tokyo",
+	"Australia/Perth", "Australia/Brisbane", "Australia/Sydney", "Pacific/Auckland",
+}
 
-	days := int(due.Sub(today).Hours() / 24)
+// reminderOffsets are the choices offered when adding a reminder rule.
+var reminderOffsets = []int{-7, -3, -2, -1, 0, 1, 2, 3, 7, 14}
+
+// reminderOffsetLabel names a reminder offset in the add-reminder form.
+func reminderOffsetLabel(offset int) string {
+	switch {
+	case offset == -1:
+		return "Day before"
+	case offset < 0:
+		return fmt.Sprintf("%d days before", -offset)
+	case offset == 0:
+		return "Due day"
+	case offset == 1:
+		return "1 day overdue"
+	default:
+		return fmt.Sprintf("%d days overdue", offset)
+	}
+}
+
+// alertSignals returns the data-signals object for the alerts settings:
+// an alerts.<category>.<channel> flag per cell of the matrix, and the
+// no-test alert days.
+func alertSignals(prefs *entities.NotificationPreferences, noTestDays int, digest entities.DigestSchedule, quiet entities.QuietHours) string {
+	alerts := make(map[string]map[string]bool)
+	for _, category := range entities.NotificationCategories {
+		alerts[string(category)] = make(map[string]bool)
+		for _, channel := range entities.NotificationChannels {
+			alerts[string(category)][channel] = prefs.IsEnabled(category, channel)
+		}
+	}
+	b, _ := json.Marshal(map[string]any{
+		"alerts":          alerts,
+		"alertNoTestDays": noTestDays,
+		"alertDigestDay":  strconv.Itoa(int(digest.Weekday)),
+		"alertDigestHour": strconv.Itoa(digest.Hour),
+		"alertQuietHours": quiet.Enabled,
+		"alertQuietStart": strconv.Itoa(quiet.Start),
+		"alertQuietEnd":   strconv.Itoa(quiet.End),
+	})
+	return string(b)
+}
+
+// pushSignals returns the data-signals object for the push settings,
+// starting on ntfy if push isn't set up.
+func pushSignals(target entities.PushTarget) string {
+	service := target.Service
+	if service == "" {
+		service = entities.PushNtfy
+	}
+	b, _ := json.Marshal(map[string]string{
+		"pushService": service,
+		"pushUrl":     target.URL,
+		"pushToken":   target.Token,
+	})
+	return string(b)
+}
+
+// webhookSignals returns the data-signals object for the new webhook form:
+// its URL and a webhookEvents.<resource>.<action> flag per event, all off.
+func webhookSignals() string {
+	events := make(map[string]map[string]bool)
+	for _, event := range entities.WebhookEvents {
+		resource, action, _ := strings.Cut(string(event), ".")
+		if events[resource] == nil {
+			events[resource] = make(map[string]bool)
+		}
+		events[resource][action] = false
+	}
+	b, _ := json.Marshal(map[string]any{"webhookUrl": "", "webhookEvents": events})
+	return string(b)
+}
+
+func deliveryStatusClass(status string) string {
+	switch status {
+	case entities.DeliverySucceeded:
+		return "is-success is-light"
+	case entities.DeliveryFailed:
+		return "is-danger is-light"
+	default:
+		return "is-warning is-light"
+	}
+}
+
+// deliveryResponse summarises the last attempt of a delivery: its HTTP
+// status, and the error if it failed.
+func deliveryResponse(d entities.WebhookDelivery) string {
+	var parts []string
+	if d.ResponseCode != 0 {
+		parts = append(parts, strconv.Itoa(d.ResponseCode))
+	}
+	if d.LastError != "" {
+		parts = append(parts, d.LastError)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// outboxStatus returns the label and tag class for a notification's
+// delivery status.
+func outboxStatus(m entities.OutboxMessage) (label, class string) {
+	switch {
+	case m.Status == entities.OutboxSent:
+		return "Sent", "is-success is-light"
+	case m.Status == entities.OutboxDead:
+		return "Failed", "is-danger is-light"
+	case m.Attempts > 0:
+		return "Retrying", "is-warning is-light"
+	default:
+		return "Queued", "is-info is-light"
+	}
+}
+
+func channelLabel(channel string) string {
+	switch channel {
+	case entities.ChannelEmail:
+		return "Email"
+	case entities.ChannelSMS:
+		return "SMS"
+	case entities.ChannelPush:
+		return "Push"
+	default:
+		return channel
+	}
+}
+
+func movementKindLabel(kind entities.StockMovementKind) string {
+	switch kind {
+	case entities.StockPurchase:
+		return "Purchase"
+	case entities.StockDose:
+		return "Dose"
+	case entities.StockAdjustment:
+		return "Adjustment"
+	case entities.StockDisposal:
+		return "Disposal"
+	default:
+		return string(kind)
+	}
+}
+
+func movementKindClass(kind entities.StockMovementKind) string {
+	switch kind {
+	case entities.StockPurchase:
+		return "is-success is-light"
+	case entities.StockDose:
+		return "is-info is-light"
+	case entities.StockDisposal:
+		return "is-danger is-light"
+	default:
+		return "is-light"
+	}
+}
+
+// fmtStockChange formats a movement's signed amount, e.g. "+2.5 lbs".
+func fmtStockChange(amount float64, unit valueobjects.Unit) string {
+	return fmt.Sprintf("%+g %s", amount, unit)
+}
+
+// fmtUsage formats a daily usage to two significant figures.
+func fmtUsage(perDay float64) string {
+	return strconv.FormatFloat(perDay, 'g', 2, 64)
+}
+
+// runsOutText describes when a chemical runs out, e.g. "runs out in ~12
+// days (Mar 21)".
+func runsOutText(f *entities.StockForecast) string {
+	switch f.DaysLeft {
+	case 0:
+		return "runs out today"
+	case 1:
+		return "runs out tomorrow"
+	}
+	if f.DaysLeft > 365 {
+		return "lasts over a year"
+	}
+	return fmt.Sprintf("runs out in ~%d days (%s)", f.DaysLeft, f.RunsOutOn.Format("Jan 2"))
+}
+
+// reorderByText says when to reorder, e.g. "by Mar 14" or "now".
+func reorderByText(f *entities.StockForecast, today time.Time) string {
+	if !f.ReorderOn.After(today) {
+		return "now"
+	}
+	return "by " + f.ReorderOn.Format("Jan 2")
+}
+
+// userToday returns the signed-in user's local calendar date, in the form
+// due dates are stored.
+func userToday(ctx context.Context) time.Time {
+	return entities.DateOf(services.UserNow(ctx))
+}
+
+// inUserZone converts t to the signed-in user's timezone for display.
+func inUserZone(ctx context.Context, t time.Time) time.Time {
+	return t.In(services.UserNow(ctx).Location())
+}
+
+// dueInText describes when dueDate is relative to today, the user's local
+// calendar date.
+func dueInText(dueDate, today time.Time) string {
+	days := int(entities.DateOf(dueDate).Sub(today).Hours() / 24)
 
 	switch {
 	case days == 0:
@@ -103,9 +357,12 @@ func dueInText(dueDate time.Time) string {
 	}
 }
 
-// relativeTime returns a human-friendly relative time string for a past timestamp.
-func relativeTime(t time.Time) string {
-	return relativeTimeFrom(t, time.Now())
+// relativeTime returns a human-friendly relative time string for a past
+// wall-clock time entered by the user, such as when water was tested.
+func relativeTime(ctx context.Context, t time.Time) string {
+	now := services.UserNow(ctx)
+	wall := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.UTC)
+	return relativeTimeFrom(t, wall)
 }
 
 // relativeTimeFrom returns a relative time string using the given reference time.
@@ -228,12 +485,8 @@ func showingRange(page, pageSize, totalItems int) string {
 	return fmt.Sprintf("Showing %d\u2013%d of %d", start, end, totalItems)
 }
 
-func dueInClass(dueDate time.Time) string {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, dueDate.Location())
-
-	days := int(due.Sub(today).Hours() / 24)
+func dueInClass(dueDate, today time.Time) string {
+	days := int(entities.DateOf(dueDate).Sub(today).Hours() / 24)
 
 	switch {
 	case days < 0:
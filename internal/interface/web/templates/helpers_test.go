@@ -3,6 +3,9 @@ package templates
 import (
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/joshthewhite/poolvibes/internal/domain/entities"
 )
 
 func TestRelativeTime(t *testing.T) {
@@ -37,3 +40,71 @@ func TestRelativeTime(t *testing.T) {
 		})
 	}
 }
+
+func TestDueInText(t *testing.T) {
+	today := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		dueDate time.Time
+		want    string
+	}{
+		{"today", time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), "due today"},
+		{"tomorrow", time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC), "due tomorrow"},
+		{"yesterday", time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC), "overdue 1 day"},
+		// The due date is a calendar date; its clock time never shifts the day.
+		{"late evening", time.Date(2026, 2, 16, 23, 0, 0, 0, time.UTC), "due tomorrow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dueInText(tt.dueDate, today); got != tt.want {
+				t.Errorf("dueInText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindEquipment(t *testing.T) {
+	pump := entities.Equipment{ID: uuid.New(), Name: "Pump"}
+	filter := entities.Equipment{ID: uuid.New(), Name: "Filter"}
+	items := []entities.Equipment{pump, filter}
+	missing := uuid.New()
+
+	tests := []struct {
+		name string
+		id   *uuid.UUID
+		want string
+	}{
+		{"nil ID", nil, ""},
+		{"found", &filter.ID, "Filter"},
+		{"not in list", &missing, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findEquipment(items, tt.id)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("findEquipment() = %q, want nil", got.Name)
+				}
+				return
+			}
+			if got == nil || got.Name != tt.want {
+				t.Errorf("findEquipment() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebcalURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://pool.example.com/calendar/abc.ics", "webcal://pool.example.com/calendar/abc.ics"},
+		{"http://localhost:8080/calendar/abc.ics", "webcal://localhost:8080/calendar/abc.ics"},
+		{"/calendar/abc.ics", "/calendar/abc.ics"},
+	}
+	for _, tt := range tests {
+		if got := webcalURL(tt.in); got != tt.want {
+			t.Errorf("webcalURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
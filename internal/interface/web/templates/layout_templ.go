@@ -8,7 +8,9 @@ package templates
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
-func Layout(email string, isAdmin bool) templ.Component {
+// Layout is the app shell. completeTaskID, from an email's "Mark done"
+// link, opens the Tasks tab with that task's completion form.
+func Layout(email string, isAdmin bool, completeTaskID string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -29,30 +31,68 @@ func Layout(email string, isAdmin bool) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>PoolVibes - Pool Maintenance</title><link rel=\"preconnect\" href=\"https://fonts.googleapis.com\"><link rel=\"preconnect\" href=\"https://fonts.gstatic.com\" crossorigin><link href=\"https://fonts.googleapis.com/css2?family=Inter:wght@400;500;600;700&family=Inter+Tight:wght@600;700;800&display=swap\" rel=\"stylesheet\"><link rel=\"stylesheet\" href=\"https://cdn.jsdelivr.net/npm/bulma@1.0.4/css/bulma.min.css\"><link rel=\"stylesheet\" href=\"https://cdnjs.cloudflare.com/ajax/libs/font-awesome/6.5.1/css/all.min.css\"><script type=\"module\" src=\"https://cdn.jsdelivr.net/gh/starfederation/datastar@v1.0.0-RC.7/bundles/datastar.js\"></script><script src=\"https://cdn.jsdelivr.net/npm/chart.js@4.4.7/dist/chart.umd.min.js\"></script><style>\n\t\t\t\t/* PoolVibes Design System */\n\t\t\t\t:root {\n\t\t\t\t\t--pv-primary: #0d9488;\n\t\t\t\t\t--pv-primary-hover: #0f766e;\n\t\t\t\t\t--pv-primary-light: #ccfbf1;\n\t\t\t\t\t--pv-navbar: #13111C;\n\t\t\t\t\t--pv-success: #10b981;\n\t\t\t\t\t--pv-danger: #ef4444;\n\t\t\t\t\t--pv-warning: #f59e0b;\n\t\t\t\t\t--pv-bg: #f8f7fc;\n\t\t\t\t\t--pv-border: #e0dce8;\n\t\t\t\t\t--pv-text: #1a1726;\n\t\t\t\t\t--pv-text-secondary: #6e6a80;\n\n\t\t\t\t\t/* Bulma overrides */\n\t\t\t\t\t--bulma-primary: var(--pv-primary);\n\t\t\t\t\t--bulma-primary-h: 175;\n\t\t\t\t\t--bulma-primary-s: 84%;\n\t\t\t\t\t--bulma-primary-l: 32%;\n\t\t\t\t\t--bulma-link: var(--pv-primary);\n\t\t\t\t\t--bulma-link-h: 175;\n\t\t\t\t\t--bulma-link-s: 84%;\n\t\t\t\t\t--bulma-link-l: 32%;\n\t\t\t\t\t--bulma-success: var(--pv-success);\n\t\t\t\t\t--bulma-success-h: 160;\n\t\t\t\t\t--bulma-success-s: 84%;\n\t\t\t\t\t--bulma-success-l: 39%;\n\t\t\t\t\t--bulma-danger: var(--pv-danger);\n\t\t\t\t\t--bulma-danger-h: 0;\n\t\t\t\t\t--bulma-danger-s: 84%;\n\t\t\t\t\t--bulma-danger-l: 60%;\n\t\t\t\t\t--bulma-warning: var(--pv-warning);\n\t\t\t\t\t--bulma-warning-h: 38;\n\t\t\t\t\t--bulma-warning-s: 92%;\n\t\t\t\t\t--bulma-warning-l: 50%;\n\t\t\t\t}\n\n\t\t\t\tbody {\n\t\t\t\t\tfont-family: 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;\n\t\t\t\t\tbackground: var(--pv-bg);\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t}\n\n\t\t\t\t[data-show] { display: none; }\n\n\t\t\t\t/* Navbar */\n\t\t\t\t.navbar.pv-navbar {\n\t\t\t\t\tbackground: var(--pv-navbar);\n\t\t\t\t\tmin-height: 3.5rem;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .navbar-item,\n\t\t\t\t.navbar.pv-navbar .navbar-brand .navbar-item {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .navbar-brand .navbar-item strong {\n\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\tletter-spacing: -0.025em;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .navbar-item.pv-email {\n\t\t\t\t\tcolor: #8b869e;\n\t\t\t\t\tfont-size: 0.875rem;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .button.pv-logout {\n\t\t\t\t\tcolor: #8b869e;\n\t\t\t\t\tborder-color: #2a2640;\n\t\t\t\t\tbackground: transparent;\n\t\t\t\t\tfont-size: 0.8rem;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .button.pv-logout:hover {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t\tborder-color: #8b869e;\n\t\t\t\t}\n\n\t\t\t\t/* Navbar burger */\n\t\t\t\t.navbar.pv-navbar .navbar-burger {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t}\n\n\t\t\t\t/* Nav links */\n\t\t\t\t.navbar.pv-navbar .pv-nav-link {\n\t\t\t\t\tcolor: #8b869e;\n\t\t\t\t\tfont-size: 0.875rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t\tborder-bottom: 2px solid transparent;\n\t\t\t\t\ttransition: color 0.15s, border-color 0.15s;\n\t\t\t\t\tcursor: pointer;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .pv-nav-link:hover {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t\tbackground: transparent;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .pv-nav-link.is-active {\n\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\tborder-bottom-color: var(--pv-primary);\n\t\t\t\t}\n\n\t\t\t\t@media screen and (max-width: 1023px) {\n\t\t\t\t\t.navbar.pv-navbar .navbar-menu {\n\t\t\t\t\t\tbackground: var(--pv-navbar);\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .navbar-menu .navbar-item {\n\t\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .navbar-menu .navbar-item:hover {\n\t\t\t\t\t\tbackground: #2a2640;\n\t\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .pv-nav-link {\n\t\t\t\t\t\tborder-bottom: none;\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .pv-nav-link.is-active {\n\t\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\t\tbackground: rgba(255, 255, 255, 0.08);\n\t\t\t\t\t\tborder-bottom: none;\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\t/* Tabs — underline style */\n\t\t\t\t.tabs.pv-tabs {\n\t\t\t\t\tborder-bottom-color: var(--pv-border);\n\t\t\t\t\tfont-size: 0.925rem;\n\t\t\t\t}\n\t\t\t\t.tabs.pv-tabs li a {\n\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\tborder-bottom-color: transparent;\n\t\t\t\t\tborder-bottom-width: 2px;\n\t\t\t\t\tpadding-bottom: calc(0.5em - 2px);\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\t\t\t\t.tabs.pv-tabs li a:hover {\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\tborder-bottom-color: #c8c3d4;\n\t\t\t\t}\n\t\t\t\t.tabs.pv-tabs li.is-active a {\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\tborder-bottom-color: var(--pv-primary);\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t}\n\n\t\t\t\t/* Cards & Boxes */\n\t\t\t\t.box, .card {\n\t\t\t\t\tborder: 1px solid var(--pv-border);\n\t\t\t\t\tborder-radius: 0.5rem;\n\t\t\t\t\tbox-shadow: 0 1px 3px rgba(26, 23, 38, 0.04), 0 2px 8px rgba(26, 23, 38, 0.03);\n\t\t\t\t}\n\t\t\t\t.card-content {\n\t\t\t\t\tpadding: 1.25rem;\n\t\t\t\t}\n\n\t\t\t\t/* Tables */\n\t\t\t\t.table thead th {\n\t\t\t\t\tbackground: #f1eff8;\n\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tfont-size: 0.8rem;\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 0.05em;\n\t\t\t\t\tborder-bottom: 2px solid var(--pv-border);\n\t\t\t\t}\n\t\t\t\t.table td {\n\t\t\t\t\tborder-color: #f1eff8;\n\t\t\t\t\tvertical-align: middle;\n\t\t\t\t}\n\t\t\t\t.table.is-striped tbody tr:nth-child(even) {\n\t\t\t\t\tbackground: #f8f7fc;\n\t\t\t\t}\n\t\t\t\t.table.is-hoverable tbody tr:hover {\n\t\t\t\t\tbackground: #f1eff8;\n\t\t\t\t}\n\n\t\t\t\t/* Buttons */\n\t\t\t\t.button.is-primary {\n\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\tborder-color: transparent;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\t\t\t\t.button.is-primary:hover {\n\t\t\t\t\tbackground: var(--pv-primary-hover);\n\t\t\t\t}\n\t\t\t\t.button.is-primary.is-outlined {\n\t\t\t\t\tbackground: transparent;\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t}\n\t\t\t\t.button.is-primary.is-outlined:hover {\n\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\tcolor: #fff;\n\t\t\t\t}\n\t\t\t\t.button {\n\t\t\t\t\tborder-radius: 0.375rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\n\t\t\t\t/* Modals */\n\t\t\t\t.modal-card-head {\n\t\t\t\t\tborder-top: 3px solid var(--pv-primary);\n\t\t\t\t\tbackground: #fff;\n\t\t\t\t\tborder-bottom: 1px solid var(--pv-border);\n\t\t\t\t}\n\t\t\t\t.modal-card-title {\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t}\n\t\t\t\t.modal-card {\n\t\t\t\t\tborder-radius: 0.5rem;\n\t\t\t\t\toverflow: hidden;\n\t\t\t\t}\n\n\t\t\t\t/* Inputs */\n\t\t\t\t.input, .textarea, .select select {\n\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\tborder-radius: 0.375rem;\n\t\t\t\t}\n\t\t\t\t.input:focus, .textarea:focus, .select select:focus {\n\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t\tbox-shadow: 0 0 0 2px rgba(13, 148, 136, 0.15);\n\t\t\t\t}\n\t\t\t\t.label {\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t\tfont-size: 0.875rem;\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t}\n\n\t\t\t\t/* Tags */\n\t\t\t\t.tag {\n\t\t\t\t\tborder-radius: 0.375rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\n\t\t\t\t/* Neumorphic cards */\n\t\t\t\t.pv-neumorphic {\n\t\t\t\t\tbackground: var(--pv-bg);\n\t\t\t\t\tborder: none !important;\n\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t6px 6px 14px rgba(26, 23, 38, 0.07),\n\t\t\t\t\t\t-6px -6px 14px rgba(255, 255, 255, 0.7);\n\t\t\t\t\ttransition: box-shadow 0.2s ease;\n\t\t\t\t}\n\t\t\t\t.pv-neumorphic:hover {\n\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t8px 8px 18px rgba(26, 23, 38, 0.1),\n\t\t\t\t\t\t-8px -8px 18px rgba(255, 255, 255, 0.8);\n\t\t\t\t}\n\n\t\t\t\t/* Empty states */\n\t\t\t\t.has-text-grey-light {\n\t\t\t\t\topacity: 0.8;\n\t\t\t\t}\n\n\t\t\t\t/* Value classes for chemistry readings */\n\t\t\t\t.value-ok {\n\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t}\n\t\t\t\t.value-warn {\n\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\tfont-weight: 700;\n\t\t\t\t}\n\n\t\t\t\t/* Titles */\n\t\t\t\t.title, h1, h2, h3 {\n\t\t\t\t\tfont-family: 'Inter Tight', 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\tfont-weight: 700;\n\t\t\t\t\tletter-spacing: -0.025em;\n\t\t\t\t}\n\n\t\t\t\t/* Notification tweaks */\n\t\t\t\t.notification {\n\t\t\t\t\tborder-radius: 0.5rem;\n\t\t\t\t}\n\n\t\t\t\t/* Section padding adjustment */\n\t\t\t\t.section {\n\t\t\t\t\tpadding-left: 1.5rem;\n\t\t\t\t\tpadding-right: 1.5rem;\n\t\t\t\t}\n\n\t\t\t\t/* Utility classes (light defaults) */\n\t\t\t\t.pv-divider {\n\t\t\t\t\theight: 1px;\n\t\t\t\t\tbackground: #e8e5f0;\n\t\t\t\t\tborder: none;\n\t\t\t\t}\n\t\t\t\t.pv-low-stock {\n\t\t\t\t\tborder: 2px solid hsl(348, 86%, 61%);\n\t\t\t\t\tbackground: hsl(348, 86%, 97%);\n\t\t\t\t}\n\t\t\t\t.pv-complete-btn {\n\t\t\t\t\twidth: 28px;\n\t\t\t\t\theight: 28px;\n\t\t\t\t\tborder: 2px solid #d4d0de;\n\t\t\t\t\tpadding: 0;\n\t\t\t\t}\n\n\t\t\t\t/* Pool Health Card */\n\t\t\t\t.pv-health-card {\n\t\t\t\t\tpadding: 1rem 1.25rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-card-inner {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-direction: column;\n\t\t\t\t\tgap: 0.75rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-card-score {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-direction: column;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 0.25rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-score-row {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\talign-items: baseline;\n\t\t\t\t\tgap: 0.4rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-number {\n\t\t\t\t\tfont-size: 2.5rem;\n\t\t\t\t\tfont-weight: 800;\n\t\t\t\t\tline-height: 1;\n\t\t\t\t}\n\t\t\t\t.pv-health-info {\n\t\t\t\t\tfont-size: 0.75rem;\n\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\tcursor: help;\n\t\t\t\t\topacity: 0.5;\n\t\t\t\t\ttransition: opacity 0.15s;\n\t\t\t\t}\n\t\t\t\t.pv-health-info:hover {\n\t\t\t\t\topacity: 0.8;\n\t\t\t\t}\n\t\t\t\t.pv-health-label {\n\t\t\t\t\tfont-size: 0.8rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-divider {\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\theight: 1px;\n\t\t\t\t\tbackground: var(--pv-border);\n\t\t\t\t\tborder: none;\n\t\t\t\t}\n\t\t\t\t.pv-health-card-details {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-wrap: wrap;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tjustify-content: center;\n\t\t\t\t\tgap: 0.5rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-streaks {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tgap: 0.4rem;\n\t\t\t\t\tflex-shrink: 0;\n\t\t\t\t}\n\t\t\t\t.pv-streak-pill {\n\t\t\t\t\tdisplay: inline-flex;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 0.25rem;\n\t\t\t\t\tpadding: 0.2rem 0.5rem;\n\t\t\t\t\tborder-radius: 999px;\n\t\t\t\t\tfont-size: 0.7rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t\tbackground: var(--pv-primary-light);\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t}\n\t\t\t\t.pv-health-milestones {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-wrap: wrap;\n\t\t\t\t\tjustify-content: center;\n\t\t\t\t\tgap: 0.35rem;\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge {\n\t\t\t\t\tdisplay: inline-flex;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 0.25rem;\n\t\t\t\t\tpadding: 0.2rem 0.5rem;\n\t\t\t\t\tborder-radius: 999px;\n\t\t\t\t\tfont-size: 0.65rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge.is-earned {\n\t\t\t\t\tbackground: var(--pv-primary-light);\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge.is-locked {\n\t\t\t\t\tbackground: #f0eef5;\n\t\t\t\t\tcolor: #c0bdd0;\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge.is-new {\n\t\t\t\t\tanimation: pv-milestone-glow 1.5s ease-in-out;\n\t\t\t\t}\n\t\t\t\t@keyframes pv-milestone-glow {\n\t\t\t\t\t0%, 100% { transform: scale(1); box-shadow: none; }\n\t\t\t\t\t50% { transform: scale(1.05); box-shadow: 0 0 8px rgba(13, 148, 136, 0.3); }\n\t\t\t\t}\n\n\t\t\t\t/* Dark mode */\n\t\t\t\t@media (prefers-color-scheme: dark) {\n\t\t\t\t\t:root {\n\t\t\t\t\t\t--pv-primary: #2dd4bf;\n\t\t\t\t\t\t--pv-primary-hover: #14b8a6;\n\t\t\t\t\t\t--pv-primary-light: #042f2e;\n\t\t\t\t\t\t--pv-navbar: #0d0b14;\n\t\t\t\t\t\t--pv-success: #34d399;\n\t\t\t\t\t\t--pv-danger: #f87171;\n\t\t\t\t\t\t--pv-warning: #fbbf24;\n\t\t\t\t\t\t--pv-bg: #13111C;\n\t\t\t\t\t\t--pv-border: #211e2e;\n\t\t\t\t\t\t--pv-text: #eeedf5;\n\t\t\t\t\t\t--pv-text-secondary: #8b869e;\n\t\t\t\t\t\t--pv-surface: #1c1929;\n\t\t\t\t\t\t--pv-surface-hover: #2a2640;\n\t\t\t\t\t\t--pv-surface-alt: #181530;\n\n\t\t\t\t\t\t/* Bulma dark overrides */\n\t\t\t\t\t\t--bulma-primary-h: 168;\n\t\t\t\t\t\t--bulma-primary-s: 72%;\n\t\t\t\t\t\t--bulma-primary-l: 51%;\n\t\t\t\t\t\t--bulma-link-h: 168;\n\t\t\t\t\t\t--bulma-link-s: 72%;\n\t\t\t\t\t\t--bulma-link-l: 51%;\n\t\t\t\t\t\t--bulma-success-h: 160;\n\t\t\t\t\t\t--bulma-success-s: 67%;\n\t\t\t\t\t\t--bulma-success-l: 52%;\n\t\t\t\t\t\t--bulma-danger-h: 0;\n\t\t\t\t\t\t--bulma-danger-s: 91%;\n\t\t\t\t\t\t--bulma-danger-l: 71%;\n\t\t\t\t\t\t--bulma-warning-h: 43;\n\t\t\t\t\t\t--bulma-warning-s: 96%;\n\t\t\t\t\t\t--bulma-warning-l: 56%;\n\t\t\t\t\t\t--bulma-scheme-main: var(--pv-bg);\n\t\t\t\t\t\t--bulma-scheme-main-bis: var(--pv-surface);\n\t\t\t\t\t\t--bulma-scheme-main-ter: var(--pv-surface-hover);\n\t\t\t\t\t\t--bulma-text: var(--pv-text);\n\t\t\t\t\t\t--bulma-text-strong: #ffffff;\n\t\t\t\t\t\t--bulma-border: var(--pv-border);\n\t\t\t\t\t\t--bulma-border-weak: #181530;\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Cards & Boxes */\n\t\t\t\t\t.box, .card {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tbox-shadow: 0 1px 3px rgba(0, 0, 0, 0.2), 0 4px 12px rgba(0, 0, 0, 0.12);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Tables */\n\t\t\t\t\t.table thead th {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\t}\n\t\t\t\t\t.table td {\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.table {\n\t\t\t\t\t\tbackground-color: var(--pv-bg);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.table.is-striped tbody tr:nth-child(even) {\n\t\t\t\t\t\tbackground: var(--pv-surface-alt);\n\t\t\t\t\t}\n\t\t\t\t\t.table.is-hoverable tbody tr:hover {\n\t\t\t\t\t\tbackground: var(--pv-surface-hover);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Modal */\n\t\t\t\t\t.modal-card-head {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tborder-bottom-color: var(--pv-border);\n\t\t\t\t\t}\n\t\t\t\t\t.modal-card-body {\n\t\t\t\t\t\tbackground: var(--pv-bg);\n\t\t\t\t\t}\n\t\t\t\t\t.modal-card-foot {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tborder-top-color: var(--pv-border);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Inputs */\n\t\t\t\t\t.input, .textarea, .select select {\n\t\t\t\t\t\tbackground-color: var(--pv-surface);\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.input:focus, .textarea:focus, .select select:focus {\n\t\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t\t\tbox-shadow: 0 0 0 2px rgba(45, 212, 191, 0.15);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Buttons */\n\t\t\t\t\t.button {\n\t\t\t\t\t\tbackground-color: var(--pv-surface);\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.button:hover {\n\t\t\t\t\t\tborder-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary {\n\t\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t\tborder-color: transparent;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary:hover {\n\t\t\t\t\t\tbackground: var(--pv-primary-hover);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary.is-outlined {\n\t\t\t\t\t\tbackground: transparent;\n\t\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary.is-outlined:hover {\n\t\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-danger.is-outlined {\n\t\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\t\tborder-color: var(--pv-danger);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-danger.is-outlined:hover {\n\t\t\t\t\t\tbackground: var(--pv-danger);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-success.is-outlined {\n\t\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\t\tborder-color: var(--pv-success);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-success.is-outlined:hover {\n\t\t\t\t\t\tbackground: var(--pv-success);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-white {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-ghost {\n\t\t\t\t\t\tbackground: transparent;\n\t\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Tabs */\n\t\t\t\t\t.tabs.pv-tabs li a:hover {\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t\tborder-bottom-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Tags */\n\t\t\t\t\t.tag.is-light {\n\t\t\t\t\t\tbackground: var(--pv-surface-hover);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.tag.is-success.is-light {\n\t\t\t\t\t\tbackground: rgba(52, 211, 153, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\t}\n\t\t\t\t\t.tag.is-danger.is-light {\n\t\t\t\t\t\tbackground: rgba(248, 113, 113, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\t}\n\t\t\t\t\t.tag.is-warning.is-light {\n\t\t\t\t\t\tbackground: rgba(251, 191, 36, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-warning);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Notification */\n\t\t\t\t\t.notification.is-danger.is-light {\n\t\t\t\t\t\tbackground: rgba(248, 113, 113, 0.1);\n\t\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\t}\n\t\t\t\t\t.notification.is-success.is-light {\n\t\t\t\t\t\tbackground: rgba(52, 211, 153, 0.1);\n\t\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Select dropdown arrow */\n\t\t\t\t\t.select:not(.is-multiple):not(.is-loading)::after {\n\t\t\t\t\t\tborder-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Neumorphic cards (dark) */\n\t\t\t\t\t.pv-neumorphic {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t\t6px 6px 14px rgba(0, 0, 0, 0.35),\n\t\t\t\t\t\t\t-6px -6px 14px rgba(40, 37, 55, 0.4);\n\t\t\t\t\t}\n\t\t\t\t\t.pv-neumorphic:hover {\n\t\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t\t8px 8px 18px rgba(0, 0, 0, 0.4),\n\t\t\t\t\t\t\t-8px -8px 18px rgba(40, 37, 55, 0.5);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Gamification (dark) */\n\t\t\t\t\t.pv-milestone-badge.is-earned,\n\t\t\t\t\t.pv-streak-pill {\n\t\t\t\t\t\tbackground: rgba(13, 148, 136, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\t}\n\t\t\t\t\t.pv-milestone-badge.is-locked {\n\t\t\t\t\t\tbackground: rgba(255, 255, 255, 0.05);\n\t\t\t\t\t\tcolor: #5a5770;\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Divider */\n\t\t\t\t\t.pv-divider {\n\t\t\t\t\t\tbackground: var(--pv-border);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Low stock card */\n\t\t\t\t\t.pv-low-stock {\n\t\t\t\t\t\tborder-color: var(--pv-danger);\n\t\t\t\t\t\tbackground: rgba(248, 113, 113, 0.1);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Complete button */\n\t\t\t\t\t.pv-complete-btn {\n\t\t\t\t\t\tborder-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Text helpers */\n\t\t\t\t\t.has-text-grey, .has-text-grey-light {\n\t\t\t\t\t\tcolor: var(--pv-text-secondary) !important;\n\t\t\t\t\t}\n\t\t\t\t\t.has-text-weight-bold, .has-text-weight-semibold {\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Delete button (X) */\n\t\t\t\t\t.delete {\n\t\t\t\t\t\tbackground-color: var(--pv-surface-hover);\n\t\t\t\t\t}\n\t\t\t\t\t.delete:hover {\n\t\t\t\t\t\tbackground-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t/* Mobile responsive adjustments */\n\t\t\t\t@media screen and (max-width: 768px) {\n\t\t\t\t\t.section {\n\t\t\t\t\t\tpadding-left: 0.75rem;\n\t\t\t\t\t\tpadding-right: 0.75rem;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-small {\n\t\t\t\t\t\tmin-height: 2.25rem;\n\t\t\t\t\t\tpadding-left: 0.75rem;\n\t\t\t\t\t\tpadding-right: 0.75rem;\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\t/* Chemistry table mobile expandable rows */\n\t\t\t\t@media screen and (max-width: 768px) {\n\t\t\t\t\t.pv-hidden-mobile {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-expand-btn {\n\t\t\t\t\t\tdisplay: inline-flex !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-detail-row td {\n\t\t\t\t\t\tpadding-top: 0;\n\t\t\t\t\t\tborder-top: none;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-kebab-menu {\n\t\t\t\t\t\tdisplay: inline-flex !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-action-btn-desktop {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-filter-toggle {\n\t\t\t\t\t\tdisplay: flex !important;\n\t\t\t\t\t\tjustify-content: space-between;\n\t\t\t\t\t\talign-items: center;\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\t@media screen and (min-width: 769px) {\n\t\t\t\t\t.pv-expand-btn {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-detail-row {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-kebab-menu {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-filter-toggle {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-filter-content {\n\t\t\t\t\t\tdisplay: block !important;\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\t.pv-kebab-menu .dropdown-menu {\n\t\t\t\t\tmin-width: 8rem;\n\t\t\t\t}\n\t\t\t</style></head><body><script>window._savedTab = localStorage.getItem('poolvibes_tab') || 'dashboard';</script><div data-signals:tab=\"window._savedTab\" data-signals:_loading=\"false\" data-signals:_menuOpen=\"false\" data-effect=\"localStorage.setItem('poolvibes_tab', $tab)\"><!-- Navbar --><nav class=\"navbar pv-navbar\" role=\"navigation\" aria-label=\"main navigation\"><div class=\"container\"><div class=\"navbar-brand\"><a class=\"navbar-item\" href=\"/\"><strong class=\"is-size-4\">PoolVibes</strong></a> <a role=\"button\" class=\"navbar-burger\" aria-label=\"menu\" data-attr:aria-expanded=\"$_menuOpen\" data-class:is-active=\"$_menuOpen\" data-on:click=\"$_menuOpen = !$_menuOpen\"><span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span></a></div><div class=\"navbar-menu\" data-class:is-active=\"$_menuOpen\"><div class=\"navbar-start\"><a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'dashboard'\" data-on:click=\"$tab = 'dashboard'; @get('/dashboard'); $_menuOpen = false\">Dashboard</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'chemistry'\" data-on:click=\"$tab = 'chemistry'; @get('/chemistry'); $_menuOpen = false\">Chemistry</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'tasks'\" data-on:click=\"$tab = 'tasks'; @get('/tasks'); $_menuOpen = false\">Tasks</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'equipment'\" data-on:click=\"$tab = 'equipment'; @get('/equipment'); $_menuOpen = false\">Equipment</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'chemicals'\" data-on:click=\"$tab = 'chemicals'; @get('/chemicals'); $_menuOpen = false\">Chemicals</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'settings'\" data-on:click=\"$tab = 'settings'; @get('/settings'); $_menuOpen = false\">Settings</a> ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>PoolVibes - Pool Maintenance</title><link rel=\"preconnect\" href=\"https://fonts.googleapis.com\"><link rel=\"preconnect\" href=\"https://fonts.gstatic.com\" crossorigin><link href=\"https://fonts.googleapis.com/css2?family=Inter:wght@400;500;600;700&family=Inter+Tight:wght@600;700;800&display=swap\" rel=\"stylesheet\"><link rel=\"stylesheet\" href=\"https://cdn.jsdelivr.net/npm/bulma@1.0.4/css/bulma.min.css\"><link rel=\"stylesheet\" href=\"https://cdnjs.cloudflare.com/ajax/libs/font-awesome/6.5.1/css/all.min.css\"><script type=\"module\" src=\"https://cdn.jsdelivr.net/gh/starfederation/datastar@v1.0.0-RC.7/bundles/datastar.js\"></script><script src=\"https://cdn.jsdelivr.net/npm/chart.js@4.4.7/dist/chart.umd.min.js\"></script><style>\n\t\t\t\t/* PoolVibes Design System */\n\t\t\t\t:root {\n\t\t\t\t\t--pv-primary: #0d9488;\n\t\t\t\t\t--pv-primary-hover: #0f766e;\n\t\t\t\t\t--pv-primary-light: #ccfbf1;\n\t\t\t\t\t--pv-navbar: #13111C;\n\t\t\t\t\t--pv-success: #10b981;\n\t\t\t\t\t--pv-danger: #ef4444;\n\t\t\t\t\t--pv-warning: #f59e0b;\n\t\t\t\t\t--pv-bg: #f8f7fc;\n\t\t\t\t\t--pv-border: #e0dce8;\n\t\t\t\t\t--pv-text: #1a1726;\n\t\t\t\t\t--pv-text-secondary: #6e6a80;\n\n\t\t\t\t\t/* Bulma overrides */\n\t\t\t\t\t--bulma-primary: var(--pv-primary);\n\t\t\t\t\t--bulma-primary-h: 175;\n\t\t\t\t\t--bulma-primary-s: 84%;\n\t\t\t\t\t--bulma-primary-l: 32%;\n\t\t\t\t\t--bulma-link: var(--pv-primary);\n\t\t\t\t\t--bulma-link-h: 175;\n\t\t\t\t\t--bulma-link-s: 84%;\n\t\t\t\t\t--bulma-link-l: 32%;\n\t\t\t\t\t--bulma-success: var(--pv-success);\n\t\t\t\t\t--bulma-success-h: 160;\n\t\t\t\t\t--bulma-success-s: 84%;\n\t\t\t\t\t--bulma-success-l: 39%;\n\t\t\t\t\t--bulma-danger: var(--pv-danger);\n\t\t\t\t\t--bulma-danger-h: 0;\n\t\t\t\t\t--bulma-danger-s: 84%;\n\t\t\t\t\t--bulma-danger-l: 60%;\n\t\t\t\t\t--bulma-warning: var(--pv-warning);\n\t\t\t\t\t--bulma-warning-h: 38;\n\t\t\t\t\t--bulma-warning-s: 92%;\n\t\t\t\t\t--bulma-warning-l: 50%;\n\t\t\t\t}\n\n\t\t\t\tbody {\n\t\t\t\t\tfont-family: 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;\n\t\t\t\t\tbackground: var(--pv-bg);\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t}\n\n\t\t\t\t[data-show] { display: none; }\n\n\t\t\t\t/* Navbar */\n\t\t\t\t.navbar.pv-navbar {\n\t\t\t\t\tbackground: var(--pv-navbar);\n\t\t\t\t\tmin-height: 3.5rem;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .navbar-item,\n\t\t\t\t.navbar.pv-navbar .navbar-brand .navbar-item {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .navbar-brand .navbar-item strong {\n\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\tletter-spacing: -0.025em;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .navbar-item.pv-email {\n\t\t\t\t\tcolor: #8b869e;\n\t\t\t\t\tfont-size: 0.875rem;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .button.pv-logout {\n\t\t\t\t\tcolor: #8b869e;\n\t\t\t\t\tborder-color: #2a2640;\n\t\t\t\t\tbackground: transparent;\n\t\t\t\t\tfont-size: 0.8rem;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .button.pv-logout:hover {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t\tborder-color: #8b869e;\n\t\t\t\t}\n\n\t\t\t\t/* Navbar burger */\n\t\t\t\t.navbar.pv-navbar .navbar-burger {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t}\n\n\t\t\t\t/* Nav links */\n\t\t\t\t.navbar.pv-navbar .pv-nav-link {\n\t\t\t\t\tcolor: #8b869e;\n\t\t\t\t\tfont-size: 0.875rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t\tborder-bottom: 2px solid transparent;\n\t\t\t\t\ttransition: color 0.15s, border-color 0.15s;\n\t\t\t\t\tcursor: pointer;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .pv-nav-link:hover {\n\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t\tbackground: transparent;\n\t\t\t\t}\n\t\t\t\t.navbar.pv-navbar .pv-nav-link.is-active {\n\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\tborder-bottom-color: var(--pv-primary);\n\t\t\t\t}\n\n\t\t\t\t@media screen and (max-width: 1023px) {\n\t\t\t\t\t.navbar.pv-navbar .navbar-menu {\n\t\t\t\t\t\tbackground: var(--pv-navbar);\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .navbar-menu .navbar-item {\n\t\t\t\t\t\tcolor: #f1eff8;\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .navbar-menu .navbar-item:hover {\n\t\t\t\t\t\tbackground: #2a2640;\n\t\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .pv-nav-link {\n\t\t\t\t\t\tborder-bottom: none;\n\t\t\t\t\t}\n\t\t\t\t\t.navbar.pv-navbar .pv-nav-link.is-active {\n\t\t\t\t\t\tcolor: #ffffff;\n\t\t\t\t\t\tbackground: rgba(255, 255, 255, 0.08);\n\t\t\t\t\t\tborder-bottom: none;\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\t/* Tabs — underline style */\n\t\t\t\t.tabs.pv-tabs {\n\t\t\t\t\tborder-bottom-color: var(--pv-border);\n\t\t\t\t\tfont-size: 0.925rem;\n\t\t\t\t}\n\t\t\t\t.tabs.pv-tabs li a {\n\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\tborder-bottom-color: transparent;\n\t\t\t\t\tborder-bottom-width: 2px;\n\t\t\t\t\tpadding-bottom: calc(0.5em - 2px);\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\t\t\t\t.tabs.pv-tabs li a:hover {\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\tborder-bottom-color: #c8c3d4;\n\t\t\t\t}\n\t\t\t\t.tabs.pv-tabs li.is-active a {\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\tborder-bottom-color: var(--pv-primary);\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t}\n\n\t\t\t\t/* Cards & Boxes */\n\t\t\t\t.box, .card {\n\t\t\t\t\tborder: 1px solid var(--pv-border);\n\t\t\t\t\tborder-radius: 0.5rem;\n\t\t\t\t\tbox-shadow: 0 1px 3px rgba(26, 23, 38, 0.04), 0 2px 8px rgba(26, 23, 38, 0.03);\n\t\t\t\t}\n\t\t\t\t.card-content {\n\t\t\t\t\tpadding: 1.25rem;\n\t\t\t\t}\n\n\t\t\t\t/* Tables */\n\t\t\t\t.table thead th {\n\t\t\t\t\tbackground: #f1eff8;\n\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tfont-size: 0.8rem;\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 0.05em;\n\t\t\t\t\tborder-bottom: 2px solid var(--pv-border);\n\t\t\t\t}\n\t\t\t\t.table td {\n\t\t\t\t\tborder-color: #f1eff8;\n\t\t\t\t\tvertical-align: middle;\n\t\t\t\t}\n\t\t\t\t.table.is-striped tbody tr:nth-child(even) {\n\t\t\t\t\tbackground: #f8f7fc;\n\t\t\t\t}\n\t\t\t\t.table.is-hoverable tbody tr:hover {\n\t\t\t\t\tbackground: #f1eff8;\n\t\t\t\t}\n\n\t\t\t\t/* Buttons */\n\t\t\t\t.button.is-primary {\n\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\tborder-color: transparent;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\t\t\t\t.button.is-primary:hover {\n\t\t\t\t\tbackground: var(--pv-primary-hover);\n\t\t\t\t}\n\t\t\t\t.button.is-primary.is-outlined {\n\t\t\t\t\tbackground: transparent;\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t}\n\t\t\t\t.button.is-primary.is-outlined:hover {\n\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\tcolor: #fff;\n\t\t\t\t}\n\t\t\t\t.button {\n\t\t\t\t\tborder-radius: 0.375rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\n\t\t\t\t/* Modals */\n\t\t\t\t.modal-card-head {\n\t\t\t\t\tborder-top: 3px solid var(--pv-primary);\n\t\t\t\t\tbackground: #fff;\n\t\t\t\t\tborder-bottom: 1px solid var(--pv-border);\n\t\t\t\t}\n\t\t\t\t.modal-card-title {\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t}\n\t\t\t\t.modal-card {\n\t\t\t\t\tborder-radius: 0.5rem;\n\t\t\t\t\toverflow: hidden;\n\t\t\t\t}\n\n\t\t\t\t/* Inputs */\n\t\t\t\t.input, .textarea, .select select {\n\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\tborder-radius: 0.375rem;\n\t\t\t\t}\n\t\t\t\t.input:focus, .textarea:focus, .select select:focus {\n\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t\tbox-shadow: 0 0 0 2px rgba(13, 148, 136, 0.15);\n\t\t\t\t}\n\t\t\t\t.label {\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t\tfont-size: 0.875rem;\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t}\n\n\t\t\t\t/* Tags */\n\t\t\t\t.tag {\n\t\t\t\t\tborder-radius: 0.375rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\n\t\t\t\t/* Neumorphic cards */\n\t\t\t\t.pv-neumorphic {\n\t\t\t\t\tbackground: var(--pv-bg);\n\t\t\t\t\tborder: none !important;\n\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t6px 6px 14px rgba(26, 23, 38, 0.07),\n\t\t\t\t\t\t-6px -6px 14px rgba(255, 255, 255, 0.7);\n\t\t\t\t\ttransition: box-shadow 0.2s ease;\n\t\t\t\t}\n\t\t\t\t.pv-neumorphic:hover {\n\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t8px 8px 18px rgba(26, 23, 38, 0.1),\n\t\t\t\t\t\t-8px -8px 18px rgba(255, 255, 255, 0.8);\n\t\t\t\t}\n\n\t\t\t\t/* Empty states */\n\t\t\t\t.has-text-grey-light {\n\t\t\t\t\topacity: 0.8;\n\t\t\t\t}\n\n\t\t\t\t/* Value classes for chemistry readings */\n\t\t\t\t.value-ok {\n\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t}\n\t\t\t\t.value-warn {\n\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\tfont-weight: 700;\n\t\t\t\t}\n\n\t\t\t\t/* Titles */\n\t\t\t\t.title, h1, h2, h3 {\n\t\t\t\t\tfont-family: 'Inter Tight', 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;\n\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\tfont-weight: 700;\n\t\t\t\t\tletter-spacing: -0.025em;\n\t\t\t\t}\n\n\t\t\t\t/* Notification tweaks */\n\t\t\t\t.notification {\n\t\t\t\t\tborder-radius: 0.5rem;\n\t\t\t\t}\n\n\t\t\t\t/* Section padding adjustment */\n\t\t\t\t.section {\n\t\t\t\t\tpadding-left: 1.5rem;\n\t\t\t\t\tpadding-right: 1.5rem;\n\t\t\t\t}\n\n\t\t\t\t/* Utility classes (light defaults) */\n\t\t\t\t.pv-divider {\n\t\t\t\t\theight: 1px;\n\t\t\t\t\tbackground: #e8e5f0;\n\t\t\t\t\tborder: none;\n\t\t\t\t}\n\t\t\t\t.pv-low-stock {\n\t\t\t\t\tborder: 2px solid hsl(348, 86%, 61%);\n\t\t\t\t\tbackground: hsl(348, 86%, 97%);\n\t\t\t\t}\n\t\t\t\t.pv-complete-btn {\n\t\t\t\t\twidth: 28px;\n\t\t\t\t\theight: 28px;\n\t\t\t\t\tborder: 2px solid #d4d0de;\n\t\t\t\t\tpadding: 0;\n\t\t\t\t}\n\n\t\t\t\t/* Pool Health Card */\n\t\t\t\t.pv-health-card {\n\t\t\t\t\tpadding: 1rem 1.25rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-card-inner {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-direction: column;\n\t\t\t\t\tgap: 0.75rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-card-score {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-direction: column;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 0.25rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-score-row {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\talign-items: baseline;\n\t\t\t\t\tgap: 0.4rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-number {\n\t\t\t\t\tfont-size: 2.5rem;\n\t\t\t\t\tfont-weight: 800;\n\t\t\t\t\tline-height: 1;\n\t\t\t\t}\n\t\t\t\t.pv-health-info {\n\t\t\t\t\tfont-size: 0.75rem;\n\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\tcursor: help;\n\t\t\t\t\topacity: 0.5;\n\t\t\t\t\ttransition: opacity 0.15s;\n\t\t\t\t}\n\t\t\t\t.pv-health-info:hover {\n\t\t\t\t\topacity: 0.8;\n\t\t\t\t}\n\t\t\t\t.pv-health-label {\n\t\t\t\t\tfont-size: 0.8rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-divider {\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\theight: 1px;\n\t\t\t\t\tbackground: var(--pv-border);\n\t\t\t\t\tborder: none;\n\t\t\t\t}\n\t\t\t\t.pv-health-card-details {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-wrap: wrap;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tjustify-content: center;\n\t\t\t\t\tgap: 0.5rem;\n\t\t\t\t}\n\t\t\t\t.pv-health-streaks {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tgap: 0.4rem;\n\t\t\t\t\tflex-shrink: 0;\n\t\t\t\t}\n\t\t\t\t.pv-streak-pill {\n\t\t\t\t\tdisplay: inline-flex;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 0.25rem;\n\t\t\t\t\tpadding: 0.2rem 0.5rem;\n\t\t\t\t\tborder-radius: 999px;\n\t\t\t\t\tfont-size: 0.7rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t\tbackground: var(--pv-primary-light);\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t}\n\t\t\t\t.pv-health-milestones {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tflex-wrap: wrap;\n\t\t\t\t\tjustify-content: center;\n\t\t\t\t\tgap: 0.35rem;\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge {\n\t\t\t\t\tdisplay: inline-flex;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 0.25rem;\n\t\t\t\t\tpadding: 0.2rem 0.5rem;\n\t\t\t\t\tborder-radius: 999px;\n\t\t\t\t\tfont-size: 0.65rem;\n\t\t\t\t\tfont-weight: 500;\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge.is-earned {\n\t\t\t\t\tbackground: var(--pv-primary-light);\n\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge.is-locked {\n\t\t\t\t\tbackground: #f0eef5;\n\t\t\t\t\tcolor: #c0bdd0;\n\t\t\t\t}\n\t\t\t\t.pv-milestone-badge.is-new {\n\t\t\t\t\tanimation: pv-milestone-glow 1.5s ease-in-out;\n\t\t\t\t}\n\t\t\t\t@keyframes pv-milestone-glow {\n\t\t\t\t\t0%, 100% { transform: scale(1); box-shadow: none; }\n\t\t\t\t\t50% { transform: scale(1.05); box-shadow: 0 0 8px rgba(13, 148, 136, 0.3); }\n\t\t\t\t}\n\n\t\t\t\t/* Dark mode */\n\t\t\t\t@media (prefers-color-scheme: dark) {\n\t\t\t\t\t:root {\n\t\t\t\t\t\t--pv-primary: #2dd4bf;\n\t\t\t\t\t\t--pv-primary-hover: #14b8a6;\n\t\t\t\t\t\t--pv-primary-light: #042f2e;\n\t\t\t\t\t\t--pv-navbar: #0d0b14;\n\t\t\t\t\t\t--pv-success: #34d399;\n\t\t\t\t\t\t--pv-danger: #f87171;\n\t\t\t\t\t\t--pv-warning: #fbbf24;\n\t\t\t\t\t\t--pv-bg: #13111C;\n\t\t\t\t\t\t--pv-border: #211e2e;\n\t\t\t\t\t\t--pv-text: #eeedf5;\n\t\t\t\t\t\t--pv-text-secondary: #8b869e;\n\t\t\t\t\t\t--pv-surface: #1c1929;\n\t\t\t\t\t\t--pv-surface-hover: #2a2640;\n\t\t\t\t\t\t--pv-surface-alt: #181530;\n\n\t\t\t\t\t\t/* Bulma dark overrides */\n\t\t\t\t\t\t--bulma-primary-h: 168;\n\t\t\t\t\t\t--bulma-primary-s: 72%;\n\t\t\t\t\t\t--bulma-primary-l: 51%;\n\t\t\t\t\t\t--bulma-link-h: 168;\n\t\t\t\t\t\t--bulma-link-s: 72%;\n\t\t\t\t\t\t--bulma-link-l: 51%;\n\t\t\t\t\t\t--bulma-success-h: 160;\n\t\t\t\t\t\t--bulma-success-s: 67%;\n\t\t\t\t\t\t--bulma-success-l: 52%;\n\t\t\t\t\t\t--bulma-danger-h: 0;\n\t\t\t\t\t\t--bulma-danger-s: 91%;\n\t\t\t\t\t\t--bulma-danger-l: 71%;\n\t\t\t\t\t\t--bulma-warning-h: 43;\n\t\t\t\t\t\t--bulma-warning-s: 96%;\n\t\t\t\t\t\t--bulma-warning-l: 56%;\n\t\t\t\t\t\t--bulma-scheme-main: var(--pv-bg);\n\t\t\t\t\t\t--bulma-scheme-main-bis: var(--pv-surface);\n\t\t\t\t\t\t--bulma-scheme-main-ter: var(--pv-surface-hover);\n\t\t\t\t\t\t--bulma-text: var(--pv-text);\n\t\t\t\t\t\t--bulma-text-strong: #ffffff;\n\t\t\t\t\t\t--bulma-border: var(--pv-border);\n\t\t\t\t\t\t--bulma-border-weak: #181530;\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Cards & Boxes */\n\t\t\t\t\t.box, .card {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tbox-shadow: 0 1px 3px rgba(0, 0, 0, 0.2), 0 4px 12px rgba(0, 0, 0, 0.12);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Tables */\n\t\t\t\t\t.table thead th {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\t}\n\t\t\t\t\t.table td {\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.table {\n\t\t\t\t\t\tbackground-color: var(--pv-bg);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.table.is-striped tbody tr:nth-child(even) {\n\t\t\t\t\t\tbackground: var(--pv-surface-alt);\n\t\t\t\t\t}\n\t\t\t\t\t.table.is-hoverable tbody tr:hover {\n\t\t\t\t\t\tbackground: var(--pv-surface-hover);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Modal */\n\t\t\t\t\t.modal-card-head {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tborder-bottom-color: var(--pv-border);\n\t\t\t\t\t}\n\t\t\t\t\t.modal-card-body {\n\t\t\t\t\t\tbackground: var(--pv-bg);\n\t\t\t\t\t}\n\t\t\t\t\t.modal-card-foot {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tborder-top-color: var(--pv-border);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Inputs */\n\t\t\t\t\t.input, .textarea, .select select {\n\t\t\t\t\t\tbackground-color: var(--pv-surface);\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.input:focus, .textarea:focus, .select select:focus {\n\t\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t\t\tbox-shadow: 0 0 0 2px rgba(45, 212, 191, 0.15);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Buttons */\n\t\t\t\t\t.button {\n\t\t\t\t\t\tbackground-color: var(--pv-surface);\n\t\t\t\t\t\tborder-color: var(--pv-border);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.button:hover {\n\t\t\t\t\t\tborder-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary {\n\t\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t\tborder-color: transparent;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary:hover {\n\t\t\t\t\t\tbackground: var(--pv-primary-hover);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary.is-outlined {\n\t\t\t\t\t\tbackground: transparent;\n\t\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\t\tborder-color: var(--pv-primary);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-primary.is-outlined:hover {\n\t\t\t\t\t\tbackground: var(--pv-primary);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-danger.is-outlined {\n\t\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\t\tborder-color: var(--pv-danger);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-danger.is-outlined:hover {\n\t\t\t\t\t\tbackground: var(--pv-danger);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-success.is-outlined {\n\t\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\t\tborder-color: var(--pv-success);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-success.is-outlined:hover {\n\t\t\t\t\t\tbackground: var(--pv-success);\n\t\t\t\t\t\tcolor: #13111C;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-white {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-ghost {\n\t\t\t\t\t\tbackground: transparent;\n\t\t\t\t\t\tcolor: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Tabs */\n\t\t\t\t\t.tabs.pv-tabs li a:hover {\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t\tborder-bottom-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Tags */\n\t\t\t\t\t.tag.is-light {\n\t\t\t\t\t\tbackground: var(--pv-surface-hover);\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\t\t\t\t\t.tag.is-success.is-light {\n\t\t\t\t\t\tbackground: rgba(52, 211, 153, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\t}\n\t\t\t\t\t.tag.is-danger.is-light {\n\t\t\t\t\t\tbackground: rgba(248, 113, 113, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\t}\n\t\t\t\t\t.tag.is-warning.is-light {\n\t\t\t\t\t\tbackground: rgba(251, 191, 36, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-warning);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Notification */\n\t\t\t\t\t.notification.is-danger.is-light {\n\t\t\t\t\t\tbackground: rgba(248, 113, 113, 0.1);\n\t\t\t\t\t\tcolor: var(--pv-danger);\n\t\t\t\t\t}\n\t\t\t\t\t.notification.is-success.is-light {\n\t\t\t\t\t\tbackground: rgba(52, 211, 153, 0.1);\n\t\t\t\t\t\tcolor: var(--pv-success);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Select dropdown arrow */\n\t\t\t\t\t.select:not(.is-multiple):not(.is-loading)::after {\n\t\t\t\t\t\tborder-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Neumorphic cards (dark) */\n\t\t\t\t\t.pv-neumorphic {\n\t\t\t\t\t\tbackground: var(--pv-surface);\n\t\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t\t6px 6px 14px rgba(0, 0, 0, 0.35),\n\t\t\t\t\t\t\t-6px -6px 14px rgba(40, 37, 55, 0.4);\n\t\t\t\t\t}\n\t\t\t\t\t.pv-neumorphic:hover {\n\t\t\t\t\t\tbox-shadow:\n\t\t\t\t\t\t\t8px 8px 18px rgba(0, 0, 0, 0.4),\n\t\t\t\t\t\t\t-8px -8px 18px rgba(40, 37, 55, 0.5);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Gamification (dark) */\n\t\t\t\t\t.pv-milestone-badge.is-earned,\n\t\t\t\t\t.pv-streak-pill {\n\t\t\t\t\t\tbackground: rgba(13, 148, 136, 0.15);\n\t\t\t\t\t\tcolor: var(--pv-primary);\n\t\t\t\t\t}\n\t\t\t\t\t.pv-milestone-badge.is-locked {\n\t\t\t\t\t\tbackground: rgba(255, 255, 255, 0.05);\n\t\t\t\t\t\tcolor: #5a5770;\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Divider */\n\t\t\t\t\t.pv-divider {\n\t\t\t\t\t\tbackground: var(--pv-border);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Low stock card */\n\t\t\t\t\t.pv-low-stock {\n\t\t\t\t\t\tborder-color: var(--pv-danger);\n\t\t\t\t\t\tbackground: rgba(248, 113, 113, 0.1);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Complete button */\n\t\t\t\t\t.pv-complete-btn {\n\t\t\t\t\t\tborder-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Text helpers */\n\t\t\t\t\t.has-text-grey, .has-text-grey-light {\n\t\t\t\t\t\tcolor: var(--pv-text-secondary) !important;\n\t\t\t\t\t}\n\t\t\t\t\t.has-text-weight-bold, .has-text-weight-semibold {\n\t\t\t\t\t\tcolor: var(--pv-text);\n\t\t\t\t\t}\n\n\t\t\t\t\t/* Delete button (X) */\n\t\t\t\t\t.delete {\n\t\t\t\t\t\tbackground-color: var(--pv-surface-hover);\n\t\t\t\t\t}\n\t\t\t\t\t.delete:hover {\n\t\t\t\t\t\tbackground-color: var(--pv-text-secondary);\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t/* Mobile responsive adjustments */\n\t\t\t\t@media screen and (max-width: 768px) {\n\t\t\t\t\t.section {\n\t\t\t\t\t\tpadding-left: 0.75rem;\n\t\t\t\t\t\tpadding-right: 0.75rem;\n\t\t\t\t\t}\n\t\t\t\t\t.button.is-small {\n\t\t\t\t\t\tmin-height: 2.25rem;\n\t\t\t\t\t\tpadding-left: 0.75rem;\n\t\t\t\t\t\tpadding-right: 0.75rem;\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\t/* Chemistry table mobile expandable rows */\n\t\t\t\t@media screen and (max-width: 768px) {\n\t\t\t\t\t.pv-hidden-mobile {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-expand-btn {\n\t\t\t\t\t\tdisplay: inline-flex !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-detail-row td {\n\t\t\t\t\t\tpadding-top: 0;\n\t\t\t\t\t\tborder-top: none;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-kebab-menu {\n\t\t\t\t\t\tdisplay: inline-flex !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-action-btn-desktop {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-filter-toggle {\n\t\t\t\t\t\tdisplay: flex !important;\n\t\t\t\t\t\tjustify-content: space-between;\n\t\t\t\t\t\talign-items: center;\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\t@media screen and (min-width: 769px) {\n\t\t\t\t\t.pv-expand-btn {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-detail-row {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-kebab-menu {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-filter-toggle {\n\t\t\t\t\t\tdisplay: none !important;\n\t\t\t\t\t}\n\t\t\t\t\t.pv-filter-content {\n\t\t\t\t\t\tdisplay: block !important;\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\t.pv-kebab-menu .dropdown-menu {\n\t\t\t\t\tmin-width: 8rem;\n\t\t\t\t}\n\t\t\t</style></head><body>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if completeTaskID != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<script>\n\t\t\t\t\tlocalStorage.setItem('poolvibes_tab', 'tasks');\n\t\t\t\t\thistory.replaceState(null, '', '/');\n\t\t\t\t</script>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<script>window._savedTab = localStorage.getItem('poolvibes_tab') || 'dashboard';</script><div data-signals:tab=\"window._savedTab\" data-signals:_loading=\"false\" data-signals:_menuOpen=\"false\" data-effect=\"localStorage.setItem('poolvibes_tab', $tab)\"><!-- Navbar --><nav class=\"navbar pv-navbar\" role=\"navigation\" aria-label=\"main navigation\"><div class=\"container\"><div class=\"navbar-brand\"><a class=\"navbar-item\" href=\"/\"><strong class=\"is-size-4\">PoolVibes</strong></a> <a role=\"button\" class=\"navbar-burger\" aria-label=\"menu\" data-attr:aria-expanded=\"$_menuOpen\" data-class:is-active=\"$_menuOpen\" data-on:click=\"$_menuOpen = !$_menuOpen\"><span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span></a></div><div class=\"navbar-menu\" data-class:is-active=\"$_menuOpen\"><div class=\"navbar-start\"><a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'dashboard'\" data-on:click=\"$tab = 'dashboard'; @get('/dashboard'); $_menuOpen = false\">Dashboard</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'chemistry'\" data-on:click=\"$tab = 'chemistry'; @get('/chemistry'); $_menuOpen = false\">Chemistry</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'tasks'\" data-on:click=\"$tab = 'tasks'; @get('/tasks'); $_menuOpen = false\">Tasks</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'equipment'\" data-on:click=\"$tab = 'equipment'; @get('/equipment'); $_menuOpen = false\">Equipment</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'chemicals'\" data-on:click=\"$tab = 'chemicals'; @get('/chemicals'); $_menuOpen = false\">Chemicals</a> <a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'settings'\" data-on:click=\"$tab = 'settings'; @get('/settings'); $_menuOpen = false\">Settings</a> ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if isAdmin {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'admin'\" data-on:click=\"$tab = 'admin'; @get('/admin/users'); $_menuOpen = false\">Admin</a>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<a class=\"navbar-item pv-nav-link\" data-class:is-active=\"$tab === 'admin'\" data-on:click=\"$tab = 'admin'; @get('/admin/users'); $_menuOpen = false\">Admin</a>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</div><div class=\"navbar-end\"><span class=\"navbar-item pv-email\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</div><div class=\"navbar-end\"><span class=\"navbar-item pv-email\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var2 string
 		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(email)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interface/web/templates/layout.templ`, Line: 743, Col: 50}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interface/web/templates/layout.templ`, Line: 751, Col: 50}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span><div class=\"navbar-item\"><form method=\"POST\" action=\"/logout\"><button type=\"submit\" class=\"button is-small pv-logout\">Logout</button></form></div></div></div></div></nav><!-- Main Content --><section class=\"section\" style=\"padding-top: 1rem;\"><div class=\"container\"><div id=\"tab-content\" data-init=\"@get('/' + $tab)\"><div id=\"loading-fallback\" class=\"has-text-centered py-6 has-text-grey-light\">Loading...</div></div></div></section><!-- Modal Container --><div id=\"modal\"></div><!-- Footer --><footer style=\"text-align: center; padding: 1.5rem; border-top: 1px solid var(--pv-border); margin-top: 2rem;\"><p style=\"font-size: 0.85rem; color: var(--pv-text-secondary);\">PoolVibes &middot; Free &amp; open source</p></footer></div><script>\n\t\t\t\tsetTimeout(function() {\n\t\t\t\t\tvar el = document.getElementById('loading-fallback');\n\t\t\t\t\tif (el) {\n\t\t\t\t\t\tel.innerHTML = 'Page failed to load. <a href=\"javascript:location.reload()\">Refresh</a>';\n\t\t\t\t\t\tel.classList.remove('has-text-grey-light');\n\t\t\t\t\t\tel.classList.add('has-text-grey');\n\t\t\t\t\t}\n\t\t\t\t}, 10000);\n\t\t\t</script></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</span><div class=\"navbar-item\"><form method=\"POST\" action=\"/logout\"><button type=\"submit\" class=\"button is-small pv-logout\">Logout</button></form></div></div></div></div></nav><!-- Main Content --><section class=\"section\" style=\"padding-top: 1rem;\"><div class=\"container\"><div id=\"tab-content\" data-init=\"@get('/' + $tab)\"><div id=\"loading-fallback\" class=\"has-text-centered py-6 has-text-grey-light\">Loading...</div></div></div></section><!-- Modal Container -->")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if completeTaskID != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<div id=\"modal\" data-init=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var3 string
+			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs("@get('/tasks/" + completeTaskID + "/complete')")
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interface/web/templates/layout.templ`, Line: 771, Col: 81}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\"></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		} else {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "<div id=\"modal\"></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "<!-- Footer --><footer style=\"text-align: center; padding: 1.5rem; border-top: 1px solid var(--pv-border); margin-top: 2rem;\"><p style=\"font-size: 0.85rem; color: var(--pv-text-secondary);\">PoolVibes &middot; Free &amp; open source</p></footer></div><script>\n\t\t\t\tsetTimeout(function() {\n\t\t\t\t\tvar el = document.getElementById('loading-fallback');\n\t\t\t\t\tif (el) {\n\t\t\t\t\t\tel.innerHTML = 'Page failed to load. <a href=\"javascript:location.reload()\">Refresh</a>';\n\t\t\t\t\t\tel.classList.remove('has-text-grey-light');\n\t\t\t\t\t\tel.classList.add('has-text-grey');\n\t\t\t\t\t}\n\t\t\t\t}, 10000);\n\t\t\t</script></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -0,0 +1,22 @@
+package templates
+
+import "github.com/joshthewhite/poolvibes/internal/domain/entities"
+
+// TaskHistoryEntry is one occurrence of a recurring task in its series
+// history, with the completion details captured when it was done.
+type TaskHistoryEntry struct {
+	Task               entities.Task
+	Completion         *entities.TaskCompletion
+	ChemistryLogLabel  string
+	ServiceRecordLabel string
+}
+
+// TaskSeriesSummary holds the per-series stats shown above a task's history.
+type TaskSeriesSummary struct {
+	Completed          int
+	Skipped            int
+	OnTimeRate         int
+	CurrentStreak      int
+	AvgDurationMinutes int
+	Ended              bool
+}
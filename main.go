@@ -4,6 +4,7 @@ import (
 	"embed"
 	"log/slog"
 	"os"
+	_ "time/tzdata" // user timezones must resolve on hosts without a zoneinfo database
 
 	"github.com/joshthewhite/poolvibes/cmd"
 )
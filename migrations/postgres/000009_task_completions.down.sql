@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_completion_photos;
+DROP TABLE IF EXISTS task_completions;
+
+DROP INDEX IF EXISTS idx_tasks_user_series;
+ALTER TABLE tasks DROP COLUMN IF EXISTS series_id;
@@ -0,0 +1,47 @@
+-- Link every occurrence of a recurring task to its series so completions
+-- can be grouped into a history. Completing a task used to create its next
+-- occurrence as a copy, so existing tasks with the same name and recurrence
+-- are one series, named after its first occurrence.
+ALTER TABLE tasks ADD COLUMN series_id UUID;
+UPDATE tasks t SET series_id = earliest.id
+FROM (
+    SELECT DISTINCT ON (user_id, name, recurrence_frequency, recurrence_interval)
+        id, user_id, name, recurrence_frequency, recurrence_interval
+    FROM tasks
+    ORDER BY user_id, name, recurrence_frequency, recurrence_interval, created_at ASC, id ASC
+) earliest
+WHERE t.series_id IS NULL
+    AND t.user_id = earliest.user_id
+    AND t.name = earliest.name
+    AND t.recurrence_frequency = earliest.recurrence_frequency
+    AND t.recurrence_interval = earliest.recurrence_interval;
+ALTER TABLE tasks ALTER COLUMN series_id SET NOT NULL;
+CREATE INDEX idx_tasks_user_series ON tasks(user_id, series_id);
+
+CREATE TABLE IF NOT EXISTS task_completions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    task_id UUID NOT NULL UNIQUE,
+    series_id UUID NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    duration_minutes INTEGER NOT NULL DEFAULT 0,
+    chemistry_log_id UUID,
+    service_record_id UUID,
+    completed_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_task_completions_user_series ON task_completions(user_id, series_id);
+
+CREATE TABLE IF NOT EXISTS task_completion_photos (
+    id UUID PRIMARY KEY,
+    completion_id UUID NOT NULL REFERENCES task_completions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_task_completion_photos_completion_id ON task_completion_photos(completion_id);
+CREATE INDEX idx_task_completion_photos_user_id ON task_completion_photos(user_id);
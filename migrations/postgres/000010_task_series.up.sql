@@ -0,0 +1,38 @@
+-- A series holds the details future occurrences of a recurring task are
+-- created from.
+CREATE TABLE IF NOT EXISTS task_series (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    recurrence_frequency TEXT NOT NULL DEFAULT 'weekly',
+    recurrence_interval INTEGER NOT NULL DEFAULT 1,
+    ended_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_task_series_user_id ON task_series(user_id);
+
+-- Existing series take their details from their latest occurrence.
+INSERT INTO task_series (id, user_id, name, description,
+    recurrence_frequency, recurrence_interval,
+    created_at, updated_at)
+SELECT t.series_id, t.user_id, t.name, t.description,
+    t.recurrence_frequency, t.recurrence_interval,
+    t.created_at, t.updated_at
+FROM tasks t
+WHERE t.id = (
+    SELECT latest.id FROM tasks latest
+    WHERE latest.series_id = t.series_id
+    ORDER BY latest.due_date DESC, latest.id DESC
+    LIMIT 1
+);
+
+-- A series with no open occurrence left was stopped by deleting it, so it
+-- ends when it was last changed.
+UPDATE task_series SET ended_at = updated_at
+WHERE NOT EXISTS (
+    SELECT 1 FROM tasks t
+    WHERE t.series_id = task_series.id AND t.status IN ('pending', 'overdue')
+);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS snoozed_until;
+ALTER TABLE tasks DROP COLUMN IF EXISTS skipped_at;
@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN skipped_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN snoozed_until TIMESTAMPTZ;
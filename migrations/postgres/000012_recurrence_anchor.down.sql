@@ -0,0 +1,2 @@
+ALTER TABLE task_series DROP COLUMN IF EXISTS recurrence_anchor;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_anchor;
@@ -0,0 +1,26 @@
+-- Reusable maintenance schedules. A NULL user_id marks a site-wide template
+-- managed by admins; built-in templates live in code and are not stored.
+CREATE TABLE IF NOT EXISTS task_templates (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_task_templates_user_id ON task_templates(user_id);
+
+CREATE TABLE IF NOT EXISTS task_template_items (
+    id UUID PRIMARY KEY,
+    template_id UUID NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    recurrence_frequency TEXT NOT NULL,
+    recurrence_interval INTEGER NOT NULL DEFAULT 1,
+    recurrence_anchor TEXT NOT NULL DEFAULT 'due_date',
+    due_in_days INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_task_template_items_template_id ON task_template_items(template_id);
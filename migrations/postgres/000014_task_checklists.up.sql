@@ -0,0 +1,25 @@
+-- Checklist steps. The series holds the steps new occurrences start with;
+-- each occurrence keeps its own copy with per-step completion state.
+CREATE TABLE IF NOT EXISTS task_series_checklist_items (
+    id UUID PRIMARY KEY,
+    series_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE INDEX idx_task_series_checklist_items_series_id ON task_series_checklist_items(series_id);
+
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT TRUE,
+    checked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items(task_id);
+CREATE INDEX idx_task_checklist_items_user_id ON task_checklist_items(user_id);
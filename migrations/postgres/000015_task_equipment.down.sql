@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_user_equipment;
+ALTER TABLE tasks DROP COLUMN IF EXISTS equipment_id;
+ALTER TABLE task_series DROP COLUMN IF EXISTS equipment_id;
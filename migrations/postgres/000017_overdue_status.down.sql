@@ -0,0 +1,6 @@
+DELETE FROM task_notifications WHERE kind != 'due';
+ALTER TABLE task_notifications DROP CONSTRAINT IF EXISTS task_notifications_user_type_kind_date_uq;
+ALTER TABLE task_notifications ADD CONSTRAINT task_notifications_user_type_date_uq UNIQUE (user_id, type, due_date);
+ALTER TABLE task_notifications DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS overdue_at;
@@ -0,0 +1,8 @@
+ALTER TABLE tasks ADD COLUMN overdue_at TIMESTAMPTZ;
+UPDATE tasks SET overdue_at = updated_at WHERE status = 'overdue';
+
+-- Overdue reminders are claimed separately from due-today reminders, so
+-- dedup notifications per user, channel, kind and day.
+ALTER TABLE task_notifications ADD COLUMN kind TEXT NOT NULL DEFAULT 'due';
+ALTER TABLE task_notifications DROP CONSTRAINT IF EXISTS task_notifications_user_type_date_uq;
+ALTER TABLE task_notifications ADD CONSTRAINT task_notifications_user_type_kind_date_uq UNIQUE (user_id, type, kind, due_date);
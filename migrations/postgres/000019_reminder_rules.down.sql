@@ -0,0 +1,8 @@
+DELETE FROM task_notifications a USING task_notifications b
+    WHERE a.user_id = b.user_id AND a.type = b.type AND a.kind = b.kind
+    AND a.due_date = b.due_date AND a.id > b.id;
+ALTER TABLE task_notifications DROP CONSTRAINT IF EXISTS task_notifications_user_type_rule_date_uq;
+ALTER TABLE task_notifications ADD CONSTRAINT task_notifications_user_type_kind_date_uq UNIQUE (user_id, type, kind, due_date);
+ALTER TABLE task_notifications DROP COLUMN IF EXISTS rule;
+
+DROP TABLE IF EXISTS reminder_rules;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS reminder_rules (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    offset_days INTEGER NOT NULL,
+    send_hour INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE(user_id, offset_days, send_hour)
+);
+CREATE INDEX idx_reminder_rules_user_id ON reminder_rules(user_id);
+
+-- Each reminder rule claims its own notifications, so dedup per user,
+-- channel, rule and due date. Existing claims are mapped onto the default
+-- rules they correspond to so nothing is sent twice on upgrade.
+ALTER TABLE task_notifications ADD COLUMN rule TEXT NOT NULL DEFAULT '';
+UPDATE task_notifications SET rule = '+1@09', due_date = due_date - 1 WHERE kind = 'overdue';
+UPDATE task_notifications SET rule = '+0@07' WHERE kind = 'due';
+ALTER TABLE task_notifications DROP CONSTRAINT IF EXISTS task_notifications_user_type_kind_date_uq;
+ALTER TABLE task_notifications ADD CONSTRAINT task_notifications_user_type_rule_date_uq UNIQUE (user_id, type, rule, due_date);
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS no_test_alert_days;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,10 @@
+-- Which notification categories each user has opted into, per channel.
+-- A row means the category is on for that channel.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    channel TEXT NOT NULL,
+    PRIMARY KEY (user_id, category, channel)
+);
+
+ALTER TABLE users ADD COLUMN no_test_alert_days INTEGER NOT NULL DEFAULT 7;
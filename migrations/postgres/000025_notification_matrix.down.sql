@@ -0,0 +1,11 @@
+ALTER TABLE users DROP COLUMN IF EXISTS quiet_hours_end;
+ALTER TABLE users DROP COLUMN IF EXISTS quiet_hours_start;
+ALTER TABLE users DROP COLUMN IF EXISTS quiet_hours_enabled;
+
+ALTER TABLE users ADD COLUMN notify_email BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN notify_sms BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE users SET notify_email = TRUE WHERE id IN (
+    SELECT user_id FROM notification_preferences WHERE category = 'reminders' AND channel = 'email');
+UPDATE users SET notify_sms = TRUE WHERE id IN (
+    SELECT user_id FROM notification_preferences WHERE category = 'reminders' AND channel = 'sms');
+DELETE FROM notification_preferences WHERE category = 'reminders';
@@ -0,0 +1,14 @@
+-- Task reminders join the per-category channel preferences, replacing the
+-- global email and SMS toggles.
+INSERT INTO notification_preferences (user_id, category, channel)
+SELECT id, 'reminders', 'email' FROM users WHERE notify_email;
+INSERT INTO notification_preferences (user_id, category, channel)
+SELECT id, 'reminders', 'sms' FROM users WHERE notify_sms;
+
+ALTER TABLE users DROP COLUMN IF EXISTS notify_email;
+ALTER TABLE users DROP COLUMN IF EXISTS notify_sms;
+
+-- Hours of the day, in the user's timezone, during which SMS is deferred.
+ALTER TABLE users ADD COLUMN quiet_hours_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN quiet_hours_start INTEGER NOT NULL DEFAULT 22;
+ALTER TABLE users ADD COLUMN quiet_hours_end INTEGER NOT NULL DEFAULT 7;
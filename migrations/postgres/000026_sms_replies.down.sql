@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_phone;
+ALTER TABLE task_notifications DROP COLUMN IF EXISTS task_ids;
+ALTER TABLE users DROP COLUMN IF EXISTS sms_opted_out;
@@ -0,0 +1,6 @@
+-- Set when the user replies STOP to a text message, until they reply START.
+ALTER TABLE users ADD COLUMN sms_opted_out BOOLEAN NOT NULL DEFAULT FALSE;
+-- The tasks a reminder batch covered, comma-separated in the order its
+-- message lists them, so a reply such as "DONE 2" can be matched to one.
+ALTER TABLE task_notifications ADD COLUMN task_ids TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_users_phone ON users(phone);
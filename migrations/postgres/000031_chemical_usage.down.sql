@@ -0,0 +1 @@
+ALTER TABLE chemicals DROP COLUMN IF EXISTS daily_usage;
@@ -0,0 +1,3 @@
+-- Average daily usage of each chemical, from the doses in its stock
+-- ledger, for run-out and reorder forecasts.
+ALTER TABLE chemicals ADD COLUMN daily_usage DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,44 @@
+-- Link every occurrence of a recurring task to its series so completions
+-- can be grouped into a history. Completing a task used to create its next
+-- occurrence as a copy, so existing tasks with the same name and recurrence
+-- are one series, named after its first occurrence.
+ALTER TABLE tasks ADD COLUMN series_id TEXT NOT NULL DEFAULT '';
+UPDATE tasks SET series_id = (
+    SELECT earliest.id FROM tasks earliest
+    WHERE earliest.user_id = tasks.user_id
+        AND earliest.name = tasks.name
+        AND earliest.recurrence_frequency = tasks.recurrence_frequency
+        AND earliest.recurrence_interval = tasks.recurrence_interval
+    ORDER BY earliest.created_at ASC, earliest.id ASC
+    LIMIT 1
+)
+WHERE series_id = '';
+CREATE INDEX idx_tasks_user_series ON tasks(user_id, series_id);
+
+CREATE TABLE IF NOT EXISTS task_completions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    task_id TEXT NOT NULL UNIQUE,
+    series_id TEXT NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    duration_minutes INTEGER NOT NULL DEFAULT 0,
+    chemistry_log_id TEXT,
+    service_record_id TEXT,
+    completed_at TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_task_completions_user_series ON task_completions(user_id, series_id);
+
+CREATE TABLE IF NOT EXISTS task_completion_photos (
+    id TEXT PRIMARY KEY,
+    completion_id TEXT NOT NULL REFERENCES task_completions(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL,
+    data BLOB NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_task_completion_photos_completion_id ON task_completion_photos(completion_id);
+CREATE INDEX idx_task_completion_photos_user_id ON task_completion_photos(user_id);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN snoozed_until;
+ALTER TABLE tasks DROP COLUMN skipped_at;
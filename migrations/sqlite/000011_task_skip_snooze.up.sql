@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN skipped_at TEXT;
+ALTER TABLE tasks ADD COLUMN snoozed_until TEXT;
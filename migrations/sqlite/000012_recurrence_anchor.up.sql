@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN recurrence_anchor TEXT NOT NULL DEFAULT 'due_date';
+ALTER TABLE task_series ADD COLUMN recurrence_anchor TEXT NOT NULL DEFAULT 'due_date';
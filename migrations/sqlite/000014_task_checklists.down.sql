@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_checklist_items;
+DROP TABLE IF EXISTS task_series_checklist_items;
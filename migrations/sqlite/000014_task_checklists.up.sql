@@ -0,0 +1,25 @@
+-- Checklist steps. The series holds the steps new occurrences start with;
+-- each occurrence keeps its own copy with per-step completion state.
+CREATE TABLE IF NOT EXISTS task_series_checklist_items (
+    id TEXT PRIMARY KEY,
+    series_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    required INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX idx_task_series_checklist_items_series_id ON task_series_checklist_items(series_id);
+
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    required INTEGER NOT NULL DEFAULT 1,
+    checked_at TEXT
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items(task_id);
+CREATE INDEX idx_task_checklist_items_user_id ON task_checklist_items(user_id);
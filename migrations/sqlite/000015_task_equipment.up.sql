@@ -0,0 +1,5 @@
+-- Tasks can optionally maintain a piece of equipment. Deleting the
+-- equipment unlinks its tasks rather than deleting them.
+ALTER TABLE task_series ADD COLUMN equipment_id TEXT;
+ALTER TABLE tasks ADD COLUMN equipment_id TEXT;
+CREATE INDEX idx_tasks_user_equipment ON tasks(user_id, equipment_id);
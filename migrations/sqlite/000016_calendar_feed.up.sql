@@ -0,0 +1,4 @@
+-- Secret token for each user's subscribable calendar feed. Empty until the
+-- user enables the feed.
+ALTER TABLE users ADD COLUMN calendar_token TEXT NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX idx_users_calendar_token ON users(calendar_token) WHERE calendar_token != '';
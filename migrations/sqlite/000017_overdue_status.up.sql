@@ -0,0 +1,23 @@
+ALTER TABLE tasks ADD COLUMN overdue_at TEXT;
+UPDATE tasks SET overdue_at = updated_at WHERE status = 'overdue';
+
+-- Overdue reminders are claimed separately from due-today reminders, so
+-- dedup notifications per user, channel, kind and day.
+CREATE TABLE task_notifications_new (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'due',
+    due_date TEXT NOT NULL,
+    sent_at TEXT NOT NULL,
+    UNIQUE(user_id, type, kind, due_date)
+);
+
+INSERT INTO task_notifications_new (id, task_id, user_id, type, due_date, sent_at)
+    SELECT id, task_id, user_id, type, due_date, sent_at FROM task_notifications;
+
+DROP TABLE task_notifications;
+ALTER TABLE task_notifications_new RENAME TO task_notifications;
+
+CREATE INDEX idx_task_notifications_user_id ON task_notifications(user_id);
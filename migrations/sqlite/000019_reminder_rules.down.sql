@@ -0,0 +1,20 @@
+CREATE TABLE task_notifications_old (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'due',
+    due_date TEXT NOT NULL,
+    sent_at TEXT NOT NULL,
+    UNIQUE(user_id, type, kind, due_date)
+);
+
+INSERT OR IGNORE INTO task_notifications_old (id, task_id, user_id, type, kind, due_date, sent_at)
+    SELECT id, task_id, user_id, type, kind, due_date, sent_at FROM task_notifications;
+
+DROP TABLE task_notifications;
+ALTER TABLE task_notifications_old RENAME TO task_notifications;
+
+CREATE INDEX idx_task_notifications_user_id ON task_notifications(user_id);
+
+DROP TABLE IF EXISTS reminder_rules;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS reminder_rules (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    offset_days INTEGER NOT NULL,
+    send_hour INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    UNIQUE(user_id, offset_days, send_hour)
+);
+CREATE INDEX idx_reminder_rules_user_id ON reminder_rules(user_id);
+
+-- Each reminder rule claims its own notifications, so dedup per user,
+-- channel, rule and due date. Existing claims are mapped onto the default
+-- rules they correspond to so nothing is sent twice on upgrade.
+CREATE TABLE task_notifications_new (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'due',
+    rule TEXT NOT NULL DEFAULT '',
+    due_date TEXT NOT NULL,
+    sent_at TEXT NOT NULL,
+    UNIQUE(user_id, type, rule, due_date)
+);
+
+INSERT OR IGNORE INTO task_notifications_new (id, task_id, user_id, type, kind, rule, due_date, sent_at)
+    SELECT id, task_id, user_id, type, kind,
+        CASE kind WHEN 'overdue' THEN '+1@09' ELSE '+0@07' END,
+        CASE kind WHEN 'overdue' THEN date(due_date, '-1 day') ELSE due_date END,
+        sent_at
+    FROM task_notifications;
+
+DROP TABLE task_notifications;
+ALTER TABLE task_notifications_new RENAME TO task_notifications;
+
+CREATE INDEX idx_task_notifications_user_id ON task_notifications(user_id);
@@ -0,0 +1,21 @@
+-- Every email and SMS is queued here with its claim, then sent by a worker
+-- that retries failures with backoff and dead-letters after too many.
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id TEXT PRIMARY KEY,
+    notification_id TEXT NOT NULL,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TEXT, -- UTC; NULL once sent or dead
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    sent_at TEXT
+);
+CREATE INDEX idx_notification_outbox_user_id ON notification_outbox(user_id, created_at);
+CREATE INDEX idx_notification_outbox_due ON notification_outbox(status, next_attempt_at);
@@ -0,0 +1,2 @@
+-- The HTML alternative of an email's body; empty for SMS and plain text emails.
+ALTER TABLE notification_outbox ADD COLUMN html_body TEXT NOT NULL DEFAULT '';
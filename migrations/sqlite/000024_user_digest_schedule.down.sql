@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN digest_hour;
+ALTER TABLE users DROP COLUMN digest_weekday;
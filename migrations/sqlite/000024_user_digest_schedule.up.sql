@@ -0,0 +1,4 @@
+-- When the weekly digest is sent, in the user's timezone: day of the week
+-- (0 = Sunday) and hour of the day.
+ALTER TABLE users ADD COLUMN digest_weekday INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN digest_hour INTEGER NOT NULL DEFAULT 8;
@@ -0,0 +1,11 @@
+ALTER TABLE users DROP COLUMN quiet_hours_end;
+ALTER TABLE users DROP COLUMN quiet_hours_start;
+ALTER TABLE users DROP COLUMN quiet_hours_enabled;
+
+ALTER TABLE users ADD COLUMN notify_email INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN notify_sms INTEGER NOT NULL DEFAULT 0;
+UPDATE users SET notify_email = 1 WHERE id IN (
+    SELECT user_id FROM notification_preferences WHERE category = 'reminders' AND channel = 'email');
+UPDATE users SET notify_sms = 1 WHERE id IN (
+    SELECT user_id FROM notification_preferences WHERE category = 'reminders' AND channel = 'sms');
+DELETE FROM notification_preferences WHERE category = 'reminders';
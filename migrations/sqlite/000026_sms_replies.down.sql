@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_phone;
+ALTER TABLE task_notifications DROP COLUMN task_ids;
+ALTER TABLE users DROP COLUMN sms_opted_out;
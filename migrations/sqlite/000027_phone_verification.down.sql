@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN phone_code_attempts;
+ALTER TABLE users DROP COLUMN phone_code_expires_at;
+ALTER TABLE users DROP COLUMN phone_code_sent_at;
+ALTER TABLE users DROP COLUMN phone_code_hash;
+ALTER TABLE users DROP COLUMN phone_verified_at;
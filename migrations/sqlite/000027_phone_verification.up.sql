@@ -0,0 +1,8 @@
+-- Phone numbers are verified with a code texted to them before any other
+-- SMS is sent. Only the code's hash is stored. Existing numbers start
+-- unverified.
+ALTER TABLE users ADD COLUMN phone_verified_at TEXT;
+ALTER TABLE users ADD COLUMN phone_code_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN phone_code_sent_at TEXT;
+ALTER TABLE users ADD COLUMN phone_code_expires_at TEXT;
+ALTER TABLE users ADD COLUMN phone_code_attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Extra email headers, such as List-Unsubscribe, one "Name: value" per
+-- line.
+ALTER TABLE notification_outbox ADD COLUMN headers TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+ALTER TABLE notification_outbox DROP COLUMN push_click;
+ALTER TABLE notification_outbox DROP COLUMN push_tags;
+ALTER TABLE notification_outbox DROP COLUMN push_priority;
+DELETE FROM notification_preferences WHERE channel = 'push';
+ALTER TABLE users DROP COLUMN push_token;
+ALTER TABLE users DROP COLUMN push_url;
+ALTER TABLE users DROP COLUMN push_service;
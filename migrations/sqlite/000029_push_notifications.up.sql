@@ -0,0 +1,11 @@
+-- Where each user's push notifications are posted: an ntfy topic or a
+-- Gotify server, and its access token.
+ALTER TABLE users ADD COLUMN push_service TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN push_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN push_token TEXT NOT NULL DEFAULT '';
+
+-- How each queued push message is shown. The target and its token are
+-- looked up when the message is sent, so they are never queued.
+ALTER TABLE notification_outbox ADD COLUMN push_priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE notification_outbox ADD COLUMN push_tags TEXT NOT NULL DEFAULT '';
+ALTER TABLE notification_outbox ADD COLUMN push_click TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,24 @@
+-- A ledger of every change to a chemical's stock. The stock on the
+-- chemical is the sum of its movements' amounts.
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    chemical_id TEXT NOT NULL REFERENCES chemicals(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    amount REAL NOT NULL,
+    unit TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    cost REAL,
+    chemistry_log_id TEXT REFERENCES chemistry_logs(id) ON DELETE SET NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_stock_movements_chemical ON stock_movements(chemical_id, created_at);
+CREATE INDEX idx_stock_movements_user_id ON stock_movements(user_id);
+
+-- Existing stock opens each chemical's ledger. A chemical has one opening
+-- movement, so it reuses the chemical's ID.
+INSERT INTO stock_movements (id, user_id, chemical_id, kind, amount, unit, reason, created_at)
+SELECT id, user_id, id, 'adjustment', stock_amount, stock_unit, 'Opening stock', created_at
+FROM chemicals
+WHERE stock_amount <> 0;
@@ -0,0 +1 @@
+ALTER TABLE chemicals DROP COLUMN daily_usage;